@@ -8,6 +8,7 @@
 //
 //	rocketype                                    # Start with random text from default location
 //	rocketype --texts-dir ~/my-texts             # Use custom texts directory
+//	rocketype --texts-dir ~/texts,~/work-texts   # Load texts from several directories
 //	cat myfile.txt | rocketype                   # Practice with custom text via stdin
 //	echo "custom text" | rocketype               # Practice with inline text
 //
@@ -33,9 +34,27 @@ import (
 	"baumeister.de/rocketype/internal"
 )
 
+// textsDirsFlag collects --texts-dir values. A value may list several
+// comma-separated paths, and the flag may be repeated.
+type textsDirsFlag []string
+
+func (f *textsDirsFlag) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *textsDirsFlag) Set(value string) error {
+	for _, dir := range strings.Split(value, ",") {
+		if dir = strings.TrimSpace(dir); dir != "" {
+			*f = append(*f, dir)
+		}
+	}
+	return nil
+}
+
 func main() {
 	// Define command-line flags
-	textsDir := flag.String("texts-dir", "", "Path to texts directory (overrides platform default)")
+	var textsDirs textsDirsFlag
+	flag.Var(&textsDirs, "texts-dir", "Path to texts directory (overrides platform default); comma-separate or repeat for several")
 	printPaths := flag.Bool("print-paths", false, "Print default paths and exit")
 	restoreSession := flag.Bool("restore-session", true, "Restore previous session on startup (default: true)")
 
@@ -52,16 +71,31 @@ func main() {
 		fmt.Fprintf(os.Stderr, "\nExamples:\n")
 		fmt.Fprintf(os.Stderr, "  %s                           # Use default texts location\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "  %s --texts-dir ~/my-texts   # Use custom directory\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s --texts-dir ~/a,~/b      # Use several directories\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "  cat file.txt | %s           # Practice with piped text\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "  %s --restore-session=false  # Start fresh, ignore saved session\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "\nEnvironment (overrides config files):\n")
+		fmt.Fprintf(os.Stderr, "  ROCKETYPE_THEME       Theme name\n")
+		fmt.Fprintf(os.Stderr, "  ROCKETYPE_MODE        text or words\n")
+		fmt.Fprintf(os.Stderr, "  ROCKETYPE_TIME_LIMIT  Time limit in seconds for words mode\n")
 		fmt.Fprintf(os.Stderr, "\nKeyboard shortcuts:\n")
 		fmt.Fprintf(os.Stderr, "  Ctrl+P     - Open command menu\n")
 		fmt.Fprintf(os.Stderr, "  Ctrl+T     - Cycle themes\n")
+		fmt.Fprintf(os.Stderr, "  Ctrl+Y     - Toggle between last two themes\n")
+		fmt.Fprintf(os.Stderr, "  Ctrl+U     - Clear current word\n")
 		fmt.Fprintf(os.Stderr, "  Ctrl+C/Esc - Quit\n")
 	}
 
 	flag.Parse()
 
+	// An explicit --restore-session decides without asking on startup
+	restoreForced := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == "restore-session" {
+			restoreForced = true
+		}
+	})
+
 	// If user wants to see paths, print and exit
 	if *printPaths {
 		defaultDir, err := internal.GetDefaultTextsDir()
@@ -74,12 +108,10 @@ func main() {
 		os.Exit(0)
 	}
 
-	// Determine which texts directory to use
-	var finalTextsDir string
-	if *textsDir != "" {
-		// User specified a custom directory
-		finalTextsDir = *textsDir
-	} else {
+	// Determine which texts directories to use
+	if len(textsDirs) == 0 {
+		var finalTextsDir string
+
 		// Try platform default first
 		defaultDir, err := internal.GetDefaultTextsDir()
 		if err != nil {
@@ -98,6 +130,7 @@ func main() {
 				}
 			}
 		}
+		textsDirs = textsDirsFlag{finalTextsDir}
 	}
 
 	// Check if input is being piped via stdin
@@ -118,15 +151,22 @@ func main() {
 	}
 
 	// Create and initialize the application
-	app, err := internal.NewApp(stdinText, finalTextsDir, *restoreSession)
+	app, err := internal.NewApp(stdinText, textsDirs, *restoreSession, restoreForced)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error creating app: %v\n", err)
 		os.Exit(1)
 	}
 
 	// Run the main event loop (blocks until quit)
-	if err := app.Run(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error running app: %v\n", err)
+	runErr := app.Run()
+
+	// Report ignored config problems once the terminal is restored
+	for _, warning := range app.Warnings() {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+	}
+
+	if runErr != nil {
+		fmt.Fprintf(os.Stderr, "Error running app: %v\n", runErr)
 		os.Exit(1)
 	}
 }
@@ -0,0 +1,165 @@
+package internal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// AchievementContext is what achievement rules are evaluated against when a
+// test is completed.
+type AchievementContext struct {
+	WPM        float64
+	Accuracy   float64
+	Keystrokes int // All keys typed in the test, including mistakes
+	DayStreak  int // Consecutive days with a finished test, including today
+}
+
+// Achievement is a badge unlocked once its rule is met by a completed test.
+type Achievement struct {
+	ID          string // Stable key in achievements.json
+	Name        string
+	Description string
+	unlocked    func(ctx AchievementContext) bool
+}
+
+// Achievements lists all badges in the order they are shown.
+var Achievements = []Achievement{
+	{
+		ID:          "first-test",
+		Name:        "First Steps",
+		Description: "Finish a test",
+		unlocked:    func(ctx AchievementContext) bool { return ctx.Keystrokes > 0 },
+	},
+	{
+		ID:          "wpm-60",
+		Name:        "60 WPM",
+		Description: "Finish a test at 60 WPM or faster",
+		unlocked:    func(ctx AchievementContext) bool { return ctx.WPM >= 60 },
+	},
+	{
+		ID:          "wpm-100",
+		Name:        "100 WPM",
+		Description: "Finish a test at 100 WPM or faster",
+		unlocked:    func(ctx AchievementContext) bool { return ctx.WPM >= 100 },
+	},
+	{
+		ID:          "perfect-run",
+		Name:        "Perfect Run",
+		Description: "Finish a test without a single mistake",
+		unlocked:    func(ctx AchievementContext) bool { return ctx.Keystrokes > 0 && ctx.Accuracy >= 100 },
+	},
+	{
+		ID:          "streak-7",
+		Name:        "7-Day Streak",
+		Description: "Finish a test on seven days in a row",
+		unlocked:    func(ctx AchievementContext) bool { return ctx.DayStreak >= 7 },
+	},
+}
+
+// EvaluateAchievements returns all achievements whose rules the context meets,
+// whether or not they were unlocked before.
+func EvaluateAchievements(ctx AchievementContext) []Achievement {
+	var met []Achievement
+	for _, achievement := range Achievements {
+		if achievement.unlocked(ctx) {
+			met = append(met, achievement)
+		}
+	}
+	return met
+}
+
+// AchievementManager handles loading and updating the unlocked achievements.
+type AchievementManager struct {
+	achievementsPath string
+	mu               sync.Mutex // Serializes read-modify-write cycles of the file
+}
+
+// NewAchievementManager creates a new achievement manager.
+// It uses the platform-appropriate config directory.
+func NewAchievementManager() (*AchievementManager, error) {
+	achievementsPath, err := GetAchievementsPath()
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve achievements path: %w", err)
+	}
+
+	return &AchievementManager{
+		achievementsPath: achievementsPath,
+	}, nil
+}
+
+// Load returns when each unlocked achievement was unlocked, keyed by ID.
+// A missing file yields no achievements. If the file is corrupt, the backup
+// kept by the last save is used instead; if that is unusable too, no
+// achievements are returned along with an error.
+func (m *AchievementManager) Load() (map[string]time.Time, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.load()
+}
+
+// Unlock evaluates the rules for a completed test and records the achievements
+// that are met. Only achievements that were not unlocked before are returned,
+// so each badge is announced once.
+func (m *AchievementManager) Unlock(ctx AchievementContext, now time.Time) ([]Achievement, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	unlocked, loadErr := m.load()
+	var fresh []Achievement
+	for _, achievement := range EvaluateAchievements(ctx) {
+		if _, ok := unlocked[achievement.ID]; !ok {
+			unlocked[achievement.ID] = now
+			fresh = append(fresh, achievement)
+		}
+	}
+	if len(fresh) == 0 {
+		return nil, loadErr
+	}
+
+	data, err := json.MarshalIndent(unlocked, "", "  ")
+	if err != nil {
+		return fresh, fmt.Errorf("failed to marshal achievements: %w", err)
+	}
+	if err := writeFileAtomic(m.achievementsPath, data, 0644); err != nil {
+		return fresh, fmt.Errorf("failed to save achievements: %w", err)
+	}
+	return fresh, loadErr
+}
+
+// load implements Load; the caller must hold m.mu.
+func (m *AchievementManager) load() (map[string]time.Time, error) {
+	unlocked, err := readAchievements(m.achievementsPath)
+	if err == nil {
+		return unlocked, nil
+	}
+	if errors.Is(err, os.ErrNotExist) {
+		return make(map[string]time.Time), nil
+	}
+
+	backup, backupErr := readAchievements(m.achievementsPath + ".bak")
+	if backupErr == nil {
+		return backup, fmt.Errorf("achievements corrupt, restored from backup: %w", err)
+	}
+	return make(map[string]time.Time), fmt.Errorf("achievements corrupt, starting over: %w", err)
+}
+
+// readAchievements reads and parses one achievements file.
+func readAchievements(path string) (map[string]time.Time, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var unlocked map[string]time.Time
+	if err := json.Unmarshal(data, &unlocked); err != nil {
+		return nil, err
+	}
+	if unlocked == nil {
+		unlocked = make(map[string]time.Time)
+	}
+	return unlocked, nil
+}
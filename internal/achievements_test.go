@@ -0,0 +1,96 @@
+package internal
+
+import (
+	"testing"
+	"time"
+)
+
+// achievementIDs returns the IDs of achievements in order.
+func achievementIDs(achievements []Achievement) []string {
+	ids := make([]string, len(achievements))
+	for i, achievement := range achievements {
+		ids[i] = achievement.ID
+	}
+	return ids
+}
+
+func hasAchievement(achievements []Achievement, id string) bool {
+	for _, achievement := range achievements {
+		if achievement.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+func TestEvaluateAchievementsPerfectRun(t *testing.T) {
+	tests := []struct {
+		name     string
+		ctx      AchievementContext
+		expected bool
+	}{
+		{"perfect", AchievementContext{WPM: 40, Accuracy: 100, Keystrokes: 200}, true},
+		{"one mistake", AchievementContext{WPM: 40, Accuracy: 99.5, Keystrokes: 200}, false},
+		{"nothing typed", AchievementContext{Accuracy: 100}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			met := EvaluateAchievements(tt.ctx)
+			if got := hasAchievement(met, "perfect-run"); got != tt.expected {
+				t.Errorf("Expected perfect-run %v, got %v", tt.expected, achievementIDs(met))
+			}
+		})
+	}
+}
+
+func TestEvaluateAchievementsThresholds(t *testing.T) {
+	met := EvaluateAchievements(AchievementContext{WPM: 100, Accuracy: 95, Keystrokes: 300, DayStreak: 7})
+	for _, id := range []string{"first-test", "wpm-60", "wpm-100", "streak-7"} {
+		if !hasAchievement(met, id) {
+			t.Errorf("Expected %s to be met, got %v", id, achievementIDs(met))
+		}
+	}
+
+	met = EvaluateAchievements(AchievementContext{WPM: 99.9, Accuracy: 95, Keystrokes: 300, DayStreak: 6})
+	if hasAchievement(met, "wpm-100") || hasAchievement(met, "streak-7") {
+		t.Errorf("Expected no 100 WPM or streak badge just below the thresholds, got %v", achievementIDs(met))
+	}
+}
+
+func TestAchievementManagerNotifiesOnce(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	manager, err := NewAchievementManager()
+	if err != nil {
+		t.Fatalf("failed to create achievement manager: %v", err)
+	}
+	now := time.Date(2024, time.March, 4, 12, 0, 0, 0, time.UTC)
+	perfect := AchievementContext{WPM: 40, Accuracy: 100, Keystrokes: 200}
+
+	unlocked, err := manager.Unlock(perfect, now)
+	if err != nil {
+		t.Fatalf("failed to unlock: %v", err)
+	}
+	if !hasAchievement(unlocked, "perfect-run") {
+		t.Fatalf("Expected perfect-run to be unlocked, got %v", achievementIDs(unlocked))
+	}
+
+	// Already unlocked badges are not announced again, even from a new manager
+	manager, _ = NewAchievementManager()
+	unlocked, err = manager.Unlock(perfect, now.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("failed to unlock: %v", err)
+	}
+	if len(unlocked) != 0 {
+		t.Errorf("Expected no new achievements, got %v", achievementIDs(unlocked))
+	}
+
+	stored, err := manager.Load()
+	if err != nil {
+		t.Fatalf("failed to load: %v", err)
+	}
+	if !stored["perfect-run"].Equal(now) {
+		t.Errorf("Expected the first unlock time to be kept, got %v", stored["perfect-run"])
+	}
+}
@@ -2,7 +2,11 @@ package internal
 
 import (
 	"fmt"
+	"math/rand"
+	"net/http"
 	"os"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -24,30 +28,92 @@ type App struct {
 	typingTest      *TypingTest
 	inputHandler    *InputHandler
 	commandMenu     *CommandMenu
+	textBrowser     *TextBrowser
 	textLibrary     *TextLibrary
 	wordLibrary     *WordLibrary
 	sessionManager  *SessionManager
 	settingsManager *SettingsManager
+	xpManager       *XPManager          // nil if the profile path could not be resolved
+	achievements    *AchievementManager // nil if the achievements path could not be resolved
 
 	// State
-	theme       Theme
-	screen      tcell.Screen
-	quit        bool
-	showResults bool
+	theme           Theme
+	previousTheme   Theme // Theme active before the last theme change (for quick toggling)
+	screen          tcell.Screen
+	quit            bool
+	showResults     bool
+	showDiagnostics bool
+	showLineTimings bool // Per-line timing view of the results screen
+
+	// Progress report overlay
+	showReport    bool
+	reportPeriod  ReportPeriod
+	reportResults []TestResult // Results history loaded when the report was opened
+
+	// Lifetime stats overlay
+	showLifetime bool
+	lifetime     LifetimeStats // Totals loaded when the overlay was opened
+
+	confirm      *confirmation // Pending question or notice (nil when none is shown)
+	resumePrompt bool          // Asking whether to resume the saved session (see ShouldPromptResume)
+	resumeText   string        // Name of the text added to the library for the saved session, if any
+
+	lastXP           XPAward       // XP awarded for the last finished test
+	lastAchievements []Achievement // Achievements first unlocked by the last finished test
+	previousBest     float64       // Best WPM of the earlier results of the last finished test's text and mode (0 if none)
 
 	// Mode settings
-	mode              string    // "text" or "words"
+	mode              string    // "text", "words" or "quote"
 	limitType         string    // "time" or "words"
 	timeLimit         int       // Time limit in seconds
 	wordLimit         int       // Word count limit
 	testStarted       time.Time // When test was started (for time limit)
 	lastCheckPosition int       // Last cursor position when we checked for more words (optimization)
 
+	// Word mode generation runs off the main loop; batches are merged in Run
+	generatedWords chan wordBatch // Receives the words of the pending generation
+	wordsPending   bool           // A generation is running and its batch not merged yet
+
+	challenge *Challenge // Pass/fail goal judged on the results screen (nil without one)
+
+	// Preferences without dedicated state above (persisted via currentSettings)
+	settings      Settings
+	savedSettings Settings // Settings as last written to (or read from) settings.json
+	layered       Settings // savedSettings with rocketype.conf and the environment applied
+
+	// Replay state (nil when no replay is running)
+	replay         *Replay
+	replayTest     *TypingTest // Separate test that the replayed input is applied to
+	lastReplayTick time.Time
+
 	// Scroll state for text mode
-	currentScrollLine int // Current scroll position (top visible line)
-	lastCursorLine    int // Last calculated cursor line (to detect line changes)
+	currentScrollLine int  // Current scroll position (top visible line)
+	lastCursorLine    int  // Last calculated cursor line (to detect line changes)
+	reviewing         bool // Home/End moved the viewport away from the cursor
+	reviewAtEnd       bool // While reviewing: show the end (End) instead of the start (Home)
+
+	chromeDimmed bool          // Whether the last frame faded the title, help and stats
+	stopwords    stopwordCache // Stopwords of the typing text, for dimming them
+
+	// Error feedback state
+	lastBell   time.Time // When the terminal bell last rang for a mistake
+	flashUntil time.Time // End of the current error flash
+	flashShown bool      // Whether the last frame showed the error flash
+
+	celebration *Celebration // Confetti after a perfect run (nil when none is playing)
 
 	leaderboards map[string][]LeaderboardEntry
+
+	rand *rand.Rand // Breaks ties when picking the least practiced text
+
+	warnings []string // Non-fatal startup problems, reported after the app exits
+}
+
+// wordBatch is the result of generating more words for word mode in the
+// background.
+type wordBatch struct {
+	base  string // Sample text the words were generated for
+	words string // Space-separated new words
 }
 
 const (
@@ -62,17 +128,40 @@ const (
 	timerUpdateIntervalMS   = 100 // Timer update interval in milliseconds
 	wordLimitMultiplier     = 2   // Multiplier for initial word generation in word limit mode
 	lastCheckPositionOffset = 10  // Don't check for more words until cursor advances by this many characters
+	maxReportPoints         = 12  // Number of most recent days or weeks shown in the progress report
+	rhythmWindowSize        = 10  // Keystroke intervals per point of the rhythm chart
+	quoteHashLength         = 12  // Hex digits of the sentence hash in quote names
+
+	// typingIdleTimeout is how long after the last keystroke typing counts as
+	// idle, which ends the focus fade.
+	typingIdleTimeout = 2 * time.Second
+
+	// typingChromeRows are the rows around the typing text taken by the title,
+	// notice, live WPM line, progress, stats and help text.
+	typingChromeRows = 10
+
+	// maxTypingMargin caps the margins above and below the typing text.
+	maxTypingMargin = 20
 )
 
+// confirmation is a question shown before a destructive action, or a notice
+// when there is no action.
+type confirmation struct {
+	message string
+	action  func() // Runs when the question is answered with yes; nil for a notice
+}
+
 // NewApp creates a new application instance and initializes all components.
 //
 // Parameters:
 //   - stdinText: optional text from stdin (empty string if not provided)
-//   - textsDir: directory path for text files
+//   - textsDirs: directory paths for text files
 //   - restoreSession: whether to attempt to restore a saved session
+//   - restoreForced: whether restoreSession was given explicitly, which skips
+//     the resume prompt
 //
 // Returns an error if the screen cannot be created or initialized.
-func NewApp(stdinText, textsDir string, restoreSession bool) (*App, error) {
+func NewApp(stdinText string, textsDirs []string, restoreSession, restoreForced bool) (*App, error) {
 	screen, err := tcell.NewScreen()
 	if err != nil {
 		return nil, fmt.Errorf("failed to create screen: %w", err)
@@ -82,6 +171,17 @@ func NewApp(stdinText, textsDir string, restoreSession bool) (*App, error) {
 		return nil, fmt.Errorf("failed to initialize screen: %w", err)
 	}
 
+	app, err := newApp(screen, stdinText, textsDirs, restoreSession, restoreForced)
+	if err != nil {
+		screen.Fini()
+		return nil, err
+	}
+	return app, nil
+}
+
+// newApp initializes all components on an already initialized screen.
+// Separated from NewApp so tests can run the app against a simulation screen.
+func newApp(screen tcell.Screen, stdinText string, textsDirs []string, restoreSession, restoreForced bool) (*App, error) {
 	// Initialize session manager
 	sessionManager, err := NewSessionManager()
 	if err != nil {
@@ -94,24 +194,36 @@ func NewApp(stdinText, textsDir string, restoreSession bool) (*App, error) {
 		return nil, fmt.Errorf("failed to create settings manager: %w", err)
 	}
 
+	// XP progression is optional; without a profile path no XP is awarded
+	xpManager, _ := NewXPManager()
+	achievements, _ := NewAchievementManager()
+
 	// Load settings (theme, etc.)
 	settings, err := settingsManager.LoadSettings()
 	if err != nil {
 		// If settings load fails, use defaults
-		settings = &Settings{ThemeName: "default"}
+		defaults := DefaultSettings()
+		settings = &defaults
 	}
 
-	// Resolve theme from settings
-	initialTheme := DefaultTheme
-	for _, theme := range AvailableThemes() {
-		if theme.Name == settings.ThemeName {
-			initialTheme = theme
-			break
-		}
+	// Apply overrides from the optional rc file. The rc file and environment
+	// only apply at runtime; saving writes back just what changed in the app.
+	savedSettings := CloneSettings(*settings)
+	settingsWarnings := settingsManager.Warnings()
+	warnings := slices.Clone(settingsWarnings)
+	if err := LoadRCFile(settings); err != nil {
+		warnings = append(warnings, err.Error())
 	}
 
-	// Load text library
-	textLibrary := NewTextLibrary(textsDir)
+	// Environment variables take precedence over config files
+	warnings = append(warnings, ApplyEnvOverrides(settings)...)
+	layered := CloneSettings(*settings)
+
+	// Resolve theme from settings
+	initialTheme, found := FindTheme(settings.ThemeName)
+	if !found {
+		initialTheme = DefaultTheme
+	}
 
 	// Load word library
 	wordsDir, err := GetDefaultWordsDir()
@@ -119,10 +231,28 @@ func NewApp(stdinText, textsDir string, restoreSession bool) (*App, error) {
 		wordsDir = GetFallbackWordsDir()
 	}
 	wordLibrary := NewWordLibrary(wordsDir)
+	wordLibrary.SetWordCase(WordCase(settings.WordCase))
+	wordLibrary.SetMinWordLength(settings.MinWordLength)
+	wordLibrary.SetHardOnly(settings.HardWordsOnly)
+	wordLibrary.SetGenOptions(WordGenOptions{Punctuation: settings.Punctuation, Capitalization: settings.Capitalization, Numbers: settings.IncludeNumbers})
+
+	// Load text library. Without texts, stdin and word sets there is nothing
+	// to practice, so the sample text is left out and the empty state explains
+	// where to put texts instead.
+	sampleText := defaultSampleText
+	if stdinText == "" && !wordLibrary.HasWordSets() {
+		sampleText = ""
+	}
+	textLibrary := newTextLibrary(textsDirs, sampleText, TextLoadOptions{
+		Pipeline:   settings.Preprocess,
+		ChunkWords: settings.ChunkWords,
+	})
 
 	// Try to restore session if requested and available (unless stdin is provided)
 	var initialText TextSource
 	var typingTest *TypingTest
+	var resumePrompt bool
+	var resumeText string
 
 	// stdin text takes precedence over session restoration, always text mode
 	if stdinText != "" {
@@ -162,8 +292,26 @@ func NewApp(stdinText, textsDir string, restoreSession bool) (*App, error) {
 				session.WordHadError,
 			)
 
-			// Add to library if not already there
-			textLibrary.AddText(initialText)
+			if session.Mode == "words" && wordLibrary.HasWordSets() {
+				// Continue the saved words; the word set supplies more as
+				// the user types on
+				settings.Mode = "words"
+				wordLibrary.SelectByName(session.TextName)
+			} else {
+				if session.Mode == "text" || session.Mode == "quote" {
+					settings.Mode = session.Mode
+				}
+				// Add to library if not already there, and select the library's
+				// copy so the text's front matter applies again
+				if !textLibrary.SelectByName(initialText.Name) {
+					textLibrary.AddText(initialText)
+					textLibrary.SelectByName(initialText.Name)
+					resumeText = initialText.Name
+				}
+			}
+
+			// The session is restored behind the prompt; declining replaces it
+			resumePrompt = ShouldPromptResume(settings.StartupPrompt, true, restoreForced)
 		} else {
 			// Session loading failed, initialize based on mode
 			if settings.Mode == "words" && wordLibrary.HasWordSets() {
@@ -182,6 +330,10 @@ func NewApp(stdinText, textsDir string, restoreSession bool) (*App, error) {
 					Path:    "",
 				}
 				typingTest = NewTypingTest(content)
+			} else if settings.Mode == "quote" {
+				// Quote mode - use a random sentence
+				initialText = textLibrary.SelectRandomQuote()
+				typingTest = NewTypingTest(initialText.Content)
 			} else {
 				// Text mode - use random text
 				settings.Mode = "text"
@@ -207,6 +359,10 @@ func NewApp(stdinText, textsDir string, restoreSession bool) (*App, error) {
 				Path:    "",
 			}
 			typingTest = NewTypingTest(content)
+		} else if settings.Mode == "quote" {
+			// Quote mode - use a random sentence
+			initialText = textLibrary.SelectRandomQuote()
+			typingTest = NewTypingTest(initialText.Content)
 		} else {
 			// Text mode - use random text
 			settings.Mode = "text"
@@ -215,19 +371,34 @@ func NewApp(stdinText, textsDir string, restoreSession bool) (*App, error) {
 		}
 	}
 
+	// Keep the full keystroke log so end-of-test analytics see the whole run
+	typingTest.SetRetainKeystrokeLog(true)
+
 	// Create components
 	renderer := NewRenderer(screen)
+	renderer.SetASCIIMode(ResolveASCIIMode(settings.ASCIIMode, os.Getenv))
+	renderer.SetSpeedUnit(settings.SpeedUnit)
+	renderer.SetTypingMargins(settings.TopMargin, settings.BottomMargin)
 	commandMenu := NewCommandMenu()
+	commandMenu.SetPreviewGenerator(func(wordSet string) string {
+		return wordLibrary.GenerateRandomWordsFrom(wordSet, wordSetPreviewCount)
+	})
+	commandMenu.SetUsageCounts(settings.CommandUsage)
+	textBrowser := NewTextBrowser()
 
 	app := &App{
 		renderer:        renderer,
 		typingTest:      typingTest,
 		commandMenu:     commandMenu,
+		textBrowser:     textBrowser,
 		textLibrary:     textLibrary,
 		wordLibrary:     wordLibrary,
 		sessionManager:  sessionManager,
 		settingsManager: settingsManager,
+		xpManager:       xpManager,
+		achievements:    achievements,
 		theme:           initialTheme,
+		previousTheme:   initialTheme,
 		screen:          screen,
 		quit:            false,
 		showResults:     false,
@@ -236,17 +407,62 @@ func NewApp(stdinText, textsDir string, restoreSession bool) (*App, error) {
 		timeLimit:       settings.TimeLimit,
 		wordLimit:       settings.WordLimit,
 		testStarted:     time.Time{}, // Will be set when typing starts
+		settings:        *settings,
+		savedSettings:   savedSettings,
+		layered:         layered,
+		resumePrompt:    resumePrompt,
+		resumeText:      resumeText,
+		warnings:        warnings,
+		rand:            rand.New(rand.NewSource(time.Now().UnixNano())),
+		generatedWords:  make(chan wordBatch, 1),
 	}
 
+	// Apply the day/night schedule on startup
+	app.applyAutoTheme(time.Now())
+
 	// Initialize input handler with callbacks
 	app.inputHandler = NewInputHandler(
-		func() { app.quit = true },
-		func() { app.toggleCommandMenu() },
-		func() { app.cycleTheme() },
-		func() { app.restartTest() },
+		InputCallbacks{
+			OnQuit:              func() { app.quit = true },
+			OnToggleCommandMenu: func() { app.toggleCommandMenu() },
+			OnCycleTheme:        func() { app.cycleTheme() },
+			OnToggleLastTheme:   func() { app.toggleLastTheme() },
+			OnRestartTest:       func() { app.restartTest() },
+			OnRegenerateWords:   func() { app.regenerateWords() },
+			OnNextTest:          func() { app.nextTest() },
+			OnToggleLimitType:   func() { app.toggleLimitType() },
+			OnTogglePause:       func() { app.togglePause() },
+			OnToggleLiveStats:   func() { app.toggleLiveStats() },
+			OnToggleReplayPause: func() { app.toggleReplayPause() },
+			OnChangeReplaySpeed: func(factor float64) { app.changeReplaySpeed(factor) },
+			OnExitReplay:        func() { app.exitReplay() },
+			OnCloseDiagnostics:  func() { app.showDiagnostics = false },
+			OnToggleLineTimings: func() { app.showLineTimings = !app.showLineTimings },
+			OnCloseReport:       func() { app.showReport = false },
+			OnSetReportPeriod:   func(period ReportPeriod) { app.reportPeriod = period },
+			OnCloseLifetime:     func() { app.showLifetime = false },
+			OnConfirm:           func(yes bool) { app.answerConfirm(yes) },
+			OnAnswerResume:      func(resume bool) { app.answerResume(resume) },
+			OnReviewScroll:      func(toEnd bool) { app.reviewScroll(toEnd) },
+		},
 		typingTest,
 		commandMenu,
+		textBrowser,
 	)
+	app.inputHandler.SetKeymap(app.keymap())
+	app.inputHandler.SetResultsEnterAction(app.settings.ResultsEnterAction)
+	app.applyTestSettings()
+	if app.mode == "text" {
+		app.noteFrontMatterError()
+	}
+	if len(settingsWarnings) > 0 {
+		app.confirm = &confirmation{message: fmt.Sprintf("settings.json: %s, using the default keys", strings.Join(settingsWarnings, "; "))}
+	}
+
+	// Proofreading starts on a copy with typos unless a session was restored
+	if app.settings.Proofread && app.mode == "text" && app.typingTest.GetUserInput() == "" {
+		app.setTextSample(app.typingTest.GetSampleText())
+	}
 
 	// Initialize commands
 	app.initCommands()
@@ -313,20 +529,64 @@ func (a *App) Run() error {
 			case *tcell.EventKey:
 				a.handleKey(ev)
 				a.draw()
+
+			case *tcell.EventInterrupt:
+				if result, ok := ev.Data().(importResult); ok {
+					a.finishImport(result)
+					a.draw()
+				}
 			}
 
+		case batch := <-a.generatedWords:
+			a.mergeWords(batch)
+			a.draw()
+
 		case <-ticker.C:
-			// Periodic updates for word mode
-			if a.mode == "words" && !a.testStarted.IsZero() && !a.typingTest.IsFinished() {
+			// Follow the day/night theme schedule
+			if a.applyAutoTheme(time.Now()) {
+				a.draw()
+			}
+
+			// Fade the UI chrome in or out when typing starts or goes idle
+			if a.chromeDimmed != a.isTypingActive(time.Now()) {
+				a.draw()
+			}
+
+			// Play the celebration
+			if a.celebration != nil {
+				if !a.celebration.Advance() {
+					a.celebration = nil
+				}
+				a.draw()
+			}
+
+			// End the error flash
+			if a.flashShown && !time.Now().Before(a.flashUntil) {
+				a.draw()
+			}
+
+			// Play back due replay events
+			if a.replay != nil {
+				a.tickReplay(time.Now())
+				a.draw()
+			}
+
+			// Periodic updates for word mode (the countdown stands still while paused)
+			if a.mode == "words" && !a.testStarted.IsZero() && !a.typingTest.IsFinished() && !a.typingTest.IsPaused() {
 				// Check if time limit reached
 				if a.limitType == "time" {
-					elapsed := time.Since(a.testStarted).Seconds()
+					elapsed := a.wordModeElapsed()
 					if elapsed >= float64(a.timeLimit) {
 						wasFinished := a.typingTest.IsFinished()
 						a.typingTest.MarkFinished()
 						a.showResults = true
 						if !wasFinished {
 							a.recordLeaderboardEntry()
+							a.recordResult()
+							a.recordLifetimeStats()
+							a.recordXP()
+							a.recordAchievements()
+							a.startCelebration()
 						}
 					}
 				}
@@ -345,22 +605,7 @@ func (a *App) Run() error {
 		_ = a.sessionManager.ClearSession()
 	} else if a.typingTest.GetCursorPos() > 0 {
 		// Test in progress - save session with stats
-		currentText := a.textLibrary.GetCurrentText()
-		stats := a.typingTest.GetStats()
-
-		session := Session{
-			TextName:          currentText.Name,
-			TextContent:       a.typingTest.GetSampleText(),
-			TextPath:          currentText.Path,
-			UserInput:         a.typingTest.GetUserInput(),
-			CursorPos:         a.typingTest.GetCursorPos(),
-			StartTime:         a.typingTest.GetStatsStartTime(),
-			TotalKeystrokes:   a.typingTest.GetTotalKeystrokes(),
-			CorrectKeystrokes: a.typingTest.GetCorrectKeystrokes(),
-			MisspelledWords:   a.typingTest.GetMisspelledWordsMap(),
-			MisspelledOrder:   stats.GetMisspelledWords(),
-			WordHadError:      a.typingTest.GetWordErrorsMap(),
-		}
+		session := CreateSessionFromApp(a)
 		err := a.sessionManager.SaveSession(session)
 		if err != nil {
 			// Log error but don't fail the quit
@@ -369,25 +614,18 @@ func (a *App) Run() error {
 	}
 
 	// Always save settings (theme preference and mode settings persist)
-	currentWordSet := ""
-	if a.mode == "words" {
-		wordSet := a.wordLibrary.GetCurrentWordSet()
-		currentWordSet = wordSet.Name
-	}
-
-	settings := Settings{
-		ThemeName:   a.theme.Name,
-		Mode:        a.mode,
-		LimitType:   a.limitType,
-		TimeLimit:   a.timeLimit,
-		WordLimit:   a.wordLimit,
-		LastWordSet: currentWordSet,
-	}
-	_ = a.settingsManager.SaveSettings(settings)
+	a.saveAllSettings()
 
 	return nil
 }
 
+// Warnings returns non-fatal problems found during startup, such as invalid
+// config values that were ignored. They can't be shown while the screen is
+// active, so callers should print them after Run returns.
+func (a *App) Warnings() []string {
+	return a.warnings
+}
+
 // handleKey routes keyboard events to the input handler with current mode.
 func (a *App) handleKey(ev *tcell.EventKey) {
 	mode := a.getCurrentMode()
@@ -396,11 +634,33 @@ func (a *App) handleKey(ev *tcell.EventKey) {
 	// Special case: command menu execution needs app context
 	if mode == ModeCommandMenu && ev.Key() == tcell.KeyEnter {
 		a.commandMenu.ExecuteSelected(a)
-		a.commandMenu.Hide()
+		a.saveAllSettings() // Persist the updated command usage counts
+		return
+	}
+
+	// Special case: text browser selection needs app context
+	if mode == ModeTextBrowser && ev.Key() == tcell.KeyEnter {
+		a.selectTextFromBrowser()
+		return
+	}
+
+	// Special case: Ctrl+Enter finishes early, which must skip recording the result
+	// below. Only terminals that distinguish it from Enter report the modifier.
+	if mode == ModeTyping && ev.Key() == tcell.KeyEnter && ev.Modifiers()&tcell.ModCtrl != 0 {
+		a.finishTest()
 		return
 	}
 
+	// Any other key brings the cursor back into view after reviewing with Home/End
+	if ev.Key() != tcell.KeyHome && ev.Key() != tcell.KeyEnd {
+		a.reviewing = false
+	}
+
+	mistakes := a.typingTest.GetTotalKeystrokes() - a.typingTest.GetCorrectKeystrokes()
 	a.inputHandler.HandleKey(ev, mode)
+	if mode == ModeTyping && a.typingTest.GetTotalKeystrokes()-a.typingTest.GetCorrectKeystrokes() > mistakes {
+		a.onError(time.Now())
+	}
 
 	// Track test start time for word mode limits
 	if mode == ModeTyping && a.mode == "words" && a.testStarted.IsZero() && a.typingTest.GetCursorPos() > 0 {
@@ -417,7 +677,7 @@ func (a *App) handleKey(ev *tcell.EventKey) {
 		limitReached := false
 
 		if a.limitType == "time" && !a.testStarted.IsZero() {
-			elapsed := time.Since(a.testStarted).Seconds()
+			elapsed := a.wordModeElapsed()
 			if elapsed >= float64(a.timeLimit) {
 				limitReached = true
 			}
@@ -442,25 +702,111 @@ func (a *App) handleKey(ev *tcell.EventKey) {
 		a.showResults = true
 		if !wasFinished {
 			a.recordLeaderboardEntry()
+			a.recordResult()
+			a.recordLifetimeStats()
+			a.recordXP()
+			a.recordAchievements()
+			a.startCelebration()
 		}
 	}
 }
 
+// isEmptyState reports whether there is nothing to practice: no texts (not even
+// the default) and no word sets.
+func (a *App) isEmptyState() bool {
+	return !a.textLibrary.HasTexts() && !a.wordLibrary.HasWordSets()
+}
+
+// hasOverlay reports whether an overlay is drawn on top of the current screen.
+func (a *App) hasOverlay() bool {
+	return a.showDiagnostics || a.showReport || a.showLifetime ||
+		a.textBrowser.IsVisible() || a.commandMenu.IsVisible() ||
+		a.typingTest.IsPaused() || a.confirm != nil || a.resumePrompt
+}
+
 // getCurrentMode determines the current application mode.
 func (a *App) getCurrentMode() AppMode {
+	if a.resumePrompt {
+		return ModeResumePrompt
+	}
+	if a.confirm != nil {
+		return ModeConfirm
+	}
+	if a.typingTest.IsPaused() {
+		return ModePaused
+	}
 	if a.commandMenu.IsVisible() {
 		return ModeCommandMenu
 	}
+	if a.textBrowser.IsVisible() {
+		return ModeTextBrowser
+	}
+	if a.showDiagnostics {
+		return ModeDiagnostics
+	}
+	if a.showReport {
+		return ModeProgressReport
+	}
+	if a.showLifetime {
+		return ModeLifetimeStats
+	}
+	if a.isEmptyState() {
+		return ModeEmpty
+	}
+	if a.replay != nil {
+		return ModeReplay
+	}
+	if a.showResults && a.showLineTimings {
+		return ModeLineTimings
+	}
 	if a.showResults {
 		return ModeResults
 	}
 	return ModeTyping
 }
 
+// onError signals a mistyped key as configured by the error feedback level:
+// the terminal bell rings at most once per bell interval, and the screen edges
+// flash briefly. Blind mode gets no feedback, as it would give mistakes away.
+func (a *App) onError(now time.Time) {
+	if a.settings.BlindMode {
+		return
+	}
+	feedback := ErrorFeedbackFor(a.settings.ErrorFeedback)
+	if feedback.BellInterval > 0 && now.Sub(a.lastBell) >= feedback.BellInterval {
+		_ = a.screen.Beep()
+		a.lastBell = now
+	}
+	if feedback.FlashDuration > 0 {
+		a.flashUntil = now.Add(feedback.FlashDuration)
+	}
+}
+
+// setErrorFeedback sets how mistakes are signaled while typing.
+func (a *App) setErrorFeedback(level string) {
+	a.settings.ErrorFeedback = level
+	a.saveAllSettings()
+}
+
+// isTypingActive reports whether the user is in the middle of typing: the focus
+// fade is enabled, the typing view is shown and the last keystroke was less than
+// typingIdleTimeout ago.
+func (a *App) isTypingActive(now time.Time) bool {
+	if !a.settings.FocusFade || a.getCurrentMode() != ModeTyping || a.typingTest.IsFinished() {
+		return false
+	}
+	last := a.typingTest.GetStats().GetLastKeystrokeTime()
+	return !last.IsZero() && now.Sub(last) < typingIdleTimeout
+}
+
 // draw renders the entire UI using the Renderer.
 func (a *App) draw() {
+	a.chromeDimmed = a.isTypingActive(time.Now())
+
 	a.renderer.Clear()
-	a.renderer.FillBackground(a.theme.Background)
+	if !a.settings.TransparentBackground {
+		a.renderer.FillBackground(a.theme.Background)
+	}
 
 	// Draw title with mode information
 	var textName string
@@ -474,31 +820,89 @@ func (a *App) draw() {
 		} else {
 			modeInfo = fmt.Sprintf("words mode, %d words", a.wordLimit)
 		}
+	} else if a.isEmptyState() {
+		textName = "no texts"
 	} else {
 		currentText := a.textLibrary.GetCurrentText()
 		textName = currentText.Name
 		modeInfo = ""
 	}
 
-	a.renderer.DrawTitle(a.theme.Name, textName, modeInfo, a.theme)
+	a.renderer.DrawTitle(a.theme.Name, textName, modeInfo, a.displayTheme(), a.chromeDimmed)
 
 	// Draw main content
-	if a.showResults {
+	if a.isEmptyState() {
+		a.renderer.DrawEmptyState(EmptyStateData{
+			TextsDirs: a.textLibrary.GetTextsDirs(),
+			WordsDir:  a.wordLibrary.GetWordsDir(),
+			Help:      a.keymap().EmptyStateHelp(),
+			Theme:     a.displayTheme(),
+		})
+	} else if a.replay != nil {
+		a.drawReplayScreen()
+	} else if a.showResults && a.showLineTimings {
+		a.drawLineTimingsScreen()
+	} else if a.showResults {
 		a.drawResultsScreen()
 	} else {
-		a.drawTypingScreen()
+		a.startTimerOnLoad()
+		a.drawTypingScreen(a.typingTest)
 	}
 
 	// Draw overlays (always on top)
+	if a.settings.DimBehindOverlays && a.hasOverlay() {
+		a.renderer.DimBackground(a.displayTheme())
+	}
+	if a.showDiagnostics {
+		a.renderer.DrawDiagnostics(DiagnosticsData{
+			Info:  GatherDiagnostics(a.screen),
+			Theme: a.displayTheme(),
+		})
+	}
+	if a.showReport {
+		a.drawProgressReportOverlay()
+	}
+	if a.showLifetime {
+		a.renderer.DrawLifetimeStats(LifetimeStatsData{Stats: a.lifetime, Theme: a.displayTheme()})
+	}
+	if a.textBrowser.IsVisible() {
+		a.drawTextBrowserOverlay()
+	}
 	if a.commandMenu.IsVisible() {
 		a.drawCommandMenuOverlay()
 	}
+	if a.typingTest.IsPaused() {
+		a.renderer.DrawPausedBanner(a.displayTheme())
+	}
+	if a.confirm != nil {
+		a.renderer.DrawConfirm(ConfirmData{
+			Message:  a.confirm.message,
+			Question: a.confirm.action != nil,
+			Theme:    a.displayTheme(),
+		})
+	}
+	if a.resumePrompt {
+		a.renderer.DrawConfirm(ConfirmData{
+			Message:  "Resume previous session? (y/n)",
+			Question: true,
+			Theme:    a.displayTheme(),
+		})
+	}
+
+	if a.celebration != nil && a.showResults {
+		a.renderer.DrawConfetti(a.celebration.Frame(), a.displayTheme())
+	}
+
+	a.flashShown = time.Now().Before(a.flashUntil)
+	if a.flashShown {
+		a.renderer.DrawErrorFlash(ErrorFeedbackFor(a.settings.ErrorFeedback).FlashStyle(a.displayTheme()))
+	}
 
 	a.renderer.Show()
 }
 
-// drawTypingScreen renders the typing test interface.
-func (a *App) drawTypingScreen() {
+// drawTypingScreen renders the typing test interface for the given test.
+func (a *App) drawTypingScreen(test *TypingTest) {
 	width, height := a.screen.Size()
 
 	// Calculate text wrapping parameters
@@ -509,7 +913,7 @@ func (a *App) drawTypingScreen() {
 	}
 
 	// Calculate available height and visible lines
-	availableHeight := height - 8
+	availableHeight := TypingAvailableHeight(height, a.settings.TopMargin, a.settings.BottomMargin)
 	maxVisibleLines := availableHeight / 2 // 2 screen rows per text line
 
 	// In word mode, only show 2 lines below cursor
@@ -518,16 +922,16 @@ func (a *App) drawTypingScreen() {
 	}
 
 	// Get cached rune slices (no conversion needed!)
-	sampleRunes := a.typingTest.GetSampleRunes()
-	cursorPos := a.typingTest.GetCursorPos()
-
-	// Calculate which line the cursor is on (use string for wrapping)
-	sampleText := a.typingTest.GetSampleText()
-	cursorLine := CalculateCursorLine(sampleText, cursorPos, maxWidth)
+	sampleRunes := test.GetSampleRunes()
+	displayRunes := test.GetDisplayRunes()
+	cursorPos := test.GetCursorPos()
+	sampleText := test.GetSampleText()
 
-	// Calculate total wrapped lines
-	lines := wrapText(sampleText, maxWidth)
-	totalLines := len(lines)
+	// Find the cursor line in the wrapped text as it is drawn; the renderer
+	// caches the layout, so the text is only wrapped when it changes
+	layout := a.renderer.textLayout(displayRunes, maxWidth)
+	cursorLine := cursorLineAt(layout.starts, cursorPos)
+	totalLines := len(layout.starts)
 
 	// Calculate scroll position
 	var scrollLine int
@@ -542,33 +946,91 @@ func (a *App) drawTypingScreen() {
 			// After reaching middle, keep cursor on middle line and scroll the text
 			scrollLine = cursorLine - wordModeCursorLine
 		}
-	} else {
+	} else if a.settings.ScrollAnchor == ScrollAnchorSmooth {
 		// In text mode, use smooth scrolling that only adjusts when necessary
 		scrollLine = a.calculateSmoothScroll(cursorLine, maxVisibleLines, totalLines)
+	} else {
+		// Keep the cursor at a fixed position in the viewport
+		scrollLine = CalculateScrollLine(cursorLine, maxVisibleLines, totalLines, a.settings.ScrollAnchor)
+		a.currentScrollLine = scrollLine
+		a.lastCursorLine = cursorLine
+	}
+
+	// Home/End review the text without moving the cursor
+	if a.reviewing && test == a.typingTest {
+		scrollLine = ReviewScrollLine(a.reviewAtEnd, maxVisibleLines, totalLines)
+	}
+
+	// When proofreading, the input is checked against the hidden text
+	var targetRunes []rune
+	if test.IsProofreading() {
+		targetRunes = sampleRunes
+	}
+
+	// Dim function words so the eyes jump to content words
+	var stopwordMask []bool
+	if a.settings.DimStopwords {
+		stopwordMask = a.stopwords.get(displayRunes)
 	}
 
 	// Draw typing view with cached rune slices
 	viewData := TypingViewData{
-		SampleText:  sampleText,
-		SampleRunes: sampleRunes,
-		UserInput:   a.typingTest.GetUserInput(),
-		UserRunes:   a.typingTest.GetUserRunes(),
-		CursorPos:   cursorPos,
-		ScrollLine:  scrollLine,
-		Theme:       a.theme,
-		WordMode:    a.mode == "words",
+		SampleText:          sampleText,
+		SampleRunes:         displayRunes,
+		TargetRunes:         targetRunes,
+		UserInput:           test.GetUserInput(),
+		UserRunes:           test.GetUserRunes(),
+		CursorPos:           cursorPos,
+		ScrollLine:          scrollLine,
+		Theme:               a.displayTheme(),
+		WordMode:            a.mode == "words",
+		BoldText:            a.settings.BoldText,
+		UnderlineWhitespace: a.settings.UnderlineWhitespace,
+		ShowMistypedOverlay: a.settings.ShowMistypedOverlay,
+		ShowLineNumbers:     a.settings.ShowLineNumbers && a.mode != "words",
+		LineNumbering:       a.settings.LineNumbering,
+		BlindMode:           a.settings.BlindMode,
+		StopwordMask:        stopwordMask,
+		HorizontalFocus:     a.settings.HorizontalFocus,
+		Generating:          a.wordsPending && a.settings.GenerationIndicator,
 	}
 	a.renderer.DrawTypingView(viewData)
 
-	// Draw stats
-	stats := a.typingTest.GetStats()
-	a.renderer.DrawStats(stats.GetWPM(), stats.GetAccuracy(), a.theme)
+	// Draw the current word enlarged for focus
+	if a.settings.BigWord && a.mode == "words" {
+		word, typed := test.GetCurrentWord()
+		a.renderer.DrawCurrentWord(word, typed, a.displayTheme(), a.settings.BlindMode)
+	}
+
+	// Draw stats (unless hidden to race without watching the numbers)
+	stats := test.GetStats()
+	switch {
+	case !a.settings.ShowLiveStats:
+	case a.settings.BlindMode:
+		// Live accuracy would give the mistakes away
+		a.renderer.DrawWPM(stats.GetWPM(), a.displayTheme(), a.chromeDimmed)
+	default:
+		// Word errors are only flagged while errors are tracked
+		wordAccuracy := -1.0
+		if a.testSettings().TrackErrors {
+			wordAccuracy = stats.GetWordAccuracy()
+		}
+		a.renderer.DrawStats(stats.GetWPM(), stats.GetAccuracy(), wordAccuracy, stats.GetCombo(), stats.GetBestCombo(), a.displayTheme(), a.chromeDimmed)
+	}
+
+	// Draw the recent WPM trend on its own row below the text; hiding the
+	// live stats hides it too
+	if a.settings.ShowLiveStats && a.settings.ShowSparkline && a.replay == nil {
+		values := recentWPM(stats)
+		width, height := a.screen.Size()
+		a.renderer.DrawLiveWPM(width/2-LiveWPMWidth(len(values))/2, height-liveWPMRowFromBottom, values, a.displayTheme())
+	}
 
 	// Draw progress for word mode
-	if a.mode == "words" && !a.testStarted.IsZero() {
+	if a.settings.ShowLiveStats && a.replay == nil && a.mode == "words" && !a.testStarted.IsZero() {
 		var progressText string
 		if a.limitType == "time" {
-			elapsed := time.Since(a.testStarted).Seconds()
+			elapsed := a.wordModeElapsed()
 			remaining := float64(a.timeLimit) - elapsed
 			if remaining < 0 {
 				remaining = 0
@@ -576,14 +1038,46 @@ func (a *App) drawTypingScreen() {
 			progressText = fmt.Sprintf("Time: %.1fs", remaining)
 		} else {
 			// Count words typed
-			wordsTyped := len(strings.Fields(a.typingTest.GetUserInput()))
+			wordsTyped := len(strings.Fields(test.GetUserInput()))
 			progressText = fmt.Sprintf("Words: %d / %d", wordsTyped, a.wordLimit)
 		}
-		a.renderer.DrawProgress(progressText, a.theme)
+		a.renderer.DrawProgress(progressText, a.displayTheme())
+	}
+
+	// Prompt to fix the remaining errors when an exact finish is required
+	if test.IsAwaitingCorrection() {
+		a.renderer.DrawNotice("Not exact yet - fix the errors with Backspace to finish", a.displayTheme())
 	}
 
 	// Draw help text
-	a.renderer.DrawHelpText(a.theme)
+	a.renderer.DrawHelpText(a.keymap().TypingHelp(a.mode == "words"), a.displayTheme(), a.chromeDimmed)
+}
+
+// recentWPM returns the WPM of the last sparklineLength timeline snapshots,
+// oldest first.
+func recentWPM(stats *Stats) []float64 {
+	history := stats.GetWPMHistory()
+	history = history[max(0, len(history)-sparklineLength):]
+	values := make([]float64, len(history))
+	for i, snapshot := range history {
+		values[i] = snapshot.WPM
+	}
+	return values
+}
+
+// drawReplayScreen renders the replayed typing view with playback status.
+func (a *App) drawReplayScreen() {
+	a.drawTypingScreen(a.replayTest)
+
+	status := fmt.Sprintf("REPLAY %.2gx", a.replay.Speed())
+	switch {
+	case a.replay.IsDone():
+		status += "  (finished)"
+	case a.replay.IsPaused():
+		status += "  (paused)"
+	}
+	status += "  |  Space: pause  |  +/-: speed  |  Esc: back"
+	a.renderer.DrawProgress(status, a.displayTheme())
 }
 
 // drawResultsScreen renders the results screen.
@@ -600,28 +1094,93 @@ func (a *App) drawResultsScreen() {
 	}
 
 	resultsData := ResultsData{
-		WPM:             stats.GetWPM(),
-		Accuracy:        stats.GetAccuracy(),
-		MisspelledWords: misspelledWords,
-		WordCounts:      wordCounts,
-		WPMHistory:      stats.GetWPMHistory(),
-		ErrorTimestamps: stats.GetErrorTimestamps(),
-		Leaderboard:     leaderboardEntries,
-		Theme:           a.theme,
+		WPM:              stats.GetWPM(),
+		RawWPM:           stats.GetRawWPM(),
+		Consistency:      stats.GetConsistency(),
+		Accuracy:         stats.GetAccuracy(),
+		CategoryAccuracy: stats.GetCategoryAccuracy(),
+		MisspelledWords:  misspelledWords,
+		WordCounts:       wordCounts,
+		WPMHistory:       stats.GetWPMHistory(),
+		ErrorTimestamps:  stats.GetErrorTimestamps(),
+		Duration:         stats.GetDuration(),
+		CompletedAt:      stats.GetEndTime(),
+		CorrectedWords:   stats.GetCorrectedWordCount(),
+		BestCombo:        stats.GetBestCombo(),
+		XP:               a.lastXP,
+		Achievements:     a.lastAchievements,
+		PreviousBest:     a.previousBest,
+		CapsLockHint:     DetectCapsLockPattern(stats.GetMistakes()),
+		Leaderboard:      leaderboardEntries,
+		ShowGraph:        a.settings.ShowGraph,
+		HideMisspelled:   !a.testSettings().TrackErrors,
+		HelpText:         a.resultsFooter(),
+		LatencyCounts:    stats.GetLatencyHistogram(LatencyBuckets),
+		RhythmSeries:     stats.GetRhythmSeries(rhythmWindowSize),
+		Theme:            a.displayTheme(),
+	}
+	if a.challenge != nil {
+		passed, reason := EvaluateChallenge(stats, *a.challenge)
+		resultsData.Challenge = &ChallengeOutcome{Passed: passed, Reason: reason}
 	}
 	a.renderer.DrawResults(resultsData)
 }
 
+// resultsFooter returns the custom results help text, or one generated from the
+// keymap so remapped keys are shown correctly.
+func (a *App) resultsFooter() string {
+	if a.settings.ResultsFooter != "" {
+		return a.settings.ResultsFooter
+	}
+	return a.keymap().ResultsFooter(a.mode == "words", a.settings.ResultsEnterAction == ResultsEnterNext)
+}
+
+// keymap returns the key bindings with the user's overrides applied.
+func (a *App) keymap() Keymap {
+	return DefaultKeymap().WithOverrides(a.settings.Keymap)
+}
+
+// drawLineTimingsScreen renders the typing speed for each line of the sample text.
+func (a *App) drawLineTimingsScreen() {
+	boundaries := a.typingTest.GetLineBoundaries()
+	wpms := a.typingTest.GetStats().GetSegmentWPMs(boundaries)
+	sample := a.typingTest.GetSampleRunes()
+
+	lines := make([]LineTiming, len(wpms))
+	for i, wpm := range wpms {
+		lines[i] = LineTiming{
+			Text: strings.TrimRight(string(sample[boundaries[i]:boundaries[i+1]]), "\n"),
+			WPM:  wpm,
+		}
+	}
+
+	a.renderer.DrawLineTimings(LineTimingsData{
+		Lines: lines,
+		Theme: a.displayTheme(),
+	})
+}
+
 func (a *App) getLeaderboardKey() string {
 	if a.mode == "words" {
 		wordSet := a.wordLibrary.GetCurrentWordSet()
 		return fmt.Sprintf("words:%s", wordSet.Name)
 	}
 
+	if a.mode == "quote" {
+		return fmt.Sprintf("quote:%s", a.quoteName())
+	}
 	currentText := a.textLibrary.GetCurrentText()
 	return fmt.Sprintf("text:%s", currentText.Name)
 }
 
+// quoteName names the current quote in leaderboards and the results history:
+// the name of its text with a short hash of the sentence, so every sentence of
+// a text keeps its own records.
+func (a *App) quoteName() string {
+	hash := TextHash(a.typingTest.GetSampleText())
+	return fmt.Sprintf("%s#%s", a.textLibrary.GetCurrentText().Name, hash[:quoteHashLength])
+}
+
 func (a *App) recordLeaderboardEntry() {
 	stats := a.typingTest.GetStats()
 	user := CurrentLeaderboardUser()
@@ -640,10 +1199,13 @@ func (a *App) recordLeaderboardEntry() {
 		Timestamp: time.Now(),
 		Mode:      a.mode,
 	}
-	if a.mode == "words" {
+	switch a.mode {
+	case "words":
 		wordSet := a.wordLibrary.GetCurrentWordSet()
 		entry.TextName = wordSet.Name
-	} else {
+	case "quote":
+		entry.TextName = a.quoteName()
+	default:
 		currentText := a.textLibrary.GetCurrentText()
 		entry.TextName = currentText.Name
 	}
@@ -660,6 +1222,149 @@ func (a *App) recordLeaderboardEntry() {
 	}
 }
 
+// recordResult appends the finished test to the results history. The best WPM
+// of the earlier results for the same text and mode is kept for the results
+// screen.
+func (a *App) recordResult() {
+	stats := a.typingTest.GetStats()
+	result := TestResult{
+		Timestamp: time.Now(),
+		Mode:      a.mode,
+		WPM:       stats.GetWPM(),
+		Accuracy:  stats.GetAccuracy(),
+	}
+	switch a.mode {
+	case "words":
+		result.TextName = a.wordLibrary.GetCurrentWordSet().Name
+	case "quote":
+		result.TextName = a.quoteName()
+	default:
+		result.TextName = a.textLibrary.GetCurrentText().Name
+	}
+
+	a.previousBest = 0
+	if history, err := LoadStatsHistory(); err != nil {
+		fmt.Fprintf(os.Stderr, "results history: failed to load: %v\n", err)
+	} else {
+		a.previousBest = history.BestWPM(result.TextName, result.Mode)
+	}
+
+	if err := AppendResult(result); err != nil {
+		fmt.Fprintf(os.Stderr, "results history: failed to save: %v\n", err)
+	}
+}
+
+// recordLifetimeStats adds the finished test to the lifetime totals.
+func (a *App) recordLifetimeStats() {
+	stats := a.typingTest.GetStats()
+	test := LifetimeStats{
+		Keystrokes:     stats.GetTotalKeystrokes(),
+		Words:          len(strings.Fields(a.typingTest.GetUserInput())),
+		TypingTime:     stats.GetDuration(),
+		TestsCompleted: 1,
+	}
+
+	if err := RecordLifetimeStats(test); err != nil {
+		fmt.Fprintf(os.Stderr, "lifetime stats: %v\n", err)
+	}
+}
+
+// recordXP awards XP for the finished test and keeps the outcome for the results screen.
+func (a *App) recordXP() {
+	a.lastXP = XPAward{}
+	if a.xpManager == nil {
+		return
+	}
+
+	stats := a.typingTest.GetStats()
+	gained := TestXP(stats.GetWPM(), stats.GetAccuracy(), stats.GetTotalKeystrokes())
+	award, err := a.xpManager.Award(gained)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "xp: %v\n", err)
+	}
+	a.lastXP = award
+}
+
+// recordAchievements unlocks the achievements met by the finished test and
+// keeps the new ones for the results screen. Runs after recordResult so the
+// finished test counts towards the day streak.
+func (a *App) recordAchievements() {
+	a.lastAchievements = nil
+	if a.achievements == nil {
+		return
+	}
+
+	results, err := LoadResultsHistory()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "achievements: %v\n", err)
+	}
+	now := time.Now()
+	stats := a.typingTest.GetStats()
+	ctx := AchievementContext{
+		WPM:        stats.GetWPM(),
+		Accuracy:   stats.GetAccuracy(),
+		Keystrokes: stats.GetTotalKeystrokes(),
+		DayStreak:  DayStreak(results, now),
+	}
+
+	unlocked, err := a.achievements.Unlock(ctx, now)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "achievements: %v\n", err)
+	}
+	a.lastAchievements = unlocked
+}
+
+// startCelebration plays the confetti animation if the finished test was a
+// perfect run and celebrations are enabled.
+func (a *App) startCelebration() {
+	stats := a.typingTest.GetStats()
+	if a.settings.CelebrateMode && stats.GetTotalKeystrokes() > 0 && stats.GetAccuracy() >= 100 {
+		a.celebration = NewCelebration()
+	}
+}
+
+// openLifetimeStats loads the lifetime totals and shows them.
+func (a *App) openLifetimeStats() {
+	lifetime, err := LoadLifetimeStats()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "lifetime stats: %v\n", err)
+	}
+	a.lifetime = lifetime
+	a.showLifetime = true
+}
+
+// openProgressReport loads the results history and shows the progress report.
+func (a *App) openProgressReport() {
+	results, err := LoadResultsHistory()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "results history: failed to load: %v\n", err)
+	}
+	a.reportResults = results
+	if a.reportPeriod == "" {
+		a.reportPeriod = ReportByDay
+	}
+	a.showReport = true
+}
+
+// drawProgressReportOverlay renders the average WPM of the most recent periods.
+func (a *App) drawProgressReportOverlay() {
+	buckets := BucketResults(a.reportResults, a.reportPeriod)
+	if len(buckets) > maxReportPoints {
+		buckets = buckets[len(buckets)-maxReportPoints:]
+	}
+
+	data := ProgressReportData{
+		Period: a.reportPeriod,
+		Points: make([]ChartPoint, len(buckets)),
+		Theme:  a.displayTheme(),
+	}
+	for i, bucket := range buckets {
+		data.Points[i] = ChartPoint{Label: bucket.Start.Format("Jan 2"), Value: bucket.AverageWPM}
+		data.Tests += bucket.Tests
+	}
+	a.renderer.DrawProgressReport(data)
+}
+
 // drawCommandMenuOverlay renders the command menu.
 func (a *App) drawCommandMenuOverlay() {
 	menuData := CommandMenuData{
@@ -667,11 +1372,43 @@ func (a *App) drawCommandMenuOverlay() {
 		FilteredCommands: a.commandMenu.GetFilteredCommands(),
 		Selected:         a.commandMenu.GetSelected(),
 		ScrollOffset:     a.commandMenu.GetScrollOffset(),
-		Theme:            a.theme,
+		Preview:          a.commandMenu.GetPreview(),
+		History:          a.commandMenu.IsHistoryView(),
+		Theme:            a.displayTheme(),
 	}
 	a.renderer.DrawCommandMenu(menuData)
 }
 
+// drawTextBrowserOverlay renders the text browser.
+func (a *App) drawTextBrowserOverlay() {
+	// Scroll by the rows that fit, which shrink on small screens
+	_, height := a.screen.Size()
+	a.textBrowser.SetVisibleRows(TextBrowserRows(height))
+
+	browserData := TextBrowserData{
+		Texts:        a.textBrowser.GetTexts(),
+		Selected:     a.textBrowser.GetSelected(),
+		ScrollOffset: a.textBrowser.GetScrollOffset(),
+		Theme:        a.displayTheme(),
+	}
+	a.renderer.DrawTextBrowser(browserData)
+}
+
+// openTextBrowser shows the text browser with the current text highlighted.
+func (a *App) openTextBrowser() {
+	a.textBrowser.Show(a.textLibrary.GetAllTexts(), a.textLibrary.GetCurrentIndex())
+}
+
+// selectTextFromBrowser selects the highlighted text and closes the browser.
+func (a *App) selectTextFromBrowser() {
+	text, ok := a.textBrowser.GetSelectedText()
+	a.textBrowser.Hide()
+	if ok {
+		a.selectTextByName(text.Name)
+		a.showResults = false
+	}
+}
+
 // toggleCommandMenu toggles the command menu visibility.
 func (a *App) toggleCommandMenu() {
 	if a.commandMenu.IsVisible() {
@@ -682,44 +1419,640 @@ func (a *App) toggleCommandMenu() {
 }
 
 // cycleTheme switches to the next theme and saves the preference.
+// Only favorite themes are cycled through if there are any.
 func (a *App) cycleTheme() {
-	a.theme = GetNextTheme(a.theme)
-	a.saveThemePreference()
+	a.setTheme(GetNextTheme(a.theme, a.settings.FavoriteThemes))
 }
 
-// saveThemePreference saves the current theme to settings.
-func (a *App) saveThemePreference() {
-	settings := Settings{
-		ThemeName:   a.theme.Name,
-		Mode:        a.mode,
-		LimitType:   a.limitType,
-		TimeLimit:   a.timeLimit,
-		WordLimit:   a.wordLimit,
-		LastWordSet: a.getLastWordSet(),
-	}
-	_ = a.settingsManager.SaveSettings(settings)
+// cycleThemeBack switches to the previous theme and saves the preference.
+func (a *App) cycleThemeBack() {
+	a.setTheme(GetPreviousTheme(a.theme, a.settings.FavoriteThemes))
 }
 
-// saveAllSettings saves all current settings including theme, mode, and limits.
-func (a *App) saveAllSettings() {
-	settings := Settings{
-		ThemeName:   a.theme.Name,
-		Mode:        a.mode,
-		LimitType:   a.limitType,
-		TimeLimit:   a.timeLimit,
-		WordLimit:   a.wordLimit,
-		LastWordSet: a.getLastWordSet(),
+// addFavoriteTheme adds the current theme to the themes cycled through.
+func (a *App) addFavoriteTheme() {
+	if slices.Contains(a.settings.FavoriteThemes, a.theme.Name) {
+		return
 	}
-	_ = a.settingsManager.SaveSettings(settings)
+	a.settings.FavoriteThemes = append(slices.Clone(a.settings.FavoriteThemes), a.theme.Name)
+	a.saveAllSettings()
 }
 
-// getLastWordSet returns the current word set name or empty string.
-func (a *App) getLastWordSet() string {
-	if a.mode == "words" {
-		wordSet := a.wordLibrary.GetCurrentWordSet()
-		return wordSet.Name
-	}
-	return ""
+// removeFavoriteTheme removes the current theme from the themes cycled through.
+func (a *App) removeFavoriteTheme() {
+	a.settings.FavoriteThemes = slices.DeleteFunc(slices.Clone(a.settings.FavoriteThemes), func(name string) bool {
+		return name == a.theme.Name
+	})
+	a.saveAllSettings()
+}
+
+// setTheme switches to the given theme as an explicit user choice.
+// Manual changes are remembered so the automatic schedule doesn't override them.
+func (a *App) setTheme(theme Theme) {
+	a.settings.ManualThemeAt = time.Now()
+	a.applyTheme(theme)
+}
+
+// applyTheme switches to the given theme, remembers the previously active theme
+// for toggling, and saves the preference.
+func (a *App) applyTheme(theme Theme) {
+	if theme.Name != a.theme.Name {
+		a.previousTheme = a.theme
+	}
+	a.theme = theme
+	a.saveThemePreference()
+}
+
+// applyAutoTheme switches to the scheduled day/night theme if the schedule is
+// enabled and the user hasn't picked a theme manually since the last boundary.
+// Returns true if the theme changed.
+func (a *App) applyAutoTheme(now time.Time) bool {
+	name, ok := a.settings.AutoTheme.ScheduledTheme(now, a.settings.ManualThemeAt)
+	if !ok || name == a.theme.Name {
+		return false
+	}
+	theme, found := FindTheme(name)
+	if !found {
+		return false
+	}
+	a.applyTheme(theme)
+	return true
+}
+
+// toggleAutoTheme enables or disables the automatic day/night schedule.
+func (a *App) toggleAutoTheme() {
+	a.settings.AutoTheme.Enabled = !a.settings.AutoTheme.Enabled
+	// Enabling the schedule is a request to follow it right away
+	a.settings.ManualThemeAt = time.Time{}
+	if !a.applyAutoTheme(time.Now()) {
+		a.saveAllSettings()
+	}
+}
+
+// testSettings returns the settings for the current test: the user's settings
+// with the current text's front matter overrides applied in text and quote mode.
+// The overrides are never saved, so they are gone after switching texts.
+func (a *App) testSettings() Settings {
+	settings := a.settings
+	if a.mode == "text" || a.mode == "quote" {
+		// Overrides were validated when the text was loaded
+		_ = ApplyRCValues(&settings, a.textLibrary.GetCurrentText().Overrides)
+	}
+	return settings
+}
+
+// applyTestSettings configures the typing test and Tab key from testSettings.
+// Call it before loading a new sample text, which resets the test.
+func (a *App) applyTestSettings() {
+	settings := a.testSettings()
+	a.typingTest.SetSkipToNextWordOnSpace(settings.SkipToNextWordOnSpace)
+	a.typingTest.SetSemantics(TypingSemantics(settings.TypingSemantics))
+	a.typingTest.SetWPMLeadIn(time.Duration(settings.WPMLeadInMS) * time.Millisecond)
+	a.typingTest.SetTrackErrors(settings.TrackErrors)
+	a.typingTest.SetRequireExactToFinish(settings.RequireExactToFinish)
+	a.typingTest.SetStrictFinish(settings.StrictFinish)
+	a.inputHandler.SetTabKey(settings.TabKey)
+}
+
+// applyChangedTestSetting applies the test settings after the user changed
+// the setting of the rc file key. If the current text's front matter
+// overrides it, a notice explains that the change only shows on other texts.
+func (a *App) applyChangedTestSetting(key string) {
+	a.applyTestSettings()
+	if a.mode != "text" && a.mode != "quote" {
+		return
+	}
+	text := a.textLibrary.GetCurrentText()
+	if _, ok := text.Overrides[key]; ok {
+		a.confirm = &confirmation{message: fmt.Sprintf("'%s' sets %s in its front matter. Your change applies to other texts.", text.Name, key)}
+	}
+}
+
+// noteFrontMatterError shows a notice if the current text's front matter is
+// broken and was ignored.
+func (a *App) noteFrontMatterError() {
+	text := a.textLibrary.GetCurrentText()
+	if text.FrontMatterErr != nil {
+		a.confirm = &confirmation{message: fmt.Sprintf("'%s' is used without its settings: %v", text.Name, text.FrontMatterErr)}
+	}
+}
+
+// toggleSkipToNextWordOnSpace switches whether a space typed mid-word skips
+// to the next word.
+func (a *App) toggleSkipToNextWordOnSpace() {
+	a.settings.SkipToNextWordOnSpace = !a.settings.SkipToNextWordOnSpace
+	a.applyChangedTestSetting("skip_to_next_word_on_space")
+	a.saveAllSettings()
+}
+
+// toggleTrackErrors switches misspelled word tracking on or off for relaxed practice.
+func (a *App) toggleTrackErrors() {
+	a.settings.TrackErrors = !a.settings.TrackErrors
+	a.applyChangedTestSetting("track_errors")
+	a.saveAllSettings()
+}
+
+// toggleCleanGutenberg adds or removes the gutenberg stage of the preprocessing
+// pipeline and reloads the texts. The current text stays selected.
+func (a *App) toggleCleanGutenberg() {
+	enabled := !slices.Contains(a.settings.Preprocess, PreprocessGutenberg)
+	a.settings.Preprocess = WithGutenberg(a.settings.Preprocess, enabled)
+	a.textLibrary.SetPipeline(a.settings.Preprocess)
+	a.restartTextTest()
+	a.saveAllSettings()
+}
+
+// toggleProofread switches proofreading practice, where texts are shown with
+// typos that must be typed corrected. A text or quote mode test restarts.
+func (a *App) toggleProofread() {
+	a.settings.Proofread = !a.settings.Proofread
+	a.restartTextTest()
+	a.saveAllSettings()
+}
+
+// restartTextTest restarts a text or quote mode test after the texts were
+// reloaded or the way they are practiced changed: on the current text, or in
+// quote mode on a sentence of it. Word mode tests are kept.
+func (a *App) restartTextTest() {
+	if !a.textLibrary.HasTexts() {
+		return
+	}
+	switch a.mode {
+	case "text":
+		a.selectTextByName(a.textLibrary.GetCurrentText().Name)
+	case "quote":
+		a.startQuote(a.textLibrary.RandomQuote())
+	}
+}
+
+// setTextSample starts a text mode test on content. When proofreading, the
+// content is shown with typos and the input is checked against the original.
+func (a *App) setTextSample(content string) {
+	if a.settings.Proofread {
+		a.typingTest.SetProofreadText(InsertTypos(content, proofreadTypoRate, a.rand), content)
+		return
+	}
+	a.typingTest.SetSampleText(content)
+}
+
+// toggleRequireExactToFinish switches whether errors must be corrected before
+// the test can finish.
+func (a *App) toggleRequireExactToFinish() {
+	a.settings.RequireExactToFinish = !a.settings.RequireExactToFinish
+	a.applyChangedTestSetting("require_exact_to_finish")
+	a.saveAllSettings()
+}
+
+// toggleStrictFinish switches whether whitespace at the end of the text must be
+// typed to finish.
+func (a *App) toggleStrictFinish() {
+	a.settings.StrictFinish = !a.settings.StrictFinish
+	a.applyChangedTestSetting("strict_finish")
+	a.saveAllSettings()
+}
+
+// toggleBlindMode switches hiding correctness feedback while typing.
+func (a *App) toggleBlindMode() {
+	a.settings.BlindMode = !a.settings.BlindMode
+	a.saveAllSettings()
+}
+
+// toggleTypingSemantics switches between character- and word-based typing.
+// The current test restarts because its progress was scored under the old rules.
+func (a *App) toggleTypingSemantics() {
+	semantics := SemanticsWord
+	if a.settings.TypingSemantics == string(SemanticsWord) {
+		semantics = SemanticsCharacter
+	}
+	a.settings.TypingSemantics = string(semantics)
+	a.applyChangedTestSetting("typing_semantics")
+	a.restartTest()
+	a.saveAllSettings()
+}
+
+// toggleWordCase switches generated words between the given case transform and
+// their original case, and starts a new test with the new words.
+func (a *App) toggleWordCase(wordCase WordCase) {
+	if a.wordLibrary.GetWordCase() == wordCase {
+		wordCase = WordCaseAsIs
+	}
+	a.settings.WordCase = string(wordCase)
+	a.wordLibrary.SetWordCase(wordCase)
+	if a.mode == "words" {
+		a.restartTest()
+	}
+	a.saveAllSettings()
+}
+
+// setWordPunctuation turns adding sentence punctuation to generated words on
+// or off and starts a new test with the new words.
+func (a *App) setWordPunctuation(on bool) {
+	a.settings.Punctuation = on
+	a.applyWordGenOptions()
+}
+
+// setWordCapitalization turns capitalizing some generated words on or off and
+// starts a new test with the new words.
+func (a *App) setWordCapitalization(on bool) {
+	a.settings.Capitalization = on
+	a.applyWordGenOptions()
+}
+
+// setWordNumbers turns replacing some generated words with numbers on or off
+// and starts a new test with the new words.
+func (a *App) setWordNumbers(on bool) {
+	a.settings.IncludeNumbers = on
+	a.applyWordGenOptions()
+}
+
+// toggleHardWordsOnly switches limiting weighted word sets to their heaviest
+// words and starts a new test with the new words.
+func (a *App) toggleHardWordsOnly() {
+	a.settings.HardWordsOnly = !a.settings.HardWordsOnly
+	a.wordLibrary.SetHardOnly(a.settings.HardWordsOnly)
+	if a.mode == "words" {
+		a.restartTest()
+	}
+	a.saveAllSettings()
+}
+
+// applyWordGenOptions passes the punctuation, capitalization and number
+// settings to the word library, restarts a word mode test and saves the
+// settings.
+func (a *App) applyWordGenOptions() {
+	opts := a.wordLibrary.GetGenOptions()
+	opts.Punctuation = a.settings.Punctuation
+	opts.Capitalization = a.settings.Capitalization
+	opts.Numbers = a.settings.IncludeNumbers
+	a.wordLibrary.SetGenOptions(opts)
+	if a.mode == "words" {
+		a.restartTest()
+	}
+	a.saveAllSettings()
+}
+
+// setMinWordLength makes word mode only use words with at least length letters
+// (0 for any length) and starts a new test with the new words.
+// arg is the number typed after the command name.
+func (a *App) setMinWordLength(arg string) {
+	length, err := strconv.Atoi(arg)
+	if err != nil || length < 0 {
+		return
+	}
+	a.settings.MinWordLength = length
+	a.wordLibrary.SetMinWordLength(length)
+	a.regenerateWords()
+	a.saveAllSettings()
+}
+
+// setTypingMargin sets the empty rows above (top) or below the typing text.
+// arg is the number typed after the command name; invalid numbers are ignored.
+func (a *App) setTypingMargin(top bool, arg string) {
+	if top {
+		if setMargin(&a.settings.TopMargin, arg) != nil {
+			return
+		}
+	} else if setMargin(&a.settings.BottomMargin, arg) != nil {
+		return
+	}
+	a.renderer.SetTypingMargins(a.settings.TopMargin, a.settings.BottomMargin)
+	a.saveAllSettings()
+}
+
+// setPipeline sets the preprocessors applied to text files from a comma
+// separated list of names (empty for none) and reloads the texts. Unknown
+// names are reported in a notice and change nothing.
+func (a *App) setPipeline(list string) {
+	pipeline := ParsePipeline(list)
+	if err := ValidatePipeline(pipeline); err != nil {
+		a.confirm = &confirmation{message: err.Error()}
+		return
+	}
+	a.settings.Preprocess = pipeline
+	a.textLibrary.SetPipeline(pipeline)
+	a.restartTextTest()
+	a.saveAllSettings()
+}
+
+// setChunkWords splits long texts into parts of about words words (0 keeps
+// them whole) and reloads the library. arg is the number typed after the
+// command name. The first part of the current text, or the text itself, stays
+// selected.
+func (a *App) setChunkWords(arg string) {
+	words, err := strconv.Atoi(arg)
+	if err != nil || words < 0 {
+		return
+	}
+	current := a.textLibrary.GetCurrentText()
+	a.settings.ChunkWords = words
+	a.textLibrary.SetChunkWords(words)
+	a.initCommands()
+	switch a.mode {
+	case "text":
+		for _, text := range a.textLibrary.GetAllTexts() {
+			if text.Path != "" && text.Path == current.Path {
+				a.selectTextByName(text.Name)
+				break
+			}
+		}
+	case "quote":
+		a.restartTextTest()
+	}
+	a.saveAllSettings()
+}
+
+// startTimerOnLoad starts the test timer as soon as the typing view is shown,
+// measuring reaction time as well as typing, when the timer is set to start on load.
+func (a *App) startTimerOnLoad() {
+	if a.settings.TimerStart != TimerStartOnLoad || a.typingTest.IsFinished() {
+		return
+	}
+	stats := a.typingTest.GetStats()
+	stats.Start()
+	if a.mode == "words" && a.testStarted.IsZero() {
+		a.testStarted = stats.GetStartTime()
+	}
+}
+
+// setResultsEnterAction changes what Enter does on the results screen.
+func (a *App) setResultsEnterAction(action string) {
+	a.settings.ResultsEnterAction = action
+	a.inputHandler.SetResultsEnterAction(action)
+	a.saveAllSettings()
+}
+
+// setTabKey changes what the Tab key does while typing.
+func (a *App) setTabKey(role string) {
+	a.settings.TabKey = role
+	a.applyChangedTestSetting("tab_key")
+	a.saveAllSettings()
+}
+
+// toggleTimerStart switches between starting the timer on the first keystroke and on load.
+func (a *App) toggleTimerStart() {
+	if a.settings.TimerStart == TimerStartOnLoad {
+		a.settings.TimerStart = TimerStartFirstKey
+	} else {
+		a.settings.TimerStart = TimerStartOnLoad
+	}
+	a.restartTest()
+	a.saveAllSettings()
+}
+
+// displayTheme returns the theme used for drawing: the active theme, with a
+// transparent background if the user wants the terminal background to show through.
+func (a *App) displayTheme() Theme {
+	if a.settings.TransparentBackground {
+		return a.theme.WithTransparentBackground()
+	}
+	return a.theme
+}
+
+// toggleTransparentBackground switches between the theme background and the
+// terminal's own background.
+func (a *App) toggleTransparentBackground() {
+	a.settings.TransparentBackground = !a.settings.TransparentBackground
+	a.saveAllSettings()
+}
+
+// toggleShowGraph shows or hides the WPM timeline on the results screen.
+func (a *App) toggleShowGraph() {
+	a.settings.ShowGraph = !a.settings.ShowGraph
+	a.saveAllSettings()
+}
+
+// toggleShowSparkline shows or hides the live WPM line while typing.
+func (a *App) toggleShowSparkline() {
+	a.settings.ShowSparkline = !a.settings.ShowSparkline
+	a.saveAllSettings()
+}
+
+// toggleDimStopwords switches dimming common function words in the text.
+func (a *App) toggleDimStopwords() {
+	a.settings.DimStopwords = !a.settings.DimStopwords
+	a.saveAllSettings()
+}
+
+// toggleCelebrateMode switches the confetti animation after perfect runs.
+func (a *App) toggleCelebrateMode() {
+	a.settings.CelebrateMode = !a.settings.CelebrateMode
+	a.saveAllSettings()
+}
+
+// toggleBigWord shows or hides the enlarged current word in word mode.
+func (a *App) toggleBigWord() {
+	a.settings.BigWord = !a.settings.BigWord
+	a.saveAllSettings()
+}
+
+// toggleBoldText switches the typing text between regular and bold.
+func (a *App) toggleBoldText() {
+	a.settings.BoldText = !a.settings.BoldText
+	a.saveAllSettings()
+}
+
+// toggleUnderlineWhitespace switches mistyped whitespace between '_' and an underline.
+func (a *App) toggleUnderlineWhitespace() {
+	a.settings.UnderlineWhitespace = !a.settings.UnderlineWhitespace
+	a.saveAllSettings()
+}
+
+// toggleMistypedOverlay shows or hides mistyped characters above the text.
+func (a *App) toggleMistypedOverlay() {
+	a.settings.ShowMistypedOverlay = !a.settings.ShowMistypedOverlay
+	a.saveAllSettings()
+}
+
+// setScrollAnchor changes where the cursor line is kept while scrolling in text mode.
+func (a *App) setScrollAnchor(anchor string) {
+	a.settings.ScrollAnchor = anchor
+	a.saveAllSettings()
+}
+
+// setSpeedUnit sets how typing speed is shown: WPM, KPM or both.
+func (a *App) setSpeedUnit(unit string) {
+	a.settings.SpeedUnit = unit
+	a.renderer.SetSpeedUnit(unit)
+	a.saveAllSettings()
+}
+
+// toggleFocusFade turns dimming of the title, help and stats while typing on or off.
+func (a *App) toggleFocusFade() {
+	a.settings.FocusFade = !a.settings.FocusFade
+	a.saveAllSettings()
+}
+
+// toggleLineNumbers shows or hides the line number gutter in text mode.
+func (a *App) toggleLineNumbers() {
+	a.settings.ShowLineNumbers = !a.settings.ShowLineNumbers
+	a.saveAllSettings()
+}
+
+// toggleHorizontalFocus switches keeping the cursor at a fixed column, with
+// the text scrolling under it like a teleprompter.
+func (a *App) toggleHorizontalFocus() {
+	a.settings.HorizontalFocus = !a.settings.HorizontalFocus
+	a.saveAllSettings()
+}
+
+// toggleLiveStats shows or hides the live stats, the live WPM line and the
+// word mode progress while typing. The results screen always shows the full stats.
+func (a *App) toggleLiveStats() {
+	a.settings.ShowLiveStats = !a.settings.ShowLiveStats
+	a.saveAllSettings()
+}
+
+// toggleDimBehindOverlays switches dimming the screen behind overlays like
+// the command palette.
+func (a *App) toggleDimBehindOverlays() {
+	a.settings.DimBehindOverlays = !a.settings.DimBehindOverlays
+	a.saveAllSettings()
+}
+
+// toggleGenerationIndicator switches marking the end of the text while more
+// words are generated in word mode.
+func (a *App) toggleGenerationIndicator() {
+	a.settings.GenerationIndicator = !a.settings.GenerationIndicator
+	a.saveAllSettings()
+}
+
+// toggleLineNumbering switches line numbers between source lines and wrapped lines.
+func (a *App) toggleLineNumbering() {
+	if a.settings.LineNumbering == LineNumbersWrapped {
+		a.settings.LineNumbering = LineNumbersLogical
+	} else {
+		a.settings.LineNumbering = LineNumbersWrapped
+	}
+	a.saveAllSettings()
+}
+
+// toggleASCIIMode switches between Unicode and ASCII-only decorations.
+// The choice is saved explicitly, replacing auto-detection.
+func (a *App) toggleASCIIMode() {
+	if ResolveASCIIMode(a.settings.ASCIIMode, os.Getenv) {
+		a.settings.ASCIIMode = ASCIIModeOff
+	} else {
+		a.settings.ASCIIMode = ASCIIModeOn
+	}
+	a.renderer.SetASCIIMode(a.settings.ASCIIMode == ASCIIModeOn)
+	a.saveAllSettings()
+}
+
+// startReplay replays the last test's recorded input with its original timing.
+// Does nothing if no input was recorded.
+func (a *App) startReplay() {
+	events := a.typingTest.GetInputLog()
+	if len(events) == 0 {
+		return
+	}
+
+	a.replayTest = NewTypingTest(a.typingTest.GetSampleText())
+	if a.typingTest.IsProofreading() {
+		a.replayTest.SetProofreadText(string(a.typingTest.GetDisplayRunes()), a.typingTest.GetSampleText())
+	}
+	a.replayTest.SetSkipToNextWordOnSpace(a.typingTest.GetSkipToNextWordOnSpace())
+	a.replayTest.SetSemantics(a.typingTest.GetSemantics())
+	a.replayTest.SetRequireExactToFinish(a.typingTest.GetRequireExactToFinish())
+	a.replayTest.SetStrictFinish(a.typingTest.GetStrictFinish())
+	a.replay = NewReplay(events, 1)
+	a.lastReplayTick = time.Now()
+	a.currentScrollLine = 0
+	a.lastCursorLine = 0
+}
+
+// tickReplay advances the replay clock and applies the events that became due.
+func (a *App) tickReplay(now time.Time) {
+	dt := now.Sub(a.lastReplayTick)
+	a.lastReplayTick = now
+
+	for _, event := range a.replay.Advance(dt) {
+		switch {
+		case event.Backspace:
+			a.replayTest.Backspace()
+		case event.Char == '\n':
+			a.replayTest.TypeNewline()
+		default:
+			a.replayTest.TypeCharacter(event.Char)
+		}
+	}
+}
+
+// toggleReplayPause pauses or resumes the running replay.
+func (a *App) toggleReplayPause() {
+	if a.replay != nil {
+		a.replay.TogglePause()
+	}
+}
+
+// changeReplaySpeed multiplies the replay speed by the given factor.
+func (a *App) changeReplaySpeed(factor float64) {
+	if a.replay != nil {
+		a.replay.SetSpeed(a.replay.Speed() * factor)
+	}
+}
+
+// exitReplay stops the running replay and returns to the previous screen.
+func (a *App) exitReplay() {
+	a.replay = nil
+	a.replayTest = nil
+	a.currentScrollLine = 0
+	a.lastCursorLine = 0
+}
+
+// toggleLastTheme swaps between the current theme and the previously active one.
+func (a *App) toggleLastTheme() {
+	a.setTheme(a.previousTheme)
+}
+
+// saveThemePreference saves the current theme to settings.
+func (a *App) saveThemePreference() {
+	a.saveAllSettings()
+}
+
+// saveAllSettings saves all current settings including theme, mode, and limits.
+// Only settings changed in the app are written, so values from rocketype.conf
+// and ROCKETYPE_* variables stay runtime-only.
+func (a *App) saveAllSettings() {
+	current := a.currentSettings()
+	a.savedSettings = MergeChangedSettings(a.savedSettings, a.layered, current)
+	a.layered = CloneSettings(current)
+	_ = a.settingsManager.SaveSettings(a.savedSettings)
+}
+
+// currentSettings builds the persistent settings from the current app state.
+func (a *App) currentSettings() Settings {
+	settings := a.settings
+	settings.ThemeName = a.theme.Name
+	settings.Mode = a.mode
+	settings.LimitType = a.limitType
+	settings.TimeLimit = a.timeLimit
+	settings.WordLimit = a.wordLimit
+	settings.LastWordSet = a.getLastWordSet()
+	settings.CommandUsage = a.commandMenu.GetUsageCounts()
+	return settings
+}
+
+// getLastWordSet returns the current word set name or empty string.
+func (a *App) getLastWordSet() string {
+	if a.mode == "words" {
+		wordSet := a.wordLibrary.GetCurrentWordSet()
+		return wordSet.Name
+	}
+	return ""
+}
+
+// finishTest ends the running test early and shows the results for the typed
+// portion. Tests finished this way are not recorded in the leaderboard or the
+// results history, since they don't cover the whole text or limit.
+func (a *App) finishTest() {
+	if a.typingTest.IsFinished() || a.typingTest.GetTotalKeystrokes() == 0 {
+		return
+	}
+	a.typingTest.MarkFinished()
+	a.showResults = true
+	a.previousBest = 0       // Not recorded, so not compared with the best either
+	a.lastXP = XPAward{}     // Nor does it earn XP
+	a.lastAchievements = nil // Or unlock achievements
 }
 
 // restartTest resets the current typing test.
@@ -728,19 +2061,79 @@ func (a *App) getLastWordSet() string {
 func (a *App) restartTest() {
 	// In word mode, generate new random words
 	if a.mode == "words" && a.wordLibrary.HasWordSets() {
-		wordCount := initialWordCount
-		if a.limitType == "words" {
-			wordCount = a.wordLimit * wordLimitMultiplier
-		}
-		content := a.wordLibrary.GenerateRandomWords(wordCount)
-		a.typingTest.SetSampleText(content)
-		a.lastCheckPosition = 0 // Reset check position for new test
-	} else {
-		// In text mode, just reset progress (keep same text)
+		a.regenerateWords()
+		return
+	}
+
+	// In text mode, just reset progress (keep same text), unless the
+	// playlist moves on to its next text after a finished test
+	if !a.settings.PlaylistMode || !a.typingTest.IsFinished() || !a.stepPlaylist(1) {
 		a.typingTest.Reset()
 	}
+	a.resetTestView()
+}
+
+// nextTest moves on from the results screen to the next test: the next word
+// set in word mode, another random quote in quote mode, and in text mode the
+// next playlist text, or the next text of the library without a playlist.
+func (a *App) nextTest() {
+	switch a.mode {
+	case "words":
+		a.stepWordSet(1)
+	case "quote":
+		a.selectRandomQuote()
+	default:
+		if a.stepPlaylist(1) {
+			a.resetTestView()
+			return
+		}
+		a.stepText(1)
+	}
+}
 
+// stepWordSet switches to the next (step 1) or previous (step -1) word set,
+// wrapping around at either end, and starts a new test with its words.
+func (a *App) stepWordSet(step int) {
+	wordSets := a.wordLibrary.GetAllWordSets()
+	if len(wordSets) == 0 {
+		return
+	}
+	current := a.wordLibrary.GetCurrentWordSet().Name
+	idx := 0
+	for i, wordSet := range wordSets {
+		if wordSet.Name == current {
+			idx = (i + step + len(wordSets)) % len(wordSets)
+			break
+		}
+	}
+	a.selectWordSet(wordSets[idx].Name)
+	a.resetTestView()
+}
+
+// regenerateWords starts a new word mode test with a fresh random sequence from
+// the current word set. The word set, limits and settings are kept.
+// Does nothing outside word mode.
+func (a *App) regenerateWords() {
+	if a.mode != "words" || !a.wordLibrary.HasWordSets() {
+		return
+	}
+
+	wordCount := initialWordCount
+	if a.limitType == "words" {
+		wordCount = a.wordLimit * wordLimitMultiplier
+	}
+	content := a.wordLibrary.GenerateRandomWords(wordCount)
+	a.typingTest.SetSampleText(content)
+	a.lastCheckPosition = 0 // Reset check position for new test
+	a.resetTestView()
+}
+
+// resetTestView leaves the results screen and resets the timer and scroll
+// state for a restarted test.
+func (a *App) resetTestView() {
 	a.showResults = false
+	a.showLineTimings = false
+	a.celebration = nil
 	a.testStarted = time.Time{} // Reset timer for word mode
 	// Reset scroll state
 	a.currentScrollLine = 0
@@ -749,6 +2142,17 @@ func (a *App) restartTest() {
 	_ = a.sessionManager.ClearSession()
 }
 
+// reviewScroll moves the text mode viewport to the start or end of the text
+// for reviewing it. The cursor stays where it is, since typing only appends;
+// the next key other than Home/End scrolls back to it.
+func (a *App) reviewScroll(toEnd bool) {
+	if a.mode != "text" {
+		return
+	}
+	a.reviewing = true
+	a.reviewAtEnd = toEnd
+}
+
 // calculateSmoothScroll computes scroll position with minimal movement.
 // Scrolls incrementally by single lines to maintain smooth behavior.
 func (a *App) calculateSmoothScroll(cursorLine, maxVisibleLines, totalLines int) int {
@@ -809,10 +2213,16 @@ func (a *App) clearSession() {
 		}
 		content := a.wordLibrary.GenerateRandomWords(wordCount)
 		a.typingTest.SetSampleText(content)
+	} else if a.mode == "quote" {
+		// Select a random sentence
+		quote := a.textLibrary.SelectRandomQuote()
+		a.applyTestSettings()
+		a.setTextSample(quote.Content)
 	} else {
 		// Select random text
 		text := a.textLibrary.SelectRandom()
-		a.typingTest.SetSampleText(text.Content)
+		a.applyTestSettings()
+		a.setTextSample(text.Content)
 	}
 
 	a.showResults = false
@@ -825,8 +2235,10 @@ func (a *App) clearSession() {
 // selectRandomText selects a random text and restarts the test.
 func (a *App) selectRandomText() {
 	text := a.textLibrary.SelectRandom()
-	a.typingTest.SetSampleText(text.Content)
 	a.mode = "text"
+	a.applyTestSettings()
+	a.noteFrontMatterError()
+	a.setTextSample(text.Content)
 	a.testStarted = time.Time{}
 	// Reset scroll state
 	a.currentScrollLine = 0
@@ -836,12 +2248,106 @@ func (a *App) selectRandomText() {
 	a.saveAllSettings()
 }
 
+// selectRandomQuote switches to quote mode with a random sentence from the
+// texts library and restarts the test. Like a text, the test ends once the
+// sentence is typed.
+func (a *App) selectRandomQuote() {
+	a.startQuote(a.textLibrary.SelectRandomQuote())
+}
+
+// startQuote switches to quote mode and restarts the test on quote.
+func (a *App) startQuote(quote TextSource) {
+	a.mode = "quote"
+	a.applyTestSettings()
+	a.setTextSample(quote.Content)
+	a.resetTestView()
+	a.saveAllSettings()
+}
+
+// selectLeastPracticedText selects the text with the fewest recorded attempts.
+// Falls back to a random text when there is no history yet.
+func (a *App) selectLeastPracticedText() {
+	results, err := LoadResultsHistory()
+	if err != nil || len(results) == 0 {
+		a.selectRandomText()
+		return
+	}
+
+	texts := a.textLibrary.GetAllTexts()
+	names := make([]string, len(texts))
+	for i, text := range texts {
+		names[i] = text.Name
+	}
+
+	name, ok := SelectLeastPracticed(names, AttemptCounts(results, "text"), a.rand)
+	if !ok {
+		a.selectRandomText()
+		return
+	}
+	a.selectTextByName(name)
+}
+
+// stepPlaylist selects the next (step 1) or previous (step -1) playlist text,
+// skipping texts that no longer exist. Returns false if the playlist has no
+// existing texts.
+func (a *App) stepPlaylist(step int) bool {
+	exists := func(name string) bool {
+		for _, text := range a.textLibrary.GetAllTexts() {
+			if text.Name == name {
+				return true
+			}
+		}
+		return false
+	}
+
+	idx := PlaylistStep(a.settings.Playlist, a.settings.PlaylistIndex, step, exists)
+	if idx < 0 {
+		return false
+	}
+	a.settings.PlaylistIndex = idx
+	a.selectTextByName(a.settings.Playlist[idx])
+	return true
+}
+
+// stepText restarts the test on the next (step 1) or previous (step -1) text
+// of the library, wrapping around at either end.
+func (a *App) stepText(step int) {
+	if !a.textLibrary.HasTexts() {
+		return
+	}
+	var text TextSource
+	if step < 0 {
+		text = a.textLibrary.SelectPrevious()
+	} else {
+		text = a.textLibrary.SelectNext()
+	}
+	a.selectTextByName(text.Name)
+	a.resetTestView()
+}
+
+// addCurrentTextToPlaylist appends the current text to the end of the playlist.
+func (a *App) addCurrentTextToPlaylist() {
+	if a.mode != "text" {
+		return
+	}
+	a.settings.Playlist = append(a.settings.Playlist, a.textLibrary.GetCurrentText().Name)
+	a.saveAllSettings()
+}
+
+// togglePlaylistMode turns automatic advancing to the next playlist text on or off.
+func (a *App) togglePlaylistMode() {
+	a.settings.PlaylistMode = !a.settings.PlaylistMode
+	a.saveAllSettings()
+}
+
 // selectTextByName selects a text by name and restarts the test.
 func (a *App) selectTextByName(name string) {
 	if a.textLibrary.SelectByName(name) {
 		text := a.textLibrary.GetCurrentText()
-		a.typingTest.SetSampleText(text.Content)
 		a.mode = "text"
+		a.applyTestSettings()
+		a.noteFrontMatterError()
+		a.setTextSample(text.Content)
 		a.testStarted = time.Time{}
 		// Reset scroll state
 		a.currentScrollLine = 0
@@ -852,10 +2358,197 @@ func (a *App) selectTextByName(name string) {
 	}
 }
 
+// repeatCurrentText restarts the test on the current text repeated several
+// times for endurance practice. arg is the count typed after the command name,
+// e.g. "3x"; invalid counts are ignored. Only texts can be repeated, generated
+// words don't run out anyway.
+func (a *App) repeatCurrentText(arg string) {
+	n, ok := ParseRepeatCount(arg)
+	if !ok || a.mode != "text" {
+		return
+	}
+	a.applyTestSettings()
+	a.setTextSample(RepeatText(a.textLibrary.GetCurrentText().Content, n))
+	a.resetTestView()
+}
+
+// deleteCurrentText asks whether to delete the file of the current text.
+// Only whole texts loaded from files can be deleted; for the default text,
+// stdin, parts of a chunked file and generated words a notice explains why not.
+func (a *App) deleteCurrentText() {
+	text := a.textLibrary.GetCurrentText()
+	if a.mode == "words" {
+		a.confirm = &confirmation{message: "Generated words have no file to delete."}
+		return
+	}
+	if !text.IsFile() {
+		a.confirm = &confirmation{message: fmt.Sprintf("'%s' is not a text file and can't be deleted.", text.Name)}
+		return
+	}
+	if text.Part > 0 {
+		a.confirm = &confirmation{message: fmt.Sprintf("'%s' is only a part of %s. Turn chunking off to delete the whole file.", text.Name, text.Path)}
+		return
+	}
+
+	a.confirm = &confirmation{
+		message: fmt.Sprintf("Delete %s?", text.Path),
+		action:  func() { a.removeTextFile(text) },
+	}
+}
+
+// duplicateCurrentText copies the current text into a new file in the user's
+// texts directory, reloads the library and selects the copy for editing.
+// Failures are shown as a notice.
+func (a *App) duplicateCurrentText() {
+	if a.mode == "words" {
+		a.confirm = &confirmation{message: "Generated words can't be duplicated."}
+		return
+	}
+	if a.textLibrary.UserTextsDir() == "" {
+		a.confirm = &confirmation{message: "There is no texts directory to copy to."}
+		return
+	}
+	path, err := a.textLibrary.Duplicate(a.textLibrary.GetCurrentText())
+	if err != nil {
+		a.confirm = &confirmation{message: fmt.Sprintf("Duplicate failed: %v", err)}
+		return
+	}
+
+	a.textLibrary.Reload()
+	a.initCommands()
+	for _, text := range a.textLibrary.GetAllTexts() {
+		if text.Path == path {
+			a.selectTextByName(text.Name)
+			break
+		}
+	}
+}
+
+// removeTextFile deletes a text's file, reloads the library and selects the
+// text that followed it. Without texts left, word mode is used if possible.
+func (a *App) removeTextFile(text TextSource) {
+	if err := os.Remove(text.Path); err != nil {
+		a.confirm = &confirmation{message: fmt.Sprintf("Could not delete %s: %v", text.Path, err)}
+		return
+	}
+
+	a.textLibrary.Reload()
+	a.initCommands()
+	if a.textLibrary.HasTexts() {
+		a.selectTextByName(a.textLibrary.GetCurrentText().Name)
+	} else if a.wordLibrary.HasWordSets() {
+		a.selectWordSet(a.wordLibrary.GetCurrentWordSet().Name)
+	}
+}
+
+// importResult is the outcome of a URL import, posted to the event loop once
+// the download is done.
+type importResult struct {
+	url     string
+	content string
+	err     error
+	notice  *confirmation // Notice shown while fetching, cleared with the result
+}
+
+// importURL fetches a text from a URL in the background and shows a notice
+// until it arrives. The result is posted to the event loop, which adds it to
+// the library with finishImport.
+func (a *App) importURL(rawURL string) {
+	if !strings.Contains(rawURL, "://") {
+		rawURL = "https://" + rawURL
+	}
+	notice := &confirmation{message: fmt.Sprintf("Fetching %s…", rawURL)}
+	a.confirm = notice
+
+	go func() {
+		client := &http.Client{Timeout: importTimeout}
+		content, err := FetchText(client, rawURL)
+		a.screen.PostEvent(tcell.NewEventInterrupt(importResult{url: rawURL, content: content, err: err, notice: notice}))
+	}()
+}
+
+// finishImport adds a fetched text to the library and selects it. Failures
+// are shown as a notice.
+func (a *App) finishImport(result importResult) {
+	if a.confirm == result.notice {
+		a.confirm = nil
+	}
+	if result.err != nil {
+		a.confirm = &confirmation{message: fmt.Sprintf("Import failed: %v", result.err)}
+		return
+	}
+	content := a.textLibrary.Preprocess(result.content)
+
+	// Keep names unique so the command and SelectByName find the imported text
+	base := ImportedTextName(result.url)
+	name := base
+	for i := 2; a.textLibrary.HasText(name); i++ {
+		name = fmt.Sprintf("%s (%d)", base, i)
+	}
+
+	parts := chunkTexts(TextSource{Name: name, Content: content}, a.settings.ChunkWords)
+	for _, part := range parts {
+		a.textLibrary.AddText(part)
+	}
+	a.initCommands()
+	a.selectTextByName(parts[0].Name)
+}
+
+// startDailyChallenge starts the challenge of the day: words from the current
+// word set seeded with today's date, so everyone gets the same test. It is
+// added as a text, which keeps its own leaderboard entries and ends after the
+// last word.
+func (a *App) startDailyChallenge() {
+	if !a.wordLibrary.HasWordSets() {
+		a.confirm = &confirmation{message: "The daily challenge needs a word set"}
+		return
+	}
+	now := time.Now()
+	name := DailyChallengeName(now, a.wordLibrary.GetCurrentWordSet().Name)
+	if !a.textLibrary.HasText(name) {
+		content := a.wordLibrary.GenerateSeededWords(SeedFromDate(now), dailyChallengeWords)
+		a.textLibrary.AddText(TextSource{Name: name, Content: content})
+		a.initCommands()
+	}
+	a.selectTextByName(name)
+	a.resetTestView()
+}
+
+// answerConfirm closes the confirmation and runs its action if confirmed.
+func (a *App) answerConfirm(yes bool) {
+	pending := a.confirm
+	a.confirm = nil
+	if yes && pending != nil && pending.action != nil {
+		pending.action()
+	}
+}
+
+// answerResume closes the startup prompt, keeping the restored session or
+// discarding it for a fresh test.
+func (a *App) answerResume(resume bool) {
+	a.resumePrompt = false
+	if !resume {
+		// The text of the declined session only came from the session
+		if a.resumeText != "" {
+			a.textLibrary.RemoveText(a.resumeText)
+		}
+		a.clearSession()
+	}
+	a.resumeText = ""
+}
+
+// toggleStartupPrompt switches asking on startup whether to resume a saved
+// session instead of resuming it right away.
+func (a *App) toggleStartupPrompt() {
+	a.settings.StartupPrompt = !a.settings.StartupPrompt
+	a.saveAllSettings()
+}
+
 // selectWordSet selects a word set and generates random words.
 func (a *App) selectWordSet(name string) {
 	if a.wordLibrary.SelectByName(name) {
 		a.mode = "words"
+		a.applyTestSettings()
 		// Start with a reasonable initial amount of words
 		// We'll dynamically generate more as the user types
 		content := a.wordLibrary.GenerateRandomWords(initialWordCount)
@@ -873,6 +2566,8 @@ func (a *App) selectWordSet(name string) {
 // ensureEnoughWords checks if there's enough text ahead of the cursor and generates more if needed.
 // This ensures the user always has at least 2 lines of text visible below the cursor.
 // Optimized to only check periodically (not on every keystroke) for performance.
+// The words are generated in a goroutine so typing never waits for them; Run
+// merges the batch with mergeWords. Only one generation runs at a time.
 func (a *App) ensureEnoughWords() {
 	cursorPos := a.typingTest.GetCursorPos()
 
@@ -891,27 +2586,36 @@ func (a *App) ensureEnoughWords() {
 
 	sampleText := a.typingTest.GetSampleText()
 
-	// Calculate how much text remains after cursor
-	remainingText := ""
-	sampleRunes := []rune(sampleText)
-	if cursorPos < len(sampleRunes) {
-		remainingText = string(sampleRunes[cursorPos:])
-	}
-
-	// Wrap remaining text to see how many lines are left
-	remainingLines := wrapText(remainingText, maxWidth)
+	// Wrap the text after the cursor to see how many lines are left; only
+	// enough lines to compare against the threshold are needed
+	remainingLines := wrapRunesWindow(a.typingTest.GetSampleRunes(), maxWidth, cursorPos, wordModeLinesThreshold)
 
 	// If less than threshold lines remaining, generate more words
-	if len(remainingLines) < wordModeLinesThreshold {
-		// Generate a chunk of new words
-		newWords := a.wordLibrary.GenerateRandomWords(wordGenerationChunk)
-		if newWords != "" {
-			// Append new words to existing text
-			updatedText := sampleText + " " + newWords
-			// Use UpdateSampleText to preserve typing progress, stats, and cursor position
-			a.typingTest.UpdateSampleText(updatedText)
-		}
+	if len(remainingLines) < wordModeLinesThreshold && !a.wordsPending {
+		a.wordsPending = true
+		// The word set is picked here; the library guards its random source
+		wordSet := a.wordLibrary.GetCurrentWordSet().Name
+		go func() {
+			a.generatedWords <- wordBatch{
+				base:  sampleText,
+				words: a.wordLibrary.ContinueWords(wordSet, sampleText, wordGenerationChunk),
+			}
+		}()
+	}
+}
+
+// mergeWords appends a batch of generated words to the sample text. Batches
+// for a sample text that was replaced in the meantime (e.g. by a restart) are
+// dropped.
+func (a *App) mergeWords(batch wordBatch) {
+	a.wordsPending = false
+	if batch.words == "" || a.mode != "words" || a.typingTest.GetSampleText() != batch.base {
+		return
 	}
+	// Use UpdateSampleText to preserve typing progress, stats, and cursor position
+	a.typingTest.UpdateSampleText(batch.base + " " + batch.words)
+	// Check again on the next key in case one chunk wasn't enough
+	a.lastCheckPosition = 0
 }
 
 // setTimeLimit sets the time limit in seconds and switches to time-based limit.
@@ -936,150 +2640,696 @@ func (a *App) setWordLimit(words int) {
 	a.saveAllSettings()
 }
 
+// setChallenge sets the pass/fail goal judged on the results screen from the
+// command argument, e.g. "70 95". Invalid goals are explained in a notice.
+func (a *App) setChallenge(arg string) {
+	challenge, err := ParseChallenge(arg)
+	if err != nil {
+		a.confirm = &confirmation{message: fmt.Sprintf("Challenge not set: %v", err)}
+		return
+	}
+	a.challenge = &challenge
+}
+
+// toggleLimitType flips between the time and the word limit, keeping the
+// stored time and word counts. In word mode the test starts over with words
+// for the new limit.
+func (a *App) toggleLimitType() {
+	if a.limitType == "time" {
+		a.limitType = "words"
+	} else {
+		a.limitType = "time"
+	}
+	a.regenerateWords()
+	a.saveAllSettings()
+}
+
+// togglePause pauses a running test or resumes a paused one. While paused the
+// clock and the word mode countdown stand still and typing is ignored.
+func (a *App) togglePause() {
+	if a.typingTest.IsPaused() {
+		pause := a.typingTest.Resume()
+		if !a.testStarted.IsZero() {
+			a.testStarted = a.testStarted.Add(pause)
+		}
+		return
+	}
+	a.typingTest.Pause()
+}
+
+// wordModeElapsed returns the seconds since the word mode test started. While
+// paused it is the time up to the pause.
+func (a *App) wordModeElapsed() float64 {
+	if pausedAt := a.typingTest.GetStats().GetPausedAt(); !pausedAt.IsZero() {
+		return pausedAt.Sub(a.testStarted).Seconds()
+	}
+	return time.Since(a.testStarted).Seconds()
+}
+
 // initCommands initializes the command palette with all available commands.
 func (a *App) initCommands() {
+	keymap := a.keymap()
 	commands := []Command{
 		{
-			Name:        "theme: default",
-			Description: "Switch to default terminal theme",
+			Name:        "theme: default",
+			Description: "Switch to default terminal theme",
+			Action: func(app *App) {
+				app.setTheme(DefaultTheme)
+			},
+		},
+		{
+			Name:        "theme: gruvbox",
+			Description: "Switch to gruvbox theme (dark)",
+			Action: func(app *App) {
+				app.setTheme(GruvboxTheme)
+			},
+		},
+		{
+			Name:        "theme: kanagawa",
+			Description: "Switch to kanagawa theme (dark)",
+			Action: func(app *App) {
+				app.setTheme(KanagawaTheme)
+			},
+		},
+		{
+			Name:        "theme: gruvbox-light",
+			Description: "Switch to gruvbox light theme",
+			Action: func(app *App) {
+				app.setTheme(GruvboxLightTheme)
+			},
+		},
+		{
+			Name:        "theme: solarized-light",
+			Description: "Switch to solarized light theme",
+			Action: func(app *App) {
+				app.setTheme(SolarizedLightTheme)
+			},
+		},
+		{
+			Name:        "theme: catppuccin-latte",
+			Description: "Switch to catppuccin latte theme (light)",
+			Action: func(app *App) {
+				app.setTheme(CatppuccinLatteTheme)
+			},
+		},
+		{
+			Name:        "theme: cyberpunk",
+			Description: "Switch to cyberpunk theme (dark, neon colors)",
+			Action: func(app *App) {
+				app.setTheme(CyberpunkTheme)
+			},
+		},
+		{
+			Name:        "theme: midnight",
+			Description: "Switch to midnight theme (dark, blue tones)",
+			Action: func(app *App) {
+				app.setTheme(MidnightTheme)
+			},
+		},
+		{
+			Name:        "theme: ocean-deep",
+			Description: "Switch to ocean deep theme (dark, aqua tones)",
+			Action: func(app *App) {
+				app.setTheme(OceanDeepTheme)
+			},
+		},
+		{
+			Name:        "theme: dracula",
+			Description: "Switch to dracula theme (dark, purple tones)",
+			Action: func(app *App) {
+				app.setTheme(DraculaTheme)
+			},
+		},
+		{
+			Name:        "theme: lavender-dream",
+			Description: "Switch to lavender dream theme (light, pastel purple)",
+			Action: func(app *App) {
+				app.setTheme(LavenderDreamTheme)
+			},
+		},
+		{
+			Name:        "theme: mint-fresh",
+			Description: "Switch to mint fresh theme (light, pastel green)",
+			Action: func(app *App) {
+				app.setTheme(MintFreshTheme)
+			},
+		},
+		{
+			Name:        "theme: peach-soft",
+			Description: "Switch to peach soft theme (light, warm tones)",
+			Action: func(app *App) {
+				app.setTheme(PeachSoftTheme)
+			},
+		},
+		{
+			Name:        "theme: rosewater",
+			Description: "Switch to rosewater theme (light, pink tones)",
+			Action: func(app *App) {
+				app.setTheme(RosewaterTheme)
+			},
+		},
+		{
+			Name:        "theme: high-contrast-dark",
+			Description: "Switch to high contrast dark (black/white)",
+			Action: func(app *App) {
+				app.setTheme(HighContrastDarkTheme)
+			},
+		},
+		{
+			Name:        "theme: high-contrast-light",
+			Description: "Switch to high contrast light (white/black)",
+			Action: func(app *App) {
+				app.setTheme(HighContrastLightTheme)
+			},
+		},
+		{
+			Name:        "theme: high-visibility",
+			Description: "Switch to high visibility theme (yellow/black)",
+			Action: func(app *App) {
+				app.setTheme(HighVisibilityTheme)
+			},
+		},
+		{
+			Name:        "theme: deuteranopia",
+			Description: "Switch to colorblind-friendly theme (blue/orange)",
+			Action: func(app *App) {
+				app.setTheme(DeuteranopiaTheme)
+			},
+		},
+		{
+			Name:        "theme: toggle last",
+			Description: "Switch back to the previously used theme",
+			Action: func(app *App) {
+				app.toggleLastTheme()
+			},
+		},
+		{
+			Name:        "theme: previous",
+			Description: "Switch to the previous theme of the cycle",
+			Action: func(app *App) {
+				app.cycleThemeBack()
+			},
+		},
+		{
+			Name:        "theme: add to favorites",
+			Description: "Include the current theme when cycling with " + keymap.Label(ActionCycleTheme) + " (favorites only)",
+			Action: func(app *App) {
+				app.addFavoriteTheme()
+			},
+		},
+		{
+			Name:        "theme: remove from favorites",
+			Description: "Stop cycling through the current theme (no favorites: all themes)",
+			Action: func(app *App) {
+				app.removeFavoriteTheme()
+			},
+		},
+		{
+			Name:        "theme: toggle auto day/night",
+			Description: "Switch between day and night themes automatically by time",
+			Action: func(app *App) {
+				app.toggleAutoTheme()
+			},
+		},
+		{
+			Name:        "text: browse",
+			Description: "Browse texts with a preview",
+			Action: func(app *App) {
+				app.openTextBrowser()
+			},
+		},
+		{
+			Name:        "text: random",
+			Description: "Select a random text",
+			Action: func(app *App) {
+				app.selectRandomText()
+			},
+		},
+		{
+			Name:        "quote: random",
+			Description: "Practice a single random sentence from the texts",
+			Action: func(app *App) {
+				app.selectRandomQuote()
+			},
+		},
+		{
+			Name:        "text: next",
+			Description: "Practice the next text of the library",
+			Action: func(app *App) {
+				app.stepText(1)
+			},
+		},
+		{
+			Name:        "text: previous",
+			Description: "Practice the previous text of the library",
+			Action: func(app *App) {
+				app.stepText(-1)
+			},
+		},
+		{
+			Name:        "daily challenge",
+			Description: "Type today's challenge, the same words for everyone on this day",
+			Action: func(app *App) {
+				app.startDailyChallenge()
+			},
+		},
+		{
+			Name:        "text: repeat",
+			Description: "Type the current text several times in a row, e.g. 'text: repeat 3x' (no count: once)",
+			Action: func(app *App) {
+				app.repeatCurrentText("1")
+			},
+			ArgAction: func(app *App, arg string) {
+				app.repeatCurrentText(arg)
+			},
+		},
+		{
+			Name:        "text: duplicate",
+			Description: "Copy the current text into a new file to edit (<name>-copy.txt)",
 			Action: func(app *App) {
-				app.theme = DefaultTheme
-				app.saveThemePreference()
+				app.duplicateCurrentText()
 			},
 		},
 		{
-			Name:        "theme: gruvbox",
-			Description: "Switch to gruvbox theme (dark)",
+			Name:        "text: delete current",
+			Description: "Delete the file of the current text (asks first)",
 			Action: func(app *App) {
-				app.theme = GruvboxTheme
-				app.saveThemePreference()
+				app.deleteCurrentText()
 			},
 		},
 		{
-			Name:        "theme: kanagawa",
-			Description: "Switch to kanagawa theme (dark)",
+			Name:        "text: import url",
+			Description: "Fetch a text from the web, e.g. 'text: import url example.com/poem.html'",
 			Action: func(app *App) {
-				app.theme = KanagawaTheme
-				app.saveThemePreference()
+				app.confirm = &confirmation{message: "Type the URL after the command, e.g. 'text: import url example.com/poem.html'."}
+			},
+			ArgAction: func(app *App, arg string) {
+				app.importURL(arg)
 			},
 		},
 		{
-			Name:        "theme: gruvbox-light",
-			Description: "Switch to gruvbox light theme",
+			Name:        "text: toggle gutenberg cleanup",
+			Description: "Strip Project Gutenberg headers and license footers from texts",
 			Action: func(app *App) {
-				app.theme = GruvboxLightTheme
-				app.saveThemePreference()
+				app.toggleCleanGutenberg()
 			},
 		},
 		{
-			Name:        "theme: solarized-light",
-			Description: "Switch to solarized light theme",
+			Name:        "text: toggle proofreading",
+			Description: "Show texts with typos and type them corrected",
 			Action: func(app *App) {
-				app.theme = SolarizedLightTheme
-				app.saveThemePreference()
+				app.toggleProofread()
 			},
 		},
 		{
-			Name:        "theme: catppuccin-latte",
-			Description: "Switch to catppuccin latte theme (light)",
+			Name:        "text: preprocess",
+			Description: "Clean up texts in order, e.g. 'text: preprocess strip-comments, collapse-spaces' (no names: none)",
 			Action: func(app *App) {
-				app.theme = CatppuccinLatteTheme
-				app.saveThemePreference()
+				app.setPipeline("")
+			},
+			ArgAction: func(app *App, arg string) {
+				app.setPipeline(arg)
 			},
 		},
 		{
-			Name:        "theme: cyberpunk",
-			Description: "Switch to cyberpunk theme (dark, neon colors)",
+			Name:        "text: chunk words",
+			Description: "Split long texts into parts of about N words, e.g. 'text: chunk words 200' (no number: whole texts)",
 			Action: func(app *App) {
-				app.theme = CyberpunkTheme
-				app.saveThemePreference()
+				app.setChunkWords("0")
+			},
+			ArgAction: func(app *App, arg string) {
+				app.setChunkWords(arg)
 			},
 		},
 		{
-			Name:        "theme: midnight",
-			Description: "Switch to midnight theme (dark, blue tones)",
+			Name:        "text: least practiced",
+			Description: "Select the text with the fewest attempts",
 			Action: func(app *App) {
-				app.theme = MidnightTheme
-				app.saveThemePreference()
+				app.selectLeastPracticedText()
 			},
 		},
 		{
-			Name:        "theme: ocean-deep",
-			Description: "Switch to ocean deep theme (dark, aqua tones)",
+			Name:        "playlist: next",
+			Description: "Practice the next text of the playlist",
 			Action: func(app *App) {
-				app.theme = OceanDeepTheme
-				app.saveThemePreference()
+				app.stepPlaylist(1)
 			},
 		},
 		{
-			Name:        "theme: dracula",
-			Description: "Switch to dracula theme (dark, purple tones)",
+			Name:        "playlist: previous",
+			Description: "Practice the previous text of the playlist",
 			Action: func(app *App) {
-				app.theme = DraculaTheme
-				app.saveThemePreference()
+				app.stepPlaylist(-1)
 			},
 		},
 		{
-			Name:        "theme: lavender-dream",
-			Description: "Switch to lavender dream theme (light, pastel purple)",
+			Name:        "playlist: add current text",
+			Description: "Append the current text to the playlist",
 			Action: func(app *App) {
-				app.theme = LavenderDreamTheme
-				app.saveThemePreference()
+				app.addCurrentTextToPlaylist()
 			},
 		},
 		{
-			Name:        "theme: mint-fresh",
-			Description: "Switch to mint fresh theme (light, pastel green)",
+			Name:        "playlist: toggle auto-advance",
+			Description: "Move on to the next playlist text after each finished test",
 			Action: func(app *App) {
-				app.theme = MintFreshTheme
-				app.saveThemePreference()
+				app.togglePlaylistMode()
 			},
 		},
 		{
-			Name:        "theme: peach-soft",
-			Description: "Switch to peach soft theme (light, warm tones)",
+			Name:        "typing: toggle space skips word",
+			Description: "Space in the middle of a word jumps to the next word",
 			Action: func(app *App) {
-				app.theme = PeachSoftTheme
-				app.saveThemePreference()
+				app.toggleSkipToNextWordOnSpace()
 			},
 		},
 		{
-			Name:        "theme: rosewater",
-			Description: "Switch to rosewater theme (light, pink tones)",
+			Name:        "typing: toggle word semantics",
+			Description: "Match input per word (extra/missing letters allowed, per-word accuracy)",
 			Action: func(app *App) {
-				app.theme = RosewaterTheme
-				app.saveThemePreference()
+				app.toggleTypingSemantics()
 			},
 		},
 		{
-			Name:        "theme: high-contrast-dark",
-			Description: "Switch to high contrast dark (black/white)",
+			Name:        "typing: toggle blind mode",
+			Description: "Hide mistakes while typing and only reveal them in the results",
 			Action: func(app *App) {
-				app.theme = HighContrastDarkTheme
-				app.saveThemePreference()
+				app.toggleBlindMode()
 			},
 		},
 		{
-			Name:        "theme: high-contrast-light",
-			Description: "Switch to high contrast light (white/black)",
+			Name:        "typing: error feedback off",
+			Description: "Don't ring the bell or flash on mistakes",
 			Action: func(app *App) {
-				app.theme = HighContrastLightTheme
-				app.saveThemePreference()
+				app.setErrorFeedback(ErrorFeedbackNone)
 			},
 		},
 		{
-			Name:        "theme: high-visibility",
-			Description: "Switch to high visibility theme (yellow/black)",
+			Name:        "typing: error feedback subtle",
+			Description: "Ring the bell now and then and flash muted on mistakes",
 			Action: func(app *App) {
-				app.theme = HighVisibilityTheme
-				app.saveThemePreference()
+				app.setErrorFeedback(ErrorFeedbackSubtle)
 			},
 		},
 		{
-			Name:        "text: random",
-			Description: "Select a random text",
+			Name:        "typing: error feedback strong",
+			Description: "Ring the bell and flash brightly on mistakes",
 			Action: func(app *App) {
-				app.selectRandomText()
+				app.setErrorFeedback(ErrorFeedbackStrong)
+			},
+		},
+		{
+			Name:        "typing: toggle exact finish",
+			Description: "Require all errors to be corrected before the test finishes",
+			Action: func(app *App) {
+				app.toggleRequireExactToFinish()
+			},
+		},
+		{
+			Name:        "typing: toggle strict finish",
+			Description: "Require a trailing newline or space of the text to be typed to finish",
+			Action: func(app *App) {
+				app.toggleStrictFinish()
+			},
+		},
+		{
+			Name:        "typing: toggle error tracking",
+			Description: "Track misspelled words and list them on the results screen",
+			Action: func(app *App) {
+				app.toggleTrackErrors()
+			},
+		},
+		{
+			Name:        "timer: toggle start on load",
+			Description: "Start the timer when the text appears instead of on the first keystroke",
+			Action: func(app *App) {
+				app.toggleTimerStart()
+			},
+		},
+		{
+			Name:        "tab key: auto",
+			Description: "Tab types a tab where the text has one and restarts the test elsewhere",
+			Action: func(app *App) {
+				app.setTabKey(TabKeyAuto)
+			},
+		},
+		{
+			Name:        "tab key: restart",
+			Description: "Tab always restarts the test",
+			Action: func(app *App) {
+				app.setTabKey(TabKeyRestart)
+			},
+		},
+		{
+			Name:        "tab key: type tab",
+			Description: "Tab always types a tab character",
+			Action: func(app *App) {
+				app.setTabKey(TabKeyType)
+			},
+		},
+		{
+			Name:        "results enter: restart",
+			Description: "Enter on the results screen restarts the test",
+			Action: func(app *App) {
+				app.setResultsEnterAction(ResultsEnterRestart)
+			},
+		},
+		{
+			Name:        "results enter: next",
+			Description: "Enter on the results screen moves on to the next text, word set or quote",
+			Action: func(app *App) {
+				app.setResultsEnterAction(ResultsEnterNext)
+			},
+		},
+		{
+			Name:        "replay: last test",
+			Description: "Watch your typing played back with its real timing",
+			Action: func(app *App) {
+				app.startReplay()
+			},
+		},
+		{
+			Name:        "command: history",
+			Description: "Re-run a command from this session",
+			SkipHistory: true,
+			Action: func(app *App) {
+				app.commandMenu.ShowHistory()
+			},
+		},
+		{
+			Name:        "display: toggle transparent background",
+			Description: "Let the terminal background show through instead of the theme background",
+			Action: func(app *App) {
+				app.toggleTransparentBackground()
+			},
+		},
+		{
+			Name:        "display: toggle results graph",
+			Description: "Show or hide the WPM timeline on the results screen",
+			Action: func(app *App) {
+				app.toggleShowGraph()
+			},
+		},
+		{
+			Name:        "display: toggle wpm sparkline",
+			Description: "Show or hide a sparkline of recent WPM in the title row while typing",
+			Action: func(app *App) {
+				app.toggleShowSparkline()
+			},
+		},
+		{
+			Name:        "display: toggle dim function words",
+			Description: "Dim common words like 'the', 'a' and 'of' so content words stand out",
+			Action: func(app *App) {
+				app.toggleDimStopwords()
+			},
+		},
+		{
+			Name:        "display: toggle celebration",
+			Description: "Play a confetti animation after a test with 100% accuracy",
+			Action: func(app *App) {
+				app.toggleCelebrateMode()
+			},
+		},
+		{
+			Name:        "display: top margin",
+			Description: "Leave N empty rows above the text, e.g. 'display: top margin 3' (no number: none)",
+			Action: func(app *App) {
+				app.setTypingMargin(true, "0")
+			},
+			ArgAction: func(app *App, arg string) {
+				app.setTypingMargin(true, arg)
+			},
+		},
+		{
+			Name:        "display: bottom margin",
+			Description: "Leave N empty rows below the text, e.g. 'display: bottom margin 3' (no number: none)",
+			Action: func(app *App) {
+				app.setTypingMargin(false, "0")
+			},
+			ArgAction: func(app *App, arg string) {
+				app.setTypingMargin(false, arg)
+			},
+		},
+		{
+			Name:        "display: toggle big word",
+			Description: "Show the current word enlarged above the text in word mode",
+			Action: func(app *App) {
+				app.toggleBigWord()
+			},
+		},
+		{
+			Name:        "display: toggle bold text",
+			Description: "Draw the typing text bold for readability",
+			Action: func(app *App) {
+				app.toggleBoldText()
+			},
+		},
+		{
+			Name:        "display: toggle underlined whitespace errors",
+			Description: "Underline mistyped spaces and newlines instead of showing '_'",
+			Action: func(app *App) {
+				app.toggleUnderlineWhitespace()
+			},
+		},
+		{
+			Name:        "display: toggle mistyped characters",
+			Description: "Show what was typed above incorrect characters",
+			Action: func(app *App) {
+				app.toggleMistypedOverlay()
+			},
+		},
+		{
+			Name:        "speed: wpm",
+			Description: "Show typing speed in words per minute",
+			Action: func(app *App) {
+				app.setSpeedUnit(SpeedUnitWPM)
+			},
+		},
+		{
+			Name:        "speed: kpm",
+			Description: "Show typing speed in correct keystrokes per minute",
+			Action: func(app *App) {
+				app.setSpeedUnit(SpeedUnitKPM)
+			},
+		},
+		{
+			Name:        "speed: wpm and kpm",
+			Description: "Show words and keystrokes per minute side by side",
+			Action: func(app *App) {
+				app.setSpeedUnit(SpeedUnitBoth)
+			},
+		},
+		{
+			Name:        "scroll: smooth",
+			Description: "Scroll only when the cursor reaches the bottom of the text",
+			Action: func(app *App) {
+				app.setScrollAnchor(ScrollAnchorSmooth)
+			},
+		},
+		{
+			Name:        "scroll: top",
+			Description: "Keep the cursor near the top of the text",
+			Action: func(app *App) {
+				app.setScrollAnchor(ScrollAnchorTop)
+			},
+		},
+		{
+			Name:        "scroll: center",
+			Description: "Keep the cursor in the middle of the text",
+			Action: func(app *App) {
+				app.setScrollAnchor(ScrollAnchorCenter)
+			},
+		},
+		{
+			Name:        "scroll: bottom",
+			Description: "Keep the cursor near the bottom of the text",
+			Action: func(app *App) {
+				app.setScrollAnchor(ScrollAnchorBottom)
+			},
+		},
+		{
+			Name:        "display: toggle focus fade",
+			Description: "Dim the title, help and stats while typing",
+			Action: func(app *App) {
+				app.toggleFocusFade()
+			},
+		},
+		{
+			Name:        "display: toggle horizontal focus",
+			Description: "Keep the cursor at a fixed column and scroll the text under it",
+			Action: func(app *App) {
+				app.toggleHorizontalFocus()
+			},
+		},
+		{
+			Name:        "display: toggle generation indicator",
+			Description: "Mark the end of the text while more words are generated in word mode",
+			Action: func(app *App) {
+				app.toggleGenerationIndicator()
+			},
+		},
+		{
+			Name:        "display: toggle dim behind overlays",
+			Description: "Fade the screen behind the command palette and other overlays",
+			Action: func(app *App) {
+				app.toggleDimBehindOverlays()
+			},
+		},
+		{
+			Name:        "display: toggle live stats",
+			Description: "Show or hide the live stats and progress while typing",
+			Action: func(app *App) {
+				app.toggleLiveStats()
+			},
+		},
+		{
+			Name:        "display: toggle line numbers",
+			Description: "Show line numbers left of the text in text mode",
+			Action: func(app *App) {
+				app.toggleLineNumbers()
+			},
+		},
+		{
+			Name:        "display: toggle wrapped line numbers",
+			Description: "Number wrapped lines on screen instead of lines of the text",
+			Action: func(app *App) {
+				app.toggleLineNumbering()
+			},
+		},
+		{
+			Name:        "display: toggle ascii mode",
+			Description: "Use plain ASCII for boxes and the graph on terminals without Unicode",
+			Action: func(app *App) {
+				app.toggleASCIIMode()
+			},
+		},
+		{
+			Name:        "stats: report",
+			Description: "Show average WPM per day or week",
+			Action: func(app *App) {
+				app.openProgressReport()
+			},
+		},
+		{
+			Name:        "stats: lifetime",
+			Description: "Show how much you have typed across all tests",
+			Action: func(app *App) {
+				app.openLifetimeStats()
+			},
+		},
+		{
+			Name:        "diagnostics",
+			Description: "Show terminal capabilities and theme colors for bug reports",
+			Action: func(app *App) {
+				app.showDiagnostics = true
+			},
+		},
+		{
+			Name:        "words: regenerate",
+			Description: "Start over with new random words from the current word set",
+			Action: func(app *App) {
+				app.regenerateWords()
 			},
 		},
 		{
@@ -1089,6 +3339,20 @@ func (a *App) initCommands() {
 				app.restartTest()
 			},
 		},
+		{
+			Name:        "finish test",
+			Description: "Stop now and show results for what has been typed",
+			Action: func(app *App) {
+				app.finishTest()
+			},
+		},
+		{
+			Name:        "session: toggle resume prompt",
+			Description: "Ask on startup whether to resume a saved session",
+			Action: func(app *App) {
+				app.toggleStartupPrompt()
+			},
+		},
 		{
 			Name:        "clear session",
 			Description: "Clear saved session and start fresh",
@@ -1122,13 +3386,105 @@ func (a *App) initCommands() {
 		wordSetName := wordSet.Name
 		commands = append(commands, Command{
 			Name:        fmt.Sprintf("words: %s", wordSetName),
-			Description: fmt.Sprintf("Practice random words from '%s'", wordSetName),
+			Description: wordSet.Describe(),
 			Action: func(app *App) {
 				app.selectWordSet(wordSetName)
 			},
+			WordSet: wordSetName,
 		})
 	}
 
+	// Add case transforms for shift key practice
+	commands = append(commands, Command{
+		Name:        "words: capitalized",
+		Description: "Toggle capitalizing the first letter of every word",
+		Action: func(app *App) {
+			app.toggleWordCase(WordCaseCapitalized)
+		},
+	}, Command{
+		Name:        "words: min length",
+		Description: "Only use words of at least N letters, e.g. 'words: min length 5' (no number: any length)",
+		Action: func(app *App) {
+			app.setMinWordLength("0")
+		},
+		ArgAction: func(app *App, arg string) {
+			app.setMinWordLength(arg)
+		},
+	}, Command{
+		Name:        "words: random capitals",
+		Description: "Toggle capitalizing a random letter of every word",
+		Action: func(app *App) {
+			app.toggleWordCase(WordCaseRandomCapitals)
+		},
+	}, Command{
+		Name:        "words: punctuation on",
+		Description: "End sentences with . ? or ! and add commas and semicolons",
+		Action: func(app *App) {
+			app.setWordPunctuation(true)
+		},
+	}, Command{
+		Name:        "words: punctuation off",
+		Description: "Generate words without punctuation",
+		Action: func(app *App) {
+			app.setWordPunctuation(false)
+		},
+	}, Command{
+		Name:        "words: capitalization on",
+		Description: "Capitalize some words and, with punctuation, every sentence start",
+		Action: func(app *App) {
+			app.setWordCapitalization(true)
+		},
+	}, Command{
+		Name:        "words: capitalization off",
+		Description: "Only capitalize words as the word case asks",
+		Action: func(app *App) {
+			app.setWordCapitalization(false)
+		},
+	}, Command{
+		Name:        "words: numbers on",
+		Description: "Replace some words with numbers like 42 or 2024",
+		Action: func(app *App) {
+			app.setWordNumbers(true)
+		},
+	}, Command{
+		Name:        "words: numbers off",
+		Description: "Generate words without numbers",
+		Action: func(app *App) {
+			app.setWordNumbers(false)
+		},
+	}, Command{
+		Name:        "words: hard only",
+		Description: "Toggle using only the heavier half of the words of weighted word sets",
+		Action: func(app *App) {
+			app.toggleHardWordsOnly()
+		},
+	})
+
+	commands = append(commands, Command{
+		Name:        "challenge: set",
+		Description: "Pass or fail the next tests on a goal, e.g. 'challenge: set 70 95' for 70 WPM at 95% accuracy",
+		Action: func(app *App) {
+			app.setChallenge("")
+		},
+		ArgAction: func(app *App, arg string) {
+			app.setChallenge(arg)
+		},
+	})
+	commands = append(commands, Command{
+		Name:        "challenge: clear",
+		Description: "Stop judging tests against the challenge",
+		Action: func(app *App) {
+			app.challenge = nil
+		},
+	})
+	commands = append(commands, Command{
+		Name:        "limit: toggle type",
+		Description: "Switch between the time and the word limit (" + keymap.Label(ActionLimitType) + ")",
+		Action: func(app *App) {
+			app.toggleLimitType()
+		},
+	})
+
 	// Add time limit commands (automatically switches to time-based limit)
 	commands = append(commands, Command{
 		Name:        "limit: 30 seconds",
@@ -1175,5 +3531,15 @@ func (a *App) initCommands() {
 		},
 	})
 
+	// Theme names alone find their theme command, without the "theme: " prefix
+	for i, cmd := range commands {
+		if name, ok := strings.CutPrefix(cmd.Name, "theme: "); ok {
+			if _, ok := FindTheme(name); !ok {
+				continue
+			}
+			commands[i].Keywords = append(commands[i].Keywords, name)
+		}
+	}
+
 	a.commandMenu.SetCommands(commands)
 }
@@ -0,0 +1,894 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// newTestApp creates an App backed by a simulation screen with all config
+// files redirected to a temporary directory.
+func newTestApp(t *testing.T) *App {
+	t.Helper()
+
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	return startTestApp(t)
+}
+
+// startTestApp creates an app like newTestApp, but keeps the config directory
+// of the environment, so tests can prepare config files first. The texts
+// directory holds the sample text, so the app doesn't start in the empty state.
+func startTestApp(t *testing.T) *App {
+	t.Helper()
+
+	textsDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(textsDir, "sample.txt"), []byte(defaultSampleText), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	screen := tcell.NewSimulationScreen("")
+	if err := screen.Init(); err != nil {
+		t.Fatalf("failed to initialize simulation screen: %v", err)
+	}
+	screen.SetSize(100, 40)
+	t.Cleanup(screen.Fini)
+
+	app, err := newApp(screen, "", []string{textsDir}, false, false)
+	if err != nil {
+		t.Fatalf("failed to create app: %v", err)
+	}
+	return app
+}
+
+func TestToggleLastTheme(t *testing.T) {
+	app := newTestApp(t)
+	app.setTheme(GruvboxTheme)
+	app.setTheme(DraculaTheme)
+
+	app.toggleLastTheme()
+	if app.theme.Name != GruvboxTheme.Name {
+		t.Errorf("Expected %q after first toggle, got %q", GruvboxTheme.Name, app.theme.Name)
+	}
+
+	app.toggleLastTheme()
+	if app.theme.Name != DraculaTheme.Name {
+		t.Errorf("Expected %q after second toggle, got %q", DraculaTheme.Name, app.theme.Name)
+	}
+}
+
+func TestToggleLastThemeAfterCycle(t *testing.T) {
+	app := newTestApp(t)
+	original := app.theme
+
+	app.cycleTheme()
+	app.toggleLastTheme()
+
+	if app.theme.Name != original.Name {
+		t.Errorf("Expected toggle to return to %q, got %q", original.Name, app.theme.Name)
+	}
+}
+
+func TestReplayReproducesTypedInput(t *testing.T) {
+	app := newTestApp(t)
+
+	app.startReplay()
+	if app.replay != nil {
+		t.Fatal("Expected no replay without recorded input")
+	}
+
+	sample := []rune(app.typingTest.GetSampleText())
+	app.typingTest.TypeCharacter(sample[0])
+	app.typingTest.TypeCharacter('#')
+	app.typingTest.Backspace()
+
+	app.startReplay()
+	if app.getCurrentMode() != ModeReplay {
+		t.Fatalf("Expected replay mode, got %v", app.getCurrentMode())
+	}
+
+	app.replay.SetSpeed(maxReplaySpeed)
+	app.tickReplay(app.lastReplayTick.Add(time.Minute))
+	if !app.replay.IsDone() {
+		t.Fatal("Expected replay to finish")
+	}
+	if got, want := app.replayTest.GetCursorPos(), app.typingTest.GetCursorPos(); got != want {
+		t.Errorf("Expected replay cursor at %d, got %d", want, got)
+	}
+
+	app.exitReplay()
+	if app.getCurrentMode() == ModeReplay {
+		t.Error("Expected replay mode to end after exit")
+	}
+}
+
+func TestReplayShowsProofreadText(t *testing.T) {
+	app := newTestApp(t)
+	app.typingTest.SetProofreadText("teh cat", "the cat")
+	typeString(app.typingTest, "the")
+
+	app.startReplay()
+	if !app.replayTest.IsProofreading() {
+		t.Fatal("Expected the replay to be a proofreading test")
+	}
+	if got := string(app.replayTest.GetDisplayRunes()); got != "teh cat" {
+		t.Errorf("Expected the replay to show the text with typos, got %q", got)
+	}
+
+	app.replay.SetSpeed(maxReplaySpeed)
+	app.tickReplay(app.lastReplayTick.Add(time.Minute))
+	if got := len(app.replayTest.GetStats().GetMistakes()); got != 0 {
+		t.Errorf("Expected the corrected words to replay without mistakes, got %d", got)
+	}
+}
+
+func TestTimerStartsOnLoad(t *testing.T) {
+	app := newTestApp(t)
+
+	app.draw()
+	if !app.typingTest.GetStats().GetStartTime().IsZero() {
+		t.Fatal("Expected timer to wait for the first keystroke by default")
+	}
+
+	app.settings.TimerStart = TimerStartOnLoad
+	app.draw()
+	if app.typingTest.GetStats().GetStartTime().IsZero() {
+		t.Error("Expected timer to start when the typing view is shown")
+	}
+}
+
+func TestDiagnosticsOverlay(t *testing.T) {
+	app := newTestApp(t)
+
+	app.showDiagnostics = true
+	if app.getCurrentMode() != ModeDiagnostics {
+		t.Fatalf("Expected diagnostics mode, got %v", app.getCurrentMode())
+	}
+	app.draw()
+
+	app.handleKey(tcell.NewEventKey(tcell.KeyEscape, 0, tcell.ModNone))
+	if app.showDiagnostics || app.quit {
+		t.Error("Expected Esc to close diagnostics without quitting")
+	}
+}
+
+func TestTransparentBackground(t *testing.T) {
+	app := newTestApp(t)
+	app.setTheme(GruvboxTheme)
+	app.typingTest.TypeCharacter('#') // Include an incorrect character
+
+	backgrounds := func() map[tcell.Color]bool {
+		app.draw()
+		screen := app.screen.(tcell.SimulationScreen)
+		cells, width, height := screen.GetContents()
+		seen := make(map[tcell.Color]bool)
+		for i := 0; i < width*height; i++ {
+			_, bg, _ := cells[i].Style.Decompose()
+			seen[bg] = true
+		}
+		return seen
+	}
+
+	if seen := backgrounds(); seen[tcell.ColorDefault] {
+		t.Fatal("Expected the theme background to fill the screen by default")
+	}
+
+	app.settings.TransparentBackground = true
+	seen := backgrounds()
+	if len(seen) != 1 || !seen[tcell.ColorDefault] {
+		t.Errorf("Expected only default backgrounds with transparency on, got %v", seen)
+	}
+}
+
+func TestProgressReport(t *testing.T) {
+	app := newTestApp(t)
+
+	now := time.Now()
+	for _, result := range []TestResult{
+		{Timestamp: now.AddDate(0, 0, -8), Mode: "text", WPM: 40},
+		{Timestamp: now.AddDate(0, 0, -1), Mode: "text", WPM: 50},
+		{Timestamp: now, Mode: "text", WPM: 60},
+	} {
+		if err := AppendResult(result); err != nil {
+			t.Fatalf("AppendResult failed: %v", err)
+		}
+	}
+
+	app.openProgressReport()
+	if app.getCurrentMode() != ModeProgressReport {
+		t.Fatalf("Expected progress report mode, got %v", app.getCurrentMode())
+	}
+	if len(app.reportResults) != 3 {
+		t.Fatalf("Expected 3 results loaded, got %d", len(app.reportResults))
+	}
+	app.draw()
+
+	app.handleKey(tcell.NewEventKey(tcell.KeyRune, 'w', tcell.ModNone))
+	if app.reportPeriod != ReportByWeek {
+		t.Errorf("Expected 'w' to switch to weekly buckets, got %q", app.reportPeriod)
+	}
+	app.draw()
+
+	app.handleKey(tcell.NewEventKey(tcell.KeyEscape, 0, tcell.ModNone))
+	if app.showReport || app.quit {
+		t.Error("Expected Esc to close the report without quitting")
+	}
+}
+
+func TestPlaylistAutoAdvance(t *testing.T) {
+	app := newTestApp(t)
+	app.textLibrary.AddText(TextSource{Name: "one", Content: "ab"})
+	app.textLibrary.AddText(TextSource{Name: "two", Content: "cd"})
+	app.settings.Playlist = []string{"one", "missing", "two"}
+
+	if !app.stepPlaylist(1) || app.textLibrary.GetCurrentText().Name != "one" {
+		t.Fatalf("Expected playlist to start with \"one\", got %q", app.textLibrary.GetCurrentText().Name)
+	}
+
+	// Without playlist mode, restarting keeps the text
+	typeString(app.typingTest, "ab")
+	app.restartTest()
+	if app.textLibrary.GetCurrentText().Name != "one" {
+		t.Fatalf("Expected restart to keep the text, got %q", app.textLibrary.GetCurrentText().Name)
+	}
+
+	app.settings.PlaylistMode = true
+	typeString(app.typingTest, "ab")
+	app.restartTest()
+	if got := app.textLibrary.GetCurrentText().Name; got != "two" {
+		t.Errorf("Expected auto-advance to skip the missing text to \"two\", got %q", got)
+	}
+	if app.typingTest.GetSampleText() != "cd" || app.typingTest.GetCursorPos() != 0 {
+		t.Error("Expected a fresh test with the next text")
+	}
+}
+
+func TestFinishTestEarly(t *testing.T) {
+	app := newTestApp(t)
+
+	// Nothing typed yet: nothing to show
+	app.handleKey(tcell.NewEventKey(tcell.KeyEnter, '\r', tcell.ModCtrl))
+	if app.typingTest.IsFinished() {
+		t.Fatal("Expected finishing an untouched test to be ignored")
+	}
+
+	sample := []rune(app.typingTest.GetSampleText())
+	typeString(app.typingTest, string(sample[:10]))
+	stats := app.typingTest.GetStats()
+	stats.startTime = time.Now().Add(-6 * time.Second)
+
+	app.handleKey(tcell.NewEventKey(tcell.KeyEnter, '\r', tcell.ModCtrl))
+
+	if !app.typingTest.IsFinished() || app.getCurrentMode() != ModeResults {
+		t.Fatal("Expected Ctrl+Enter to finish the test and show results")
+	}
+	if app.typingTest.GetCursorPos() != 10 {
+		t.Errorf("Expected Ctrl+Enter not to type a newline, got cursor %d", app.typingTest.GetCursorPos())
+	}
+	// 10 correct characters (2 words) in about 6 seconds
+	if wpm := stats.GetWPM(); wpm < 15 || wpm > 25 {
+		t.Errorf("Expected WPM for the typed portion (about 20), got %.1f", wpm)
+	}
+	if acc := stats.GetAccuracy(); acc != 100 {
+		t.Errorf("Expected 100%% accuracy, got %.1f%%", acc)
+	}
+	if stats.GetDuration() < 6*time.Second || stats.GetDuration() > 7*time.Second {
+		t.Errorf("Expected the duration to stop at finishing, got %v", stats.GetDuration())
+	}
+
+	results, err := LoadResultsHistory()
+	if err != nil {
+		t.Fatalf("failed to load results history: %v", err)
+	}
+	if len(results) != 0 {
+		t.Errorf("Expected an early finish not to be recorded, got %v", results)
+	}
+}
+
+func TestFocusFadeWhileTyping(t *testing.T) {
+	app := newTestApp(t)
+	app.settings.FocusFade = true
+
+	if app.isTypingActive(time.Now()) {
+		t.Error("Expected no focus fade before typing starts")
+	}
+
+	sample := []rune(app.typingTest.GetSampleText())
+	typeString(app.typingTest, string(sample[:3]))
+	now := time.Now()
+	if !app.isTypingActive(now) {
+		t.Error("Expected focus fade right after a keystroke")
+	}
+	if app.isTypingActive(now.Add(typingIdleTimeout)) {
+		t.Error("Expected focus fade to end once typing is idle")
+	}
+
+	app.settings.FocusFade = false
+	if app.isTypingActive(now) {
+		t.Error("Expected no focus fade when the setting is off")
+	}
+}
+
+func TestTextOverridesAppliedOnSelect(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	textsDir := t.TempDir()
+	files := map[string]string{
+		"code.txt":  "---\ntyping_semantics = word\nrequire_exact_to_finish = true\n---\nfunc main() {}",
+		"prose.txt": "Roads go ever ever on",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(textsDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	screen := tcell.NewSimulationScreen("")
+	if err := screen.Init(); err != nil {
+		t.Fatalf("failed to initialize simulation screen: %v", err)
+	}
+	t.Cleanup(screen.Fini)
+	app, err := newApp(screen, "", []string{textsDir}, false, false)
+	if err != nil {
+		t.Fatalf("failed to create app: %v", err)
+	}
+
+	app.selectTextByName("code")
+	if app.typingTest.GetSemantics() != SemanticsWord || !app.typingTest.GetRequireExactToFinish() {
+		t.Error("Expected the code text's overrides to apply")
+	}
+	if app.typingTest.GetSampleText() != "func main() {}" {
+		t.Errorf("Expected the text without front matter, got %q", app.typingTest.GetSampleText())
+	}
+	if app.settings.TypingSemantics != string(SemanticsCharacter) {
+		t.Error("Expected the overrides not to change the saved settings")
+	}
+
+	app.selectTextByName("prose")
+	if app.typingTest.GetSemantics() != SemanticsCharacter || app.typingTest.GetRequireExactToFinish() {
+		t.Error("Expected the overrides to be reverted after switching texts")
+	}
+}
+
+func TestTogglesKeepTextOverrides(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	textsDir := t.TempDir()
+	files := map[string]string{
+		"code.txt":   "---\ntyping_semantics = word\n---\nfunc main() {}",
+		"broken.txt": "---\ntheme = nord\n---\nRoads go ever ever on",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(textsDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	screen := tcell.NewSimulationScreen("")
+	if err := screen.Init(); err != nil {
+		t.Fatalf("failed to initialize simulation screen: %v", err)
+	}
+	t.Cleanup(screen.Fini)
+	app, err := newApp(screen, "", []string{textsDir}, false, false)
+	if err != nil {
+		t.Fatalf("failed to create app: %v", err)
+	}
+
+	app.selectTextByName("code")
+	app.confirm = nil
+	app.toggleTypingSemantics()
+	if app.settings.TypingSemantics != string(SemanticsWord) {
+		t.Errorf("Expected the toggle to change the user's setting, got %q", app.settings.TypingSemantics)
+	}
+	if app.typingTest.GetSemantics() != SemanticsWord {
+		t.Error("Expected the front matter to keep overriding the toggled setting")
+	}
+	if app.confirm == nil || !strings.Contains(app.confirm.message, "typing_semantics") {
+		t.Errorf("Expected a notice that the text overrides the setting, got %+v", app.confirm)
+	}
+
+	app.confirm = nil
+	app.toggleSkipToNextWordOnSpace()
+	if app.typingTest.GetSkipToNextWordOnSpace() != app.settings.SkipToNextWordOnSpace || app.confirm != nil {
+		t.Error("Expected settings the text doesn't override to apply without a notice")
+	}
+
+	app.selectTextByName("broken")
+	if app.confirm == nil || !strings.Contains(app.confirm.message, "theme") {
+		t.Errorf("Expected a notice about the broken front matter, got %+v", app.confirm)
+	}
+}
+
+func TestEmptyState(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	textsDir := t.TempDir()
+	screen := tcell.NewSimulationScreen("")
+	if err := screen.Init(); err != nil {
+		t.Fatalf("failed to initialize simulation screen: %v", err)
+	}
+	screen.SetSize(100, 40)
+	t.Cleanup(screen.Fini)
+
+	app, err := newApp(screen, "", []string{textsDir}, false, false)
+	if err != nil {
+		t.Fatalf("failed to create app: %v", err)
+	}
+	if app.getCurrentMode() != ModeEmpty {
+		t.Fatal("Expected the empty state without texts, stdin and word sets")
+	}
+	app.draw()
+	if text := strings.Join(screenRows(screen), "\n"); !strings.Contains(text, textsDir) {
+		t.Errorf("Expected the empty state to show the texts directory, got:\n%s", text)
+	}
+
+	app, err = newApp(screen, "Typed from stdin", []string{textsDir}, false, false)
+	if err != nil {
+		t.Fatalf("failed to create app: %v", err)
+	}
+	if app.getCurrentMode() == ModeEmpty {
+		t.Error("Expected no empty state when text is piped in")
+	}
+
+	wordsDir, err := GetDefaultWordsDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(wordsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(wordsDir, "fruit.txt"), []byte("apple banana"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	app, err = newApp(screen, "", []string{textsDir}, false, false)
+	if err != nil {
+		t.Fatalf("failed to create app: %v", err)
+	}
+	if app.getCurrentMode() == ModeEmpty || !app.textLibrary.HasTexts() {
+		t.Error("Expected the sample text and no empty state when word sets are available")
+	}
+}
+
+func TestRegenerateWords(t *testing.T) {
+	app := newTestApp(t)
+	app.wordLibrary = newTestWordLibrary(t, "apple banana cherry")
+	app.selectWordSet("test")
+	app.limitType = "words"
+	app.wordLimit = 10
+
+	typeString(app.typingTest, "app")
+	app.regenerateWords()
+
+	if app.typingTest.GetCursorPos() != 0 || app.typingTest.GetStats().GetTotalKeystrokes() != 0 {
+		t.Error("Expected regenerating to reset the cursor and stats")
+	}
+	words := strings.Fields(app.typingTest.GetSampleText())
+	if len(words) != 10*wordLimitMultiplier {
+		t.Errorf("Expected %d words for the word limit, got %d", 10*wordLimitMultiplier, len(words))
+	}
+	for _, word := range words {
+		if word != "apple" && word != "banana" && word != "cherry" {
+			t.Fatalf("Expected words from the current set, got %q", word)
+		}
+	}
+	if app.mode != "words" || app.wordLibrary.GetCurrentWordSet().Name != "test" {
+		t.Error("Expected the mode and word set to be kept")
+	}
+}
+
+func TestDeleteCurrentTextGuard(t *testing.T) {
+	app := newTestApp(t)
+	app.textLibrary.AddText(TextSource{Name: "stdin", Content: "piped text"})
+	app.selectTextByName("stdin")
+
+	app.deleteCurrentText()
+	if app.getCurrentMode() != ModeConfirm || app.confirm.action != nil {
+		t.Fatal("Expected a notice instead of a question for stdin")
+	}
+	app.answerConfirm(true)
+	if app.confirm != nil || app.textLibrary.GetCurrentText().Name != "stdin" {
+		t.Error("Expected the notice to close without deleting anything")
+	}
+
+	app.wordLibrary = newTestWordLibrary(t, "apple banana")
+	app.selectWordSet("test")
+	app.deleteCurrentText()
+	if app.confirm == nil || app.confirm.action != nil {
+		t.Error("Expected a notice instead of a question for generated words")
+	}
+}
+
+func TestDeleteCurrentTextRefusesChunkedParts(t *testing.T) {
+	app := newTestApp(t)
+	textsDir := t.TempDir()
+	book := filepath.Join(textsDir, "book.txt")
+	if err := os.WriteFile(book, []byte(paragraph(30)+"\n\n"+paragraph(30)), 0644); err != nil {
+		t.Fatal(err)
+	}
+	app.textLibrary = NewTextLibraryWithOptions([]string{textsDir}, TextLoadOptions{ChunkWords: 30})
+
+	app.selectTextByName("book - part 1")
+	app.deleteCurrentText()
+	if app.confirm == nil || app.confirm.action != nil {
+		t.Fatal("Expected a notice instead of a question for a part of a file")
+	}
+	app.answerConfirm(true)
+	if _, err := os.Stat(book); err != nil {
+		t.Errorf("Expected the book to be kept, got %v", err)
+	}
+}
+
+func TestDeleteCurrentTextSelectsFallback(t *testing.T) {
+	app := newTestApp(t)
+	textsDir := t.TempDir()
+	for _, name := range []string{"a", "b", "c"} {
+		if err := os.WriteFile(filepath.Join(textsDir, name+".txt"), []byte("text "+name), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	app.textLibrary = NewTextLibrary([]string{textsDir})
+
+	app.selectTextByName("b")
+	app.deleteCurrentText()
+	app.answerConfirm(false)
+	if _, err := os.Stat(filepath.Join(textsDir, "b.txt")); err != nil {
+		t.Fatalf("Expected declining to keep the file, got %v", err)
+	}
+
+	app.deleteCurrentText()
+	app.answerConfirm(true)
+	if _, err := os.Stat(filepath.Join(textsDir, "b.txt")); !os.IsNotExist(err) {
+		t.Fatalf("Expected the file to be deleted, got %v", err)
+	}
+	if got := app.textLibrary.GetCurrentText().Name; got != "c" {
+		t.Errorf("Expected the following text to be selected, got %q", got)
+	}
+	if app.typingTest.GetSampleText() != "text c" {
+		t.Errorf("Expected the fallback text to be practiced, got %q", app.typingTest.GetSampleText())
+	}
+
+	// Deleting the last text falls back to the one before it
+	app.deleteCurrentText()
+	app.answerConfirm(true)
+	if got := app.textLibrary.GetCurrentText().Name; got != "a" {
+		t.Errorf("Expected the previous text after deleting the last one, got %q", got)
+	}
+	for _, cmd := range commandNames(app.commandMenu.GetFilteredCommands()) {
+		if cmd == "text: b" || cmd == "text: c" {
+			t.Errorf("Expected commands of deleted texts to be removed, found %q", cmd)
+		}
+	}
+}
+
+func TestHomeEndReviewScroll(t *testing.T) {
+	app := newTestApp(t)
+	app.mode = "text"
+	app.typingTest.SetSampleText("one two three")
+
+	app.handleKey(tcell.NewEventKey(tcell.KeyRune, 'o', tcell.ModNone))
+	app.handleKey(tcell.NewEventKey(tcell.KeyEnd, 0, tcell.ModNone))
+	if !app.reviewing || !app.reviewAtEnd {
+		t.Fatal("Expected End to review the end of the text")
+	}
+	app.handleKey(tcell.NewEventKey(tcell.KeyHome, 0, tcell.ModNone))
+	if !app.reviewing || app.reviewAtEnd {
+		t.Fatal("Expected Home to review the start of the text")
+	}
+	if got := app.typingTest.GetCursorPos(); got != 1 {
+		t.Errorf("Expected reviewing to leave the cursor alone, got position %d", got)
+	}
+
+	app.handleKey(tcell.NewEventKey(tcell.KeyRune, 'n', tcell.ModNone))
+	if app.reviewing {
+		t.Error("Expected typing to return to the cursor")
+	}
+
+	app.mode = "words"
+	app.handleKey(tcell.NewEventKey(tcell.KeyEnd, 0, tcell.ModNone))
+	if app.reviewing {
+		t.Error("Expected Home/End to do nothing in word mode")
+	}
+}
+
+func TestToggleLimitType(t *testing.T) {
+	app := newTestApp(t)
+	app.setWordLimit(50)
+	app.setTimeLimit(120)
+
+	app.toggleLimitType()
+	if app.limitType != "words" || app.timeLimit != 120 || app.wordLimit != 50 {
+		t.Errorf("Expected the word limit with both values kept, got %q, %ds, %d words",
+			app.limitType, app.timeLimit, app.wordLimit)
+	}
+
+	app.handleKey(tcell.NewEventKey(tcell.KeyCtrlL, 0, tcell.ModCtrl))
+	if app.limitType != "time" || app.timeLimit != 120 || app.wordLimit != 50 {
+		t.Errorf("Expected Ctrl+L to switch back to the time limit with both values kept, got %q, %ds, %d words",
+			app.limitType, app.timeLimit, app.wordLimit)
+	}
+	if settings := app.currentSettings(); settings.LimitType != "time" || settings.TimeLimit != 120 || settings.WordLimit != 50 {
+		t.Errorf("Expected the settings to keep both limits, got %+v", settings)
+	}
+}
+
+func TestTogglePauseIgnoresTyping(t *testing.T) {
+	app := newTestApp(t)
+	app.handleKey(tcell.NewEventKey(tcell.KeyRune, 'a', 0))
+	cursor := app.typingTest.GetCursorPos()
+
+	app.handleKey(tcell.NewEventKey(tcell.KeyCtrlSpace, 0, tcell.ModCtrl))
+	if !app.typingTest.IsPaused() || app.getCurrentMode() != ModePaused {
+		t.Fatal("Expected Ctrl+Space to pause the running test")
+	}
+	app.handleKey(tcell.NewEventKey(tcell.KeyRune, 'b', 0))
+	if app.typingTest.GetCursorPos() != cursor {
+		t.Error("Expected typing to be ignored while paused")
+	}
+
+	app.handleKey(tcell.NewEventKey(tcell.KeyCtrlSpace, 0, tcell.ModCtrl))
+	if app.typingTest.IsPaused() || app.getCurrentMode() != ModeTyping {
+		t.Error("Expected Ctrl+Space to resume the test")
+	}
+}
+
+func TestToggleLiveStats(t *testing.T) {
+	app := newTestApp(t)
+	app.wordLibrary = newTestWordLibrary(t, "apple banana cherry")
+	app.selectWordSet("test")
+	app.handleKey(tcell.NewEventKey(tcell.KeyRune, 'a', 0))
+
+	liveStatsShown := func() (stats, progress bool) {
+		app.draw()
+		text := strings.Join(screenRows(app.screen.(tcell.SimulationScreen)), "\n")
+		return strings.Contains(text, "Accuracy:"), strings.Contains(text, "Time:")
+	}
+	if stats, progress := liveStatsShown(); !stats || !progress {
+		t.Fatalf("Expected live stats and progress by default, got stats %v, progress %v", stats, progress)
+	}
+
+	app.handleKey(tcell.NewEventKey(tcell.KeyCtrlS, 0, tcell.ModCtrl))
+	if app.currentSettings().ShowLiveStats {
+		t.Error("Expected Ctrl+S to hide the live stats in the settings")
+	}
+	if stats, progress := liveStatsShown(); stats || progress {
+		t.Errorf("Expected hidden live stats and progress, got stats %v, progress %v", stats, progress)
+	}
+
+	app.handleKey(tcell.NewEventKey(tcell.KeyCtrlS, 0, tcell.ModCtrl))
+	if stats, progress := liveStatsShown(); !stats || !progress {
+		t.Errorf("Expected Ctrl+S to show them again, got stats %v, progress %v", stats, progress)
+	}
+}
+
+func TestThemeNameFindsThemeCommand(t *testing.T) {
+	app := newTestApp(t)
+	app.commandMenu.Show()
+	for _, ch := range "dracula" {
+		app.commandMenu.AddChar(ch)
+	}
+
+	filtered := app.commandMenu.GetFilteredCommands()
+	if len(filtered) == 0 || filtered[0].Name != "theme: dracula" {
+		t.Fatalf("Expected the dracula theme command first, got %v", commandNames(filtered))
+	}
+	app.commandMenu.ExecuteSelected(app)
+	if app.theme.Name != DraculaTheme.Name {
+		t.Errorf("Expected Enter to switch to dracula, got %q", app.theme.Name)
+	}
+}
+
+func TestOnlyThemeCommandsGetThemeKeyword(t *testing.T) {
+	app := newTestApp(t)
+	app.commandMenu.Show()
+
+	for _, cmd := range app.commandMenu.GetFilteredCommands() {
+		name, ok := strings.CutPrefix(cmd.Name, "theme: ")
+		if !ok {
+			continue
+		}
+		_, isTheme := FindTheme(name)
+		if hasKeyword := slices.Contains(cmd.Keywords, name); hasKeyword != isTheme {
+			t.Errorf("%q: expected keyword %q %v, got %v", cmd.Name, name, isTheme, hasKeyword)
+		}
+	}
+}
+
+func TestCommandDescriptionsShowBoundKeys(t *testing.T) {
+	app := newTestApp(t)
+	app.settings.Keymap = map[string]string{ActionLimitType: "f4", ActionCycleTheme: "f6"}
+	app.initCommands()
+	app.commandMenu.Show()
+
+	want := map[string]string{
+		"limit: toggle type":      "(F4)",
+		"theme: add to favorites": "cycling with F6",
+	}
+	for _, cmd := range app.commandMenu.GetFilteredCommands() {
+		if key, ok := want[cmd.Name]; ok && !strings.Contains(cmd.Description, key) {
+			t.Errorf("%q: expected %s in %q", cmd.Name, key, cmd.Description)
+		}
+	}
+}
+
+func TestAsyncWordMergeKeepsPosition(t *testing.T) {
+	app := newTestApp(t)
+	app.wordLibrary = newTestWordLibrary(t, "apple banana cherry")
+	app.selectWordSet("test")
+	app.typingTest.UpdateSampleText("apple banana cherry")
+
+	typeString(app.typingTest, "apple banana")
+	app.ensureEnoughWords()
+	if !app.wordsPending {
+		t.Fatal("Expected more words to be generated near the end of the text")
+	}
+
+	// Typing goes on while the words are generated
+	batch := <-app.generatedWords
+	typeString(app.typingTest, " ch")
+	app.mergeWords(batch)
+
+	if app.wordsPending {
+		t.Error("Expected the generation to be done after the merge")
+	}
+	if input := app.typingTest.GetUserInput(); input != "apple banana ch" || app.typingTest.GetCursorPos() != 15 {
+		t.Errorf("Expected the typed input to be kept, got %q at %d", input, app.typingTest.GetCursorPos())
+	}
+	sample := app.typingTest.GetSampleText()
+	if !strings.HasPrefix(sample, "apple banana cherry ") || len(strings.Fields(sample)) != 3+wordGenerationChunk {
+		t.Errorf("Expected the words to be appended, got %q", sample)
+	}
+
+	// A batch for a replaced text is dropped
+	app.typingTest.UpdateSampleText("apple banana cherry")
+	app.lastCheckPosition = 0
+	app.ensureEnoughWords()
+	batch = <-app.generatedWords
+	app.regenerateWords()
+	sample = app.typingTest.GetSampleText()
+	app.mergeWords(batch)
+	if app.typingTest.GetSampleText() != sample {
+		t.Error("Expected words generated for a replaced text to be dropped")
+	}
+}
+
+func TestRCFileValuesAreNotSaved(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	configDir, err := GetConfigDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rcPath := filepath.Join(configDir, rcFileName)
+	if err := os.WriteFile(rcPath, []byte("time_limit=15\nshow_graph=false\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	app := startTestApp(t)
+	if app.timeLimit != 15 || app.settings.ShowGraph {
+		t.Fatalf("Expected the rc file to apply, got time limit %d and show graph %v", app.timeLimit, app.settings.ShowGraph)
+	}
+	app.toggleLiveStats()
+
+	// Removing the rc file lines brings back the settings.json values, while
+	// the change made in the app is kept
+	if err := os.Remove(rcPath); err != nil {
+		t.Fatal(err)
+	}
+	app = startTestApp(t)
+	if app.timeLimit != 60 || !app.settings.ShowGraph {
+		t.Errorf("Expected the rc values not to be saved, got time limit %d and show graph %v", app.timeLimit, app.settings.ShowGraph)
+	}
+	if app.settings.ShowLiveStats {
+		t.Error("Expected the live stats toggled in the app to be saved")
+	}
+}
+
+func TestEnvOverridesAreNotSaved(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	settingsManager, err := NewSettingsManager()
+	if err != nil {
+		t.Fatal(err)
+	}
+	settings := DefaultSettings()
+	settings.ThemeName = GruvboxTheme.Name
+	if err := settingsManager.SaveSettings(settings); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("ROCKETYPE_THEME", DraculaTheme.Name)
+	app := startTestApp(t)
+	if app.theme.Name != DraculaTheme.Name {
+		t.Fatalf("Expected the theme from the environment, got %q", app.theme.Name)
+	}
+	app.saveAllSettings()
+
+	t.Setenv("ROCKETYPE_THEME", "")
+	app = startTestApp(t)
+	if app.theme.Name != GruvboxTheme.Name {
+		t.Errorf("Expected the theme from settings.json without the variable, got %q", app.theme.Name)
+	}
+}
+
+func TestManualThemeSurvivesRestart(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	app := startTestApp(t)
+	// Starting and switching at the same hour keeps the night theme all day
+	app.settings.AutoTheme = AutoThemeSchedule{Enabled: true, DayTheme: GruvboxLightTheme.Name, NightTheme: GruvboxTheme.Name}
+	app.setTheme(DraculaTheme)
+
+	app = startTestApp(t)
+	if app.theme.Name != DraculaTheme.Name {
+		t.Errorf("Expected the manually picked theme after a restart, got %q", app.theme.Name)
+	}
+}
+
+func TestInvalidSettingsKeymapFallsBack(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	settingsManager, err := NewSettingsManager()
+	if err != nil {
+		t.Fatal(err)
+	}
+	settings := DefaultSettings()
+	settings.Keymap = map[string]string{ActionCommandMenu: "x", ActionRestart: "f5"}
+	if err := settingsManager.SaveSettings(settings); err != nil {
+		t.Fatal(err)
+	}
+
+	app := startTestApp(t)
+	keymap := app.keymap()
+	if keymap[ActionCommandMenu] != DefaultKeymap()[ActionCommandMenu] || keymap[ActionRestart] != "f5" {
+		t.Errorf("Expected the default command menu key and the valid restart binding, got %v", keymap)
+	}
+	if app.confirm == nil || !strings.Contains(app.confirm.message, ActionCommandMenu) {
+		t.Errorf("Expected a notice about the invalid binding, got %+v", app.confirm)
+	}
+	if len(app.Warnings()) != 1 {
+		t.Errorf("Expected the invalid binding in the warnings, got %v", app.Warnings())
+	}
+}
+
+func TestFinishTestClearsLastRewards(t *testing.T) {
+	app := newTestApp(t)
+	app.lastXP = XPAward{Gained: 10}
+	app.lastAchievements = []Achievement{{ID: "first_test"}}
+
+	typeString(app.typingTest, "a")
+	app.finishTest()
+	if app.lastXP != (XPAward{}) {
+		t.Errorf("Expected no XP for a test finished early, got %+v", app.lastXP)
+	}
+	if len(app.lastAchievements) != 0 {
+		t.Errorf("Expected no achievements for a test finished early, got %v", app.lastAchievements)
+	}
+}
+
+func TestLiveWPMLineHasItsOwnRow(t *testing.T) {
+	app := newTestApp(t)
+	app.settings.ShowSparkline = true
+	app.typingTest.GetStats().wpmHistory = []WPMSnapshot{{WPM: 40}, {WPM: 60}, {WPM: 80}, {WPM: 70}}
+
+	app.draw()
+	rows := screenRows(app.screen.(tcell.SimulationScreen))
+	height := len(rows)
+	if strings.TrimSpace(rows[height-liveWPMRowFromBottom]) == "" {
+		t.Error("Expected the live WPM line below the text")
+	}
+	for y, row := range rows {
+		braille := strings.ContainsFunc(row, func(r rune) bool { return r >= '⠀' && r <= '⣿' })
+		if y != height-liveWPMRowFromBottom && braille {
+			t.Errorf("Expected the live WPM line only on its own row, found braille on row %d: %q", y, row)
+		}
+	}
+}
+
+func TestHidingLiveStatsHidesLiveWPMLine(t *testing.T) {
+	app := newTestApp(t)
+	app.settings.ShowSparkline = true
+	app.settings.ShowLiveStats = false
+	app.typingTest.GetStats().wpmHistory = []WPMSnapshot{{WPM: 40}, {WPM: 60}, {WPM: 80}, {WPM: 70}}
+
+	app.draw()
+	rows := screenRows(app.screen.(tcell.SimulationScreen))
+	if row := rows[len(rows)-liveWPMRowFromBottom]; strings.TrimSpace(row) != "" {
+		t.Errorf("Expected no live WPM line with the live stats hidden, got %q", row)
+	}
+}
@@ -0,0 +1,63 @@
+package internal
+
+import "github.com/gdamore/tcell/v2"
+
+const (
+	confettiFrames  = 15      // Frames a celebration lasts (one per timer tick)
+	confettiSpacing = 4       // Columns per confetti piece
+	confettiPieces  = "*+o~." // Characters the confetti is made of
+)
+
+// Celebration is the confetti animation played after a perfect run. It is
+// advanced by the app's timer, one frame per tick, so it never blocks input.
+type Celebration struct {
+	frame int
+}
+
+// NewCelebration starts a celebration at its first frame.
+func NewCelebration() *Celebration {
+	return &Celebration{}
+}
+
+// Frame returns the current frame, starting at 0.
+func (c *Celebration) Frame() int {
+	return c.frame
+}
+
+// Advance moves to the next frame. It returns false once all confettiFrames
+// frames were shown and the celebration is over.
+func (c *Celebration) Advance() bool {
+	if c.frame < confettiFrames {
+		c.frame++
+	}
+	return !c.Done()
+}
+
+// Done reports whether the celebration has ended.
+func (c *Celebration) Done() bool {
+	return c.frame >= confettiFrames
+}
+
+// DrawConfetti renders one frame of confetti falling from the top of the screen.
+// Pieces start with a small delay each and fall at one or two rows per frame,
+// so they spread out while falling. Colors come from the theme.
+func (r *Renderer) DrawConfetti(frame int, theme Theme) {
+	width, height := r.screen.Size()
+	colors := []tcell.Color{theme.TextCorrect, theme.TextIncorrect, theme.TextCursor, theme.Title, theme.MenuSelectedBg}
+	pieces := []rune(confettiPieces)
+
+	for i := range width / confettiSpacing {
+		delay := (i * 7) % 5
+		speed := 1 + i%2
+		y := 1 + (frame-delay)*speed
+		if frame < delay || y >= height-1 {
+			continue
+		}
+		x := i*confettiSpacing + (i*3)%confettiSpacing
+		if i%3 == 0 {
+			x += (frame / 2) % 2 // Some pieces flutter sideways
+		}
+		style := tcell.StyleDefault.Foreground(colors[i%len(colors)]).Background(theme.Background).Bold(true)
+		r.setContent(x, y, pieces[(i+frame)%len(pieces)], nil, style)
+	}
+}
@@ -0,0 +1,52 @@
+package internal
+
+import "testing"
+
+func TestCelebrationFrames(t *testing.T) {
+	c := NewCelebration()
+	if c.Frame() != 0 || c.Done() {
+		t.Fatalf("Expected a new celebration at frame 0, got frame %d", c.Frame())
+	}
+
+	for i := 1; i < confettiFrames; i++ {
+		if !c.Advance() {
+			t.Fatalf("Expected the celebration to continue at frame %d", i)
+		}
+		if c.Frame() != i {
+			t.Fatalf("Expected frame %d, got %d", i, c.Frame())
+		}
+	}
+
+	if c.Advance() || !c.Done() {
+		t.Fatal("Expected the celebration to end after the last frame")
+	}
+	if c.Advance() || c.Frame() != confettiFrames {
+		t.Errorf("Expected an ended celebration to stay on its last frame, got %d", c.Frame())
+	}
+}
+
+func TestStartCelebrationOnPerfectRun(t *testing.T) {
+	app := newTestApp(t)
+	app.settings.CelebrateMode = true
+
+	app.typingTest.SetSampleText("ab")
+	typeString(app.typingTest, "ax")
+	app.startCelebration()
+	if app.celebration != nil {
+		t.Fatal("Expected no celebration for a run with mistakes")
+	}
+
+	app.typingTest.SetSampleText("ab")
+	typeString(app.typingTest, "ab")
+	app.startCelebration()
+	if app.celebration == nil {
+		t.Fatal("Expected a celebration for a perfect run")
+	}
+
+	app.settings.CelebrateMode = false
+	app.celebration = nil
+	app.startCelebration()
+	if app.celebration != nil {
+		t.Error("Expected no celebration when disabled")
+	}
+}
@@ -0,0 +1,67 @@
+package internal
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Challenge is a pass/fail goal for the next tests, e.g. 70 WPM at 95%
+// accuracy. A zero target is not checked.
+type Challenge struct {
+	TargetWPM      float64
+	TargetAccuracy float64 // In percent
+}
+
+// ParseChallenge parses a goal like "70 95": the target WPM followed by an
+// optional target accuracy in percent. Units may be added, as in
+// "70wpm 95%". Fails for a missing or non-positive WPM and for an accuracy
+// above 100%.
+func ParseChallenge(arg string) (Challenge, error) {
+	fields := strings.Fields(strings.ToLower(arg))
+	if len(fields) == 0 || len(fields) > 2 {
+		return Challenge{}, fmt.Errorf("expected a WPM and optionally an accuracy, e.g. \"70 95\"")
+	}
+
+	var challenge Challenge
+	wpm, err := strconv.ParseFloat(strings.TrimSuffix(fields[0], "wpm"), 64)
+	if err != nil || wpm <= 0 {
+		return Challenge{}, fmt.Errorf("invalid target WPM %q", fields[0])
+	}
+	challenge.TargetWPM = wpm
+
+	if len(fields) == 2 {
+		accuracy, err := strconv.ParseFloat(strings.TrimSuffix(fields[1], "%"), 64)
+		if err != nil || accuracy < 0 || accuracy > 100 {
+			return Challenge{}, fmt.Errorf("invalid target accuracy %q", fields[1])
+		}
+		challenge.TargetAccuracy = accuracy
+	}
+	return challenge, nil
+}
+
+// String describes the goal, e.g. "70 WPM at 95% accuracy".
+func (c Challenge) String() string {
+	goal := fmt.Sprintf("%g WPM", c.TargetWPM)
+	if c.TargetAccuracy > 0 {
+		goal += fmt.Sprintf(" at %g%% accuracy", c.TargetAccuracy)
+	}
+	return goal
+}
+
+// EvaluateChallenge checks a finished test against the challenge. A failed
+// challenge's reason names each target that was missed and by how much, e.g.
+// "4.5 WPM short of 70"; a passed one's reason repeats the goal.
+func EvaluateChallenge(stats *Stats, challenge Challenge) (passed bool, reason string) {
+	var shortfalls []string
+	if wpm := stats.GetWPM(); wpm < challenge.TargetWPM {
+		shortfalls = append(shortfalls, fmt.Sprintf("%.1f WPM short of %g", challenge.TargetWPM-wpm, challenge.TargetWPM))
+	}
+	if accuracy := stats.GetAccuracy(); accuracy < challenge.TargetAccuracy {
+		shortfalls = append(shortfalls, fmt.Sprintf("%.1f%% accuracy short of %g%%", challenge.TargetAccuracy-accuracy, challenge.TargetAccuracy))
+	}
+	if len(shortfalls) > 0 {
+		return false, strings.Join(shortfalls, ", ")
+	}
+	return true, challenge.String() + " reached"
+}
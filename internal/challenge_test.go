@@ -0,0 +1,85 @@
+package internal
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// newFinishedStats returns the stats of a one minute test with the given
+// keystrokes, every wrongEvery-th of them wrong (0 for none).
+func newFinishedStats(keystrokes, wrongEvery int) *Stats {
+	stats := NewStats()
+	start := time.Date(2024, time.March, 4, 12, 0, 0, 0, time.UTC)
+	stats.startTime = start
+	for i := 0; i < keystrokes; i++ {
+		stats.RecordKeystroke(wrongEvery == 0 || i%wrongEvery != 0)
+	}
+	stats.endTime = start.Add(time.Minute)
+	stats.testComplete = true
+	return stats
+}
+
+func TestEvaluateChallenge(t *testing.T) {
+	challenge := Challenge{TargetWPM: 70, TargetAccuracy: 95}
+
+	// 400 keystrokes in a minute are 80 WPM at 100% accuracy
+	passed, reason := EvaluateChallenge(newFinishedStats(400, 0), challenge)
+	if !passed || reason != "70 WPM at 95% accuracy reached" {
+		t.Errorf("Expected a pass, got %v %q", passed, reason)
+	}
+
+	// 325 keystrokes are 65 WPM
+	passed, reason = EvaluateChallenge(newFinishedStats(325, 0), challenge)
+	if passed || reason != "5.0 WPM short of 70" {
+		t.Errorf("Expected a fail on WPM, got %v %q", passed, reason)
+	}
+
+	// Every 10th of 450 keystrokes wrong: 81 WPM at 90% accuracy
+	passed, reason = EvaluateChallenge(newFinishedStats(450, 10), challenge)
+	if passed || reason != "5.0% accuracy short of 95%" {
+		t.Errorf("Expected a fail on accuracy, got %v %q", passed, reason)
+	}
+
+	// Without an accuracy target only the speed counts
+	if passed, _ := EvaluateChallenge(newFinishedStats(450, 10), Challenge{TargetWPM: 70}); !passed {
+		t.Error("Expected a pass without an accuracy target")
+	}
+}
+
+func TestParseChallenge(t *testing.T) {
+	tests := []struct {
+		arg  string
+		want Challenge
+	}{
+		{"70", Challenge{TargetWPM: 70}},
+		{"70 95", Challenge{TargetWPM: 70, TargetAccuracy: 95}},
+		{" 72.5WPM 97.5% ", Challenge{TargetWPM: 72.5, TargetAccuracy: 97.5}},
+	}
+	for _, tt := range tests {
+		got, err := ParseChallenge(tt.arg)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseChallenge(%q) = %+v, %v, want %+v", tt.arg, got, err, tt.want)
+		}
+	}
+
+	for _, arg := range []string{"", "fast", "0", "70 101", "70 95 3"} {
+		if _, err := ParseChallenge(arg); err == nil {
+			t.Errorf("Expected %q to be rejected", arg)
+		}
+	}
+}
+
+func TestDrawResultsChallenge(t *testing.T) {
+	renderer, screen := newTestRenderer(t, 100, 40)
+	renderer.DrawResults(ResultsData{
+		WPM:       65,
+		Accuracy:  100,
+		Challenge: &ChallengeOutcome{Reason: "5.0 WPM short of 70"},
+		Theme:     DefaultTheme,
+	})
+
+	if text := strings.Join(screenRows(screen), "\n"); !strings.Contains(text, "FAIL  5.0 WPM short of 70") {
+		t.Errorf("Expected the failed challenge on the results screen, got:\n%s", text)
+	}
+}
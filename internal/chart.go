@@ -0,0 +1,429 @@
+package internal
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+const (
+	wpmIncrement       = 25 // Default Y-axis label increment
+	yAxisLabelWidth    = 4  // Width of Y-axis labels
+	yAxisPadding       = 2  // Padding between Y-axis labels and graph
+	graphHeightPadding = 3  // Space for title and X-axis
+	brailleDotsWidth   = 2  // Braille character width in dots
+	brailleDotsHeight  = 4  // Braille character height in dots
+
+	histogramBarRows    = 2 // Rows of bar height in a histogram
+	histogramBarWidth   = 3 // Columns per histogram bar (also the label width)
+	histogramBarSpacing = 1 // Columns between histogram bars
+
+	sparklineLength = 20 // Recent WPM snapshots shown in the live sparkline
+
+	rhythmChartHeight = 8  // Height of the rhythm chart on the results screen
+	rhythmIncrement   = 50 // Y-axis label increment of the rhythm chart (percent)
+)
+
+// AxisLabel is a label below the X-axis of a line chart.
+type AxisLabel struct {
+	Position float64 // 0 is the left edge of the plot, 1 the right edge
+	Text     string
+}
+
+// ChartOptions configures how DrawLineChart renders a series.
+type ChartOptions struct {
+	Title       string      // Centered above the chart; omitted if empty
+	YIncrement  float64     // Step between Y-axis labels; defaults to wpmIncrement
+	XLabels     []AxisLabel // Labels below the X-axis; overlapping labels are skipped
+	Color       tcell.Color // Line color; defaults to Theme.TextCorrect
+	Markers     []float64   // Positions (0 to 1) marked on the bottom row of the plot
+	MarkerColor tcell.Color // Marker color; defaults to Theme.TextIncorrect
+	Interpolate bool        // Interpolate between values instead of repeating the last one per column
+	Theme       Theme
+}
+
+// DrawLineChart renders series as a line chart with Y-axis labels, evenly spacing
+// the values across the plot. The Y-axis always starts at 0. Nothing is drawn
+// for fewer than two values or if the area is too small.
+//
+// Parameters:
+//   - x, y: top-left position of the chart including title and axis labels
+//   - width, height: dimensions of the chart area
+//   - series: values to plot, left to right
+//   - opts: title, axis labels, colors and markers
+func (r *Renderer) DrawLineChart(x, y, width, height int, series []float64, opts ChartOptions) {
+	if len(series) < 2 || width < 10 || height < 3 {
+		return
+	}
+
+	theme := opts.Theme
+	increment := opts.YIncrement
+	if increment <= 0 {
+		increment = wpmIncrement
+	}
+	color := opts.Color
+	if color == tcell.ColorDefault {
+		color = theme.TextCorrect
+	}
+
+	minValue, maxValue := chartRange(series, increment)
+
+	// Draw title
+	if opts.Title != "" {
+		titleX := x + (width-len(opts.Title))/2
+		r.DrawText(titleX, y, opts.Title, theme.Title, theme.Background)
+	}
+	y++ // Title row is reserved even without a title
+
+	graphHeight := height - graphHeightPadding
+	graphWidth := width - (yAxisLabelWidth + yAxisPadding + 1)
+
+	// Draw Y-axis labels at every increment
+	numLabels := int(maxValue/increment) + 1
+	for i := 0; i < numLabels; i++ {
+		labelValue := float64(i) * increment
+		label := fmt.Sprintf("%*.0f", yAxisLabelWidth, labelValue) // Right-align
+		labelY := y + valueRow(labelValue, graphHeight, minValue, maxValue)
+		r.DrawText(x, labelY, label, theme.Help, theme.Background)
+	}
+
+	// Starting position for graph content
+	graphX := x + yAxisLabelWidth + yAxisPadding
+	graphY := y
+
+	// Initialize graph area with spaces
+	graphStyle := tcell.StyleDefault.Foreground(theme.TextDefault).Background(theme.Background)
+	for gy := 0; gy < graphHeight; gy++ {
+		for gx := 0; gx < graphWidth; gx++ {
+			r.setContent(graphX+gx, graphY+gy, ' ', nil, graphStyle)
+		}
+	}
+
+	// Draw the graph line
+	points := seriesPoints(series, graphWidth, graphHeight, minValue, maxValue, opts.Interpolate)
+	if r.glyphs.Braille {
+		r.drawBrailleLine(graphX, graphY, graphWidth, graphHeight, points, color, theme.Background)
+	} else {
+		r.drawASCIILine(graphX, graphY, graphWidth, graphHeight, points, color, theme.Background)
+	}
+
+	r.drawChartMarkers(graphX, graphY, graphWidth, graphHeight, opts)
+	r.drawXAxisLabels(graphX, graphY+graphHeight+1, graphWidth, opts.XLabels, theme)
+}
+
+// HistogramWidth returns the columns DrawHistogram needs for the given number of bars.
+func HistogramWidth(bars int) int {
+	return bars*(histogramBarWidth+histogramBarSpacing) - histogramBarSpacing
+}
+
+// DrawHistogram renders counts as vertical bars scaled to the largest count,
+// with the title above and a label (up to histogramBarWidth characters) below
+// each bar. Any non-zero count gets a visible bar. The histogram takes
+// histogramBarRows+2 rows and HistogramWidth(len(counts)) columns.
+func (r *Renderer) DrawHistogram(x, y int, title string, labels []string, counts []int, theme Theme) {
+	r.DrawText(x, y, title, theme.Title, theme.Background)
+
+	maxCount := 0
+	for _, count := range counts {
+		maxCount = max(maxCount, count)
+	}
+
+	levels := []rune(r.glyphs.BarLevels)
+	barStyle := tcell.StyleDefault.Foreground(theme.TextCorrect).Background(theme.Background)
+	for i, count := range counts {
+		barX := x + i*(histogramBarWidth+histogramBarSpacing)
+		height := barHeight(count, maxCount, histogramBarRows*len(levels))
+		for row := range histogramBarRows {
+			fill := min(height-row*len(levels), len(levels))
+			if fill <= 0 {
+				break
+			}
+			for dx := range histogramBarWidth {
+				r.setContent(barX+dx, y+histogramBarRows-row, levels[fill-1], nil, barStyle)
+			}
+		}
+		if i < len(labels) {
+			r.DrawText(barX, y+histogramBarRows+1, labels[i], theme.Help, theme.Background)
+		}
+	}
+}
+
+// DrawSparkline renders values as a one-row sparkline starting at (x, y), one
+// cell per value (see Sparkline).
+func (r *Renderer) DrawSparkline(x, y int, values []float64, theme Theme) {
+	style := tcell.StyleDefault.Foreground(theme.Help).Background(theme.Background)
+	for i, ch := range []rune(Sparkline(values, r.glyphs.SparkLevels)) {
+		r.setContent(x+i, y, ch, nil, style)
+	}
+}
+
+// LiveWPMWidth returns the columns DrawLiveWPM needs for the given number of
+// values.
+func LiveWPMWidth(values int) int {
+	return (values + brailleDotsWidth - 1) / brailleDotsWidth
+}
+
+// DrawLiveWPM renders recent WPM values as a one-row braille line starting at
+// (x, y), two values per cell and scaled between the smallest and the largest
+// value, taking LiveWPMWidth(len(values)) columns. Without braille it falls back
+// to DrawSparkline. Nothing is drawn for fewer than two values.
+func (r *Renderer) DrawLiveWPM(x, y int, values []float64, theme Theme) {
+	if len(values) < 2 {
+		return
+	}
+	if !r.glyphs.Braille {
+		r.DrawSparkline(x, y, values, theme)
+		return
+	}
+
+	lo, hi := values[0], values[0]
+	for _, v := range values {
+		lo, hi = min(lo, v), max(hi, v)
+	}
+	points := make([][2]int, len(values))
+	for i, v := range values {
+		row := brailleDotsHeight / 2
+		if hi > lo {
+			row = brailleDotsHeight - 1 - int(math.Round((v-lo)/(hi-lo)*(brailleDotsHeight-1)))
+		}
+		points[i] = [2]int{i, row}
+	}
+
+	style := tcell.StyleDefault.Foreground(theme.Help).Background(theme.Background)
+	r.drawBraillePolyline(x, y, LiveWPMWidth(len(values)), 1, points, style)
+}
+
+// Sparkline maps each value to one of levels, ordered from lowest to highest,
+// scaled between the smallest and the largest value. If all values are equal
+// they map to the middle level.
+func Sparkline(values []float64, levels string) string {
+	steps := []rune(levels)
+	if len(values) == 0 || len(steps) == 0 {
+		return ""
+	}
+
+	lo, hi := values[0], values[0]
+	for _, v := range values {
+		lo, hi = min(lo, v), max(hi, v)
+	}
+
+	line := make([]rune, len(values))
+	for i, v := range values {
+		level := len(steps) / 2
+		if hi > lo {
+			level = int(math.Round((v - lo) / (hi - lo) * float64(len(steps)-1)))
+		}
+		line[i] = steps[level]
+	}
+	return string(line)
+}
+
+// barHeight scales count to a bar of at most steps steps, rounding up so that
+// any non-zero count is visible.
+func barHeight(count, maxCount, steps int) int {
+	if count <= 0 || maxCount <= 0 {
+		return 0
+	}
+	return (count*steps + maxCount - 1) / maxCount
+}
+
+// EvenlySpacedLabels positions one label per series value, matching the
+// spacing DrawLineChart uses for the values.
+func EvenlySpacedLabels(texts []string) []AxisLabel {
+	labels := make([]AxisLabel, len(texts))
+	for i, text := range texts {
+		if len(texts) > 1 {
+			labels[i].Position = float64(i) / float64(len(texts)-1)
+		}
+		labels[i].Text = text
+	}
+	return labels
+}
+
+// chartRange returns the Y-axis range for series: from 0 up to the next
+// multiple of increment above the largest value.
+func chartRange(series []float64, increment float64) (minValue, maxValue float64) {
+	maxValue = series[0]
+	for _, value := range series {
+		if value > maxValue {
+			maxValue = value
+		}
+	}
+
+	maxValue = float64(int(maxValue/increment)+1) * increment
+	if maxValue < increment {
+		maxValue = increment
+	}
+	return 0, maxValue
+}
+
+// seriesPoints maps evenly spaced values to one row per graph column. Each
+// column shows the last value at or before it, or with interpolate a value
+// between its neighbors, so a few values form a straight line.
+func seriesPoints(series []float64, graphWidth, graphHeight int, minValue, maxValue float64, interpolate bool) []int {
+	points := make([]int, graphWidth)
+	for i := range points {
+		// Map column to a fractional position in series
+		pos := float64(i) / float64(graphWidth-1) * float64(len(series)-1)
+		idx := int(pos)
+		value := series[min(idx, len(series)-1)]
+		if interpolate && idx+1 < len(series) {
+			value += (series[idx+1] - value) * (pos - float64(idx))
+		}
+		points[i] = valueRow(value, graphHeight, minValue, maxValue)
+	}
+	return points
+}
+
+// valueRow maps a value to a graph row, clamped to the graph. Rows are
+// inverted because Y increases downward.
+func valueRow(value float64, graphHeight int, minValue, maxValue float64) int {
+	normalized := (value - minValue) / (maxValue - minValue)
+	if normalized < 0 {
+		normalized = 0
+	}
+	if normalized > 1 {
+		normalized = 1
+	}
+	return graphHeight - 1 - int(normalized*float64(graphHeight-1))
+}
+
+// positionColumn maps a position between 0 and 1 to a graph column.
+func positionColumn(position float64, graphWidth int) int {
+	return int(position * float64(graphWidth-1))
+}
+
+// drawBrailleLine draws a smooth line through the given points using braille characters.
+func (r *Renderer) drawBrailleLine(graphX, graphY, graphWidth, graphHeight int, points []int, fg, bg tcell.Color) {
+	lineStyle := tcell.StyleDefault.Foreground(fg).Background(bg).Bold(true)
+
+	// One point per cell, scaled to braille sub-pixel resolution
+	dots := make([][2]int, len(points))
+	for i, point := range points {
+		dots[i] = [2]int{i * brailleDotsWidth, point * brailleDotsHeight}
+	}
+	r.drawBraillePolyline(graphX, graphY, graphWidth, graphHeight, dots, lineStyle)
+}
+
+// drawBraillePolyline connects points given in braille dots (x, y from the
+// top-left; brailleDotsWidth by brailleDotsHeight dots per cell) with straight
+// lines, within an area of width by height cells at (x, y). Dots outside the
+// area are dropped.
+func (r *Renderer) drawBraillePolyline(x, y, width, height int, points [][2]int, style tcell.Style) {
+	grid := make([][]uint8, height)
+	for i := range grid {
+		grid[i] = make([]uint8, width)
+	}
+
+	for i := 0; i < len(points)-1; i++ {
+		drawBrailleLineSegment(grid, width, height, points[i][0], points[i][1], points[i+1][0], points[i+1][1])
+	}
+
+	// Render braille characters
+	brailleBase := rune(0x2800)
+	for cellY := 0; cellY < height; cellY++ {
+		for cellX := 0; cellX < width; cellX++ {
+			if grid[cellY][cellX] != 0 {
+				r.setContent(x+cellX, y+cellY, brailleBase+rune(grid[cellY][cellX]), nil, style)
+			}
+		}
+	}
+}
+
+// drawASCIILine draws the graph line with one point per column, linking
+// consecutive points vertically so jumps stay readable.
+func (r *Renderer) drawASCIILine(graphX, graphY, graphWidth, graphHeight int, points []int, fg, bg tcell.Color) {
+	lineStyle := tcell.StyleDefault.Foreground(fg).Background(bg).Bold(true)
+
+	for i := 0; i < len(points) && i < graphWidth; i++ {
+		if i+1 < len(points) {
+			from, to := min(points[i], points[i+1]), max(points[i], points[i+1])
+			for y := from + 1; y < to && y < graphHeight; y++ {
+				r.setContent(graphX+i, graphY+y, r.glyphs.GraphLink, nil, lineStyle)
+			}
+		}
+		if points[i] >= 0 && points[i] < graphHeight {
+			r.setContent(graphX+i, graphY+points[i], r.glyphs.GraphPoint, nil, lineStyle)
+		}
+	}
+}
+
+// drawBrailleLineSegment draws a line segment in the braille grid using Bresenham's algorithm.
+func drawBrailleLineSegment(grid [][]uint8, width, height, x1, y1, x2, y2 int) {
+	dx := abs(x2 - x1)
+	dy := abs(y2 - y1)
+	sx := 1
+	if x1 > x2 {
+		sx = -1
+	}
+	sy := 1
+	if y1 > y2 {
+		sy = -1
+	}
+	err := dx - dy
+
+	x, y := x1, y1
+	for {
+		// Convert sub-pixel coordinates to braille cell and dot position
+		cellX := x / brailleDotsWidth
+		cellY := y / brailleDotsHeight
+		dotX := x % brailleDotsWidth
+		dotY := y % brailleDotsHeight
+
+		// Set braille dot if within bounds
+		if cellX >= 0 && cellX < width && cellY >= 0 && cellY < height {
+			// Braille dot pattern: left column 0-3, right column 4-7
+			dotIndex := dotX*brailleDotsHeight + dotY
+			grid[cellY][cellX] |= (1 << dotIndex)
+		}
+
+		if x == x2 && y == y2 {
+			break
+		}
+
+		e2 := 2 * err
+		if e2 > -dy {
+			err -= dy
+			x += sx
+		}
+		if e2 < dx {
+			err += dx
+			y += sy
+		}
+	}
+}
+
+// drawChartMarkers renders markers on the bottom row of the graph.
+func (r *Renderer) drawChartMarkers(graphX, graphY, graphWidth, graphHeight int, opts ChartOptions) {
+	color := opts.MarkerColor
+	if color == tcell.ColorDefault {
+		color = opts.Theme.TextIncorrect
+	}
+	markerStyle := tcell.StyleDefault.Foreground(color).Background(opts.Theme.Background)
+
+	for _, position := range opts.Markers {
+		if position < 0 || position > 1 {
+			continue
+		}
+		markerX := graphX + positionColumn(position, graphWidth)
+		r.setContent(markerX, graphY+graphHeight-1, r.glyphs.ErrorMarker, nil, markerStyle)
+	}
+}
+
+// drawXAxisLabels draws labels centered on their positions, kept within the
+// graph width. A label that would overlap the previous one is skipped.
+func (r *Renderer) drawXAxisLabels(graphX, labelY, graphWidth int, labels []AxisLabel, theme Theme) {
+	nextFree := graphX
+	for _, label := range labels {
+		labelX := graphX + positionColumn(label.Position, graphWidth) - len(label.Text)/2
+		labelX = max(labelX, graphX)
+		labelX = min(labelX, graphX+graphWidth-len(label.Text))
+		if labelX < nextFree {
+			continue
+		}
+
+		r.DrawText(labelX, labelY, label.Text, theme.Help, theme.Background)
+		nextFree = labelX + len(label.Text) + 1
+	}
+}
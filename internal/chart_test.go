@@ -0,0 +1,215 @@
+package internal
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestChartRange(t *testing.T) {
+	tests := []struct {
+		name      string
+		series    []float64
+		increment float64
+		wantMax   float64
+	}{
+		{"rounds up to next increment", []float64{10, 62, 40}, 25, 75},
+		{"exact multiple gets headroom", []float64{50}, 25, 75},
+		{"all zero", []float64{0, 0}, 25, 25},
+		{"custom increment", []float64{93}, 10, 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			minValue, maxValue := chartRange(tt.series, tt.increment)
+			if minValue != 0 || maxValue != tt.wantMax {
+				t.Errorf("Expected range 0..%v, got %v..%v", tt.wantMax, minValue, maxValue)
+			}
+		})
+	}
+}
+
+func TestValueRow(t *testing.T) {
+	const height = 11
+	tests := []struct {
+		value float64
+		want  int
+	}{
+		{0, 10},   // Bottom row
+		{100, 0},  // Top row
+		{50, 5},   // Middle
+		{-10, 10}, // Clamped below
+		{150, 0},  // Clamped above
+	}
+
+	for _, tt := range tests {
+		if got := valueRow(tt.value, height, 0, 100); got != tt.want {
+			t.Errorf("valueRow(%v) = %d, want %d", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestSeriesPointsInterpolates(t *testing.T) {
+	// Two values stretched over five columns form a straight line
+	points := seriesPoints([]float64{0, 100}, 5, 5, 0, 100, true)
+
+	want := []int{4, 3, 2, 1, 0}
+	for i := range want {
+		if points[i] != want[i] {
+			t.Fatalf("Expected %v, got %v", want, points)
+		}
+	}
+}
+
+func TestSeriesPointsSamplesWithoutInterpolation(t *testing.T) {
+	// Without interpolation each column keeps the last value at or before it
+	points := seriesPoints([]float64{0, 100}, 5, 5, 0, 100, false)
+
+	want := []int{4, 4, 4, 4, 0}
+	for i := range want {
+		if points[i] != want[i] {
+			t.Fatalf("Expected %v, got %v", want, points)
+		}
+	}
+}
+
+func TestSeriesPointsHitsEveryValue(t *testing.T) {
+	series := []float64{0, 100, 50}
+	points := seriesPoints(series, 9, 11, 0, 100, true)
+
+	if len(points) != 9 {
+		t.Fatalf("Expected one point per column, got %d", len(points))
+	}
+	// Values land on the first, middle and last columns
+	for i, column := range []int{0, 4, 8} {
+		if want := valueRow(series[i], 11, 0, 100); points[column] != want {
+			t.Errorf("Column %d: expected row %d, got %d", column, want, points[column])
+		}
+	}
+}
+
+func TestEvenlySpacedLabels(t *testing.T) {
+	labels := EvenlySpacedLabels([]string{"a", "b", "c"})
+
+	want := []float64{0, 0.5, 1}
+	for i, label := range labels {
+		if label.Position != want[i] {
+			t.Errorf("Label %q: expected position %v, got %v", label.Text, want[i], label.Position)
+		}
+	}
+	if got := positionColumn(labels[2].Position, 21); got != 20 {
+		t.Errorf("Expected last label in last column, got %d", got)
+	}
+}
+
+func TestErrorMarkerPositions(t *testing.T) {
+	start := time.Now()
+	errors := []time.Time{
+		start.Add(-time.Second),     // Before the graph
+		start.Add(5 * time.Second),  // Halfway
+		start.Add(10 * time.Second), // At the end
+		start.Add(11 * time.Second), // After the graph
+	}
+
+	positions := errorMarkerPositions(start, 10, errors)
+	if len(positions) != 2 || positions[0] != 0.5 || positions[1] != 1 {
+		t.Errorf("Expected positions [0.5 1], got %v", positions)
+	}
+}
+
+func TestDrawLineChart(t *testing.T) {
+	renderer, screen := newTestRenderer(t, 40, 12)
+	renderer.DrawLineChart(0, 0, 40, 12, []float64{10, 30, 20}, ChartOptions{
+		Title:   "Chart",
+		XLabels: EvenlySpacedLabels([]string{"one", "two", "three"}),
+		Markers: []float64{0.5},
+		Theme:   DefaultTheme,
+	})
+	rows := screenRows(screen)
+
+	if !strings.Contains(rows[0], "Chart") {
+		t.Errorf("Expected title in first row, got %q", rows[0])
+	}
+	if !strings.Contains(rows[11], "one") || !strings.Contains(rows[11], "three") {
+		t.Errorf("Expected X-axis labels in last row, got %q", rows[11])
+	}
+
+	// Marker on the bottom plot row, halfway across the plot
+	graphX := yAxisLabelWidth + yAxisPadding
+	graphWidth := 40 - (yAxisLabelWidth + yAxisPadding + 1)
+	markerX := graphX + positionColumn(0.5, graphWidth)
+	if got := []rune(rows[9])[markerX]; got != UnicodeGlyphs.ErrorMarker {
+		t.Errorf("Expected error marker at column %d, got %q", markerX, got)
+	}
+}
+
+func TestDrawHistogram(t *testing.T) {
+	renderer, screen := newTestRenderer(t, 20, 4)
+	renderer.DrawHistogram(0, 0, "Hist", []string{"a", "b", "c"}, []int{8, 1, 0}, DefaultTheme)
+	rows := screenRows(screen)
+
+	if !strings.HasPrefix(rows[0], "Hist") {
+		t.Errorf("Expected title in first row, got %q", rows[0])
+	}
+	// The largest count fills both bar rows; 1 of 8 is an eighth of the height (2 of 16 steps)
+	if got := string([]rune(rows[1])[:HistogramWidth(3)]); got != "███        " {
+		t.Errorf("Unexpected top bar row %q", got)
+	}
+	if got := string([]rune(rows[2])[:HistogramWidth(3)]); got != "███ ▂▂▂    " {
+		t.Errorf("Unexpected bottom bar row %q", got)
+	}
+	if !strings.HasPrefix(rows[3], "a   b   c") {
+		t.Errorf("Expected bar labels in last row, got %q", rows[3])
+	}
+}
+
+func TestSparkline(t *testing.T) {
+	levels := UnicodeGlyphs.SparkLevels
+	tests := []struct {
+		name   string
+		values []float64
+		want   string
+	}{
+		{"empty", nil, ""},
+		{"rising", []float64{40, 50, 60, 70, 80, 90, 100, 110}, "▁▂▃▄▅▆▇█"},
+		{"min and max", []float64{80, 20, 80}, "█▁█"},
+		{"rounded to nearest", []float64{0, 50, 100}, "▁▅█"},
+		{"flat", []float64{60, 60, 60}, "▅▅▅"},
+	}
+
+	for _, tt := range tests {
+		if got := Sparkline(tt.values, levels); got != tt.want {
+			t.Errorf("%s: Sparkline(%v) = %q, want %q", tt.name, tt.values, got, tt.want)
+		}
+	}
+
+	if got := Sparkline([]float64{1, 2, 3, 4}, ASCIIGlyphs.SparkLevels); got != "_.-^" {
+		t.Errorf("Expected ASCII levels to be used, got %q", got)
+	}
+}
+
+func TestDrawLiveWPM(t *testing.T) {
+	renderer, screen := newTestRenderer(t, 20, 1)
+	renderer.DrawLiveWPM(0, 0, []float64{50}, DefaultTheme)
+	if row := screenRows(screen)[0]; strings.TrimSpace(row) != "" {
+		t.Errorf("Expected nothing for a single value, got %q", row)
+	}
+
+	// Two values per cell: a rise from the bottom to the top dot row, then flat
+	values := []float64{0, 100, 100, 100, 100}
+	renderer.DrawLiveWPM(0, 0, values, DefaultTheme)
+	row := []rune(screenRows(screen)[0])
+	if LiveWPMWidth(len(values)) != 3 {
+		t.Fatalf("Expected 5 values to take 3 columns, got %d", LiveWPMWidth(len(values)))
+	}
+	if row[0] != '⠼' || row[1] != '⠑' || row[2] != '⠁' || row[3] != ' ' {
+		t.Errorf("Unexpected braille line %q", string(row[:4]))
+	}
+
+	renderer, screen = newTestRenderer(t, 20, 1)
+	renderer.SetASCIIMode(true)
+	renderer.DrawLiveWPM(0, 0, []float64{1, 2, 3, 4}, DefaultTheme)
+	if row := screenRows(screen)[0]; !strings.HasPrefix(row, "_.-^") {
+		t.Errorf("Expected the ASCII sparkline as fallback, got %q", row)
+	}
+}
@@ -0,0 +1,105 @@
+package internal
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// blankLinePattern separates paragraphs.
+var blankLinePattern = regexp.MustCompile(`\n[ \t]*\n`)
+
+// SplitIntoChunks splits a long text into parts of about targetWords words
+// without breaking paragraphs. Paragraphs are separated by blank lines; a text
+// without blank lines (e.g. stripped HTML) uses its lines as paragraphs.
+//
+// A part ends once it reaches targetWords, or before a paragraph that would
+// overshoot the target by more than the part falls short of it. A paragraph
+// longer than targetWords becomes a part of its own, and a short remainder is
+// added to the previous part. A targetWords of 0 or less returns the whole text.
+func SplitIntoChunks(text string, targetWords int) []string {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil
+	}
+	if targetWords <= 0 {
+		return []string{text}
+	}
+
+	paragraphs, separator := splitParagraphs(text)
+
+	var chunks [][]string
+	var current []string
+	words := 0
+	flush := func() {
+		if len(current) > 0 {
+			chunks = append(chunks, current)
+		}
+		current = nil
+		words = 0
+	}
+	for _, paragraph := range paragraphs {
+		count := len(strings.Fields(paragraph))
+		if len(current) > 0 && words+count-targetWords > targetWords-words {
+			flush()
+		}
+		current = append(current, paragraph)
+		words += count
+		if words >= targetWords {
+			flush()
+		}
+	}
+
+	// Merge a remainder of less than half the target into the previous part
+	if len(current) > 0 && len(chunks) > 0 && words < targetWords/2 {
+		last := len(chunks) - 1
+		chunks[last] = append(chunks[last], current...)
+		current = nil
+	}
+	flush()
+
+	result := make([]string, len(chunks))
+	for i, chunk := range chunks {
+		result[i] = strings.Join(chunk, separator)
+	}
+	return result
+}
+
+// splitParagraphs returns the non-empty paragraphs of text and the separator
+// to join them with again.
+func splitParagraphs(text string) ([]string, string) {
+	separator := "\n\n"
+	parts := blankLinePattern.Split(text, -1)
+	if len(parts) == 1 {
+		separator = "\n"
+		parts = strings.Split(text, "\n")
+	}
+
+	paragraphs := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if part = strings.TrimSpace(part); part != "" {
+			paragraphs = append(paragraphs, part)
+		}
+	}
+	return paragraphs, separator
+}
+
+// chunkTexts splits text into parts named "<name> - part N" when chunking is on
+// and the text is long enough for more than one part; otherwise text is
+// returned as is. The parts keep the Path of the file and are numbered in Part.
+func chunkTexts(text TextSource, targetWords int) []TextSource {
+	chunks := SplitIntoChunks(text.Content, targetWords)
+	if len(chunks) <= 1 {
+		return []TextSource{text}
+	}
+
+	parts := make([]TextSource, len(chunks))
+	for i, chunk := range chunks {
+		part := text
+		part.Name = fmt.Sprintf("%s - part %d", text.Name, i+1)
+		part.Content = chunk
+		part.Part = i + 1
+		parts[i] = part
+	}
+	return parts
+}
@@ -0,0 +1,93 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// paragraph returns a paragraph of n words.
+func paragraph(n int) string {
+	return strings.TrimSpace(strings.Repeat("word ", n))
+}
+
+func TestSplitIntoChunksSizing(t *testing.T) {
+	text := strings.Join([]string{paragraph(10), paragraph(10), paragraph(10), paragraph(10), paragraph(10), paragraph(10)}, "\n\n")
+
+	chunks := SplitIntoChunks(text, 20)
+	if len(chunks) != 3 {
+		t.Fatalf("Expected 3 chunks of 20 words, got %d: %q", len(chunks), chunks)
+	}
+	for i, chunk := range chunks {
+		if words := len(strings.Fields(chunk)); words != 20 {
+			t.Errorf("Chunk %d: expected 20 words, got %d", i, words)
+		}
+	}
+
+	if chunks := SplitIntoChunks(text, 0); len(chunks) != 1 || chunks[0] != text {
+		t.Errorf("Expected chunking to be off for a target of 0, got %q", chunks)
+	}
+}
+
+func TestSplitIntoChunksKeepsParagraphs(t *testing.T) {
+	paragraphs := []string{paragraph(5), paragraph(50), paragraph(12), paragraph(3)}
+	text := strings.Join(paragraphs, "\n\n")
+
+	chunks := SplitIntoChunks(text, 20)
+
+	// A short paragraph ends its part rather than joining the long one, which stays whole
+	want := []string{
+		paragraphs[0],
+		paragraphs[1],
+		paragraphs[2] + "\n\n" + paragraphs[3],
+	}
+	if len(chunks) != len(want) {
+		t.Fatalf("Expected %d chunks, got %d: %q", len(want), len(chunks), chunks)
+	}
+	for i := range want {
+		if chunks[i] != want[i] {
+			t.Errorf("Chunk %d: expected %q, got %q", i, want[i], chunks[i])
+		}
+	}
+	if strings.Join(chunks, "\n\n") != text {
+		t.Error("Expected the chunks to add up to the whole text")
+	}
+
+	// A remainder of less than half the target joins the previous part
+	chunks = SplitIntoChunks(paragraph(20)+"\n\n"+paragraph(4), 20)
+	if len(chunks) != 1 {
+		t.Errorf("Expected the short remainder to be merged, got %q", chunks)
+	}
+}
+
+func TestSplitIntoChunksUsesLinesWithoutBlankLines(t *testing.T) {
+	text := paragraph(10) + "\n" + paragraph(10) + "\n" + paragraph(10) + "\n" + paragraph(10)
+
+	chunks := SplitIntoChunks(text, 20)
+	if len(chunks) != 2 || chunks[0] != paragraph(10)+"\n"+paragraph(10) {
+		t.Errorf("Expected lines to be treated as paragraphs, got %q", chunks)
+	}
+}
+
+func TestLoadTextInChunks(t *testing.T) {
+	dir := t.TempDir()
+	book := strings.Join([]string{paragraph(30), paragraph(30), paragraph(30)}, "\n\n")
+	if err := os.WriteFile(filepath.Join(dir, "book.txt"), []byte(book), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	library := NewTextLibrary([]string{dir})
+	library.SetChunkWords(30)
+
+	var names []string
+	for i, text := range library.GetAllTexts() {
+		names = append(names, text.Name)
+		if text.Part != i+1 {
+			t.Errorf("Expected %q to be part %d, got %d", text.Name, i+1, text.Part)
+		}
+	}
+	if strings.Join(names, ",") != "book - part 1,book - part 2,book - part 3" {
+		t.Errorf("Expected the book in three parts, got %v", names)
+	}
+}
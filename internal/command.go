@@ -1,11 +1,20 @@
 package internal
 
-import "strings"
+import (
+	"slices"
+	"strings"
+)
 
 const (
 	// defaultMaxVisibleCommands is the typical number of commands visible in the menu
 	// This matches the default menu height calculation in the renderer
 	defaultMaxVisibleCommands = 10
+
+	// wordSetPreviewCount is the number of sample words shown for a highlighted word set
+	wordSetPreviewCount = 12
+
+	// maxCommandHistory is the number of executed commands remembered per session
+	maxCommandHistory = 20
 )
 
 // Command represents an executable action in the command palette.
@@ -14,6 +23,27 @@ type Command struct {
 	Name        string     // Display name shown in the command palette
 	Description string     // Descriptive text explaining what the command does
 	Action      func(*App) // Function to execute when the command is selected
+	WordSet     string     // Word set selected by this command (enables a sample preview)
+	SkipHistory bool       // Don't record this command in the command history
+	Keywords    []string   // Extra terms the filter matches, e.g. a theme's bare name
+
+	// ArgAction runs instead of Action when the filter is the command name
+	// followed by an argument, e.g. "words: min length 5".
+	ArgAction func(app *App, arg string)
+}
+
+// commandArg returns the argument typed after the command name in filter, and
+// whether filter starts with the name of a command that takes an argument.
+// The argument may be empty. The name is matched case-insensitively.
+func commandArg(cmd Command, filter string) (string, bool) {
+	if cmd.ArgAction == nil {
+		return "", false
+	}
+	prefix := strings.ToLower(cmd.Name) + " "
+	if !strings.HasPrefix(strings.ToLower(filter), prefix) {
+		return "", false
+	}
+	return strings.TrimSpace(filter[len(prefix):]), true
 }
 
 // CommandMenu manages the command palette overlay, including visibility,
@@ -25,6 +55,17 @@ type CommandMenu struct {
 	selected     int       // Index of currently selected command in filtered list
 	scrollOffset int       // Scroll offset for viewing long command lists
 	commands     []Command // All available commands
+
+	// Command history (most recent first, without duplicates)
+	history     []string // Names of executed commands
+	historyView bool     // Whether the menu lists the history instead of all commands
+
+	usage map[string]int // How often each command was executed (persisted across sessions)
+
+	// Word set preview state (regenerated only when the highlighted set changes)
+	previewGenerator func(wordSet string) string // Produces sample words for a word set
+	previewFor       string                      // Word set the cached preview belongs to
+	previewText      string                      // Cached preview text
 }
 
 // NewCommandMenu creates a new CommandMenu instance with default values.
@@ -44,6 +85,7 @@ func NewCommandMenu() *CommandMenu {
 // This ensures a clean slate each time the menu is opened.
 func (cm *CommandMenu) Show() {
 	cm.visible = true
+	cm.historyView = false
 	cm.filter = ""
 	cm.selected = 0
 	cm.scrollOffset = 0
@@ -53,11 +95,108 @@ func (cm *CommandMenu) Show() {
 // The command list is preserved for the next time the menu is opened.
 func (cm *CommandMenu) Hide() {
 	cm.visible = false
+	cm.historyView = false
 	cm.filter = ""
 	cm.selected = 0
 	cm.scrollOffset = 0
 }
 
+// ShowHistory displays the command menu listing previously executed commands,
+// most recent first, so they can be re-run. Filtering works as in the full menu.
+func (cm *CommandMenu) ShowHistory() {
+	cm.Show()
+	cm.historyView = true
+}
+
+// IsHistoryView returns whether the menu currently lists the command history.
+func (cm *CommandMenu) IsHistoryView() bool {
+	return cm.historyView
+}
+
+// GetHistory returns the names of executed commands, most recent first.
+func (cm *CommandMenu) GetHistory() []string {
+	// Return a copy to prevent external modification
+	result := make([]string, len(cm.history))
+	copy(result, cm.history)
+	return result
+}
+
+// recordHistory moves the named command to the front of the history,
+// dropping the oldest entry once the history is full.
+func (cm *CommandMenu) recordHistory(name string) {
+	for i, existing := range cm.history {
+		if existing == name {
+			cm.history = append(cm.history[:i], cm.history[i+1:]...)
+			break
+		}
+	}
+	cm.history = append([]string{name}, cm.history...)
+	if len(cm.history) > maxCommandHistory {
+		cm.history = cm.history[:maxCommandHistory]
+	}
+}
+
+// SetUsageCounts replaces the per-command execution counts, e.g. with the
+// counts saved in a previous session.
+func (cm *CommandMenu) SetUsageCounts(usage map[string]int) {
+	cm.usage = make(map[string]int, len(usage))
+	for name, count := range usage {
+		cm.usage[name] = count
+	}
+}
+
+// GetUsageCounts returns how often each command was executed.
+func (cm *CommandMenu) GetUsageCounts() map[string]int {
+	// Return a copy to prevent external modification
+	result := make(map[string]int, len(cm.usage))
+	for name, count := range cm.usage {
+		result[name] = count
+	}
+	return result
+}
+
+// recordUsage increments the execution count of the named command.
+func (cm *CommandMenu) recordUsage(name string) {
+	if cm.usage == nil {
+		cm.usage = make(map[string]int)
+	}
+	cm.usage[name]++
+}
+
+// sortByUsage returns the commands with the most used ones first. The sort is
+// stable, so unused commands (and commands used equally often) keep their
+// original, grouped order.
+func (cm *CommandMenu) sortByUsage(commands []Command) []Command {
+	if len(cm.usage) == 0 {
+		return commands
+	}
+	sorted := slices.Clone(commands)
+	slices.SortStableFunc(sorted, func(a, b Command) int {
+		return cm.usage[b.Name] - cm.usage[a.Name]
+	})
+	return sorted
+}
+
+// availableCommands returns the commands the menu currently lists: either all
+// commands, most used first, or the ones from the history that still exist.
+func (cm *CommandMenu) availableCommands() []Command {
+	if !cm.historyView {
+		return cm.sortByUsage(cm.commands)
+	}
+
+	byName := make(map[string]Command, len(cm.commands))
+	for _, cmd := range cm.commands {
+		byName[cmd.Name] = cmd
+	}
+	var commands []Command
+	for _, name := range cm.history {
+		if cmd, ok := byName[name]; ok {
+			commands = append(commands, cmd)
+		}
+	}
+	return commands
+}
+
 // IsVisible returns whether the command menu is currently displayed.
 func (cm *CommandMenu) IsVisible() bool {
 	return cm.visible
@@ -72,6 +211,40 @@ func (cm *CommandMenu) SetCommands(commands []Command) {
 	cm.commands = commands
 }
 
+// SetPreviewGenerator sets the function used to produce sample words for
+// highlighted word set commands.
+//
+// Parameters:
+//   - generator: returns sample words for the named word set
+func (cm *CommandMenu) SetPreviewGenerator(generator func(wordSet string) string) {
+	cm.previewGenerator = generator
+	cm.previewFor = ""
+	cm.previewText = ""
+}
+
+// GetPreview returns sample words for the highlighted command if it selects a
+// word set. The sample is generated once per selection change and cached, so
+// calling this on every frame is cheap.
+//
+// Returns an empty string if the highlighted command has no preview.
+func (cm *CommandMenu) GetPreview() string {
+	filtered := cm.GetFilteredCommands()
+	if cm.previewGenerator == nil || cm.selected >= len(filtered) {
+		return ""
+	}
+
+	wordSet := filtered[cm.selected].WordSet
+	if wordSet == "" {
+		return ""
+	}
+
+	if wordSet != cm.previewFor {
+		cm.previewFor = wordSet
+		cm.previewText = cm.previewGenerator(wordSet)
+	}
+	return cm.previewText
+}
+
 // AddChar appends a character to the current filter string.
 // Resets the selection to the first item in the newly filtered list.
 //
@@ -100,27 +273,56 @@ func (cm *CommandMenu) GetFilter() string {
 }
 
 // GetFilteredCommands returns commands that match the current filter.
-// Matching is case-insensitive and searches both command names and descriptions.
-// If no filter is applied, returns all commands.
+// Matching is case-insensitive and searches command names, descriptions and
+// keywords. Commands that take an argument also match their name followed by one.
+// If no filter is applied, returns all commands with the most used ones first.
 //
-// Returns a slice of matching Command structs in their original order.
+// Returns a slice of matching Command structs. While filtering, matches keep
+// their original order so usage counts don't reshuffle them, except that
+// commands with a keyword equal to the filter come first: typing "dracula" and
+// Enter switches to that theme.
 func (cm *CommandMenu) GetFilteredCommands() []Command {
 	if cm.filter == "" {
-		return cm.commands
+		return cm.availableCommands()
+	}
+
+	commands := cm.commands
+	if cm.historyView {
+		commands = cm.availableCommands()
 	}
 
 	filter := strings.ToLower(cm.filter)
-	var filtered []Command
+	var exact, filtered []Command
 
-	for _, cmd := range cm.commands {
+	for _, cmd := range commands {
 		nameMatch := strings.Contains(strings.ToLower(cmd.Name), filter)
 		descMatch := strings.Contains(strings.ToLower(cmd.Description), filter)
-		if nameMatch || descMatch {
+		_, argMatch := commandArg(cmd, cm.filter)
+		keywordMatch, exactKeyword := matchKeywords(cmd.Keywords, filter)
+		switch {
+		case exactKeyword:
+			exact = append(exact, cmd)
+		case nameMatch || descMatch || argMatch || keywordMatch:
 			filtered = append(filtered, cmd)
 		}
 	}
 
-	return filtered
+	return append(exact, filtered...)
+}
+
+// matchKeywords reports whether a keyword contains the lowercase filter, and
+// whether one equals it (ignoring case and surrounding spaces).
+func matchKeywords(keywords []string, filter string) (match, exact bool) {
+	for _, keyword := range keywords {
+		keyword = strings.ToLower(keyword)
+		if keyword == strings.TrimSpace(filter) {
+			return true, true
+		}
+		if strings.Contains(keyword, filter) {
+			match = true
+		}
+	}
+	return match, false
 }
 
 // MoveUp moves the selection cursor up by one position.
@@ -161,16 +363,30 @@ func (cm *CommandMenu) GetScrollOffset() int {
 	return cm.scrollOffset
 }
 
-// ExecuteSelected executes the currently selected command and closes the menu.
-// If no commands match the filter or selection is invalid, this is a no-op.
-// The menu is automatically hidden after successful command execution.
+// ExecuteSelected closes the menu and executes the currently selected command,
+// passing it the argument typed after its name if it takes one, and
+// recording it in the command history and usage counts. The menu is hidden before the action
+// runs so actions can reopen it (e.g. to show the history).
+// If no commands match the filter or selection is invalid, only the menu is closed.
 //
 // Parameters:
 //   - app: the App instance to pass to the command's action function
 func (cm *CommandMenu) ExecuteSelected(app *App) {
 	filtered := cm.GetFilteredCommands()
-	if len(filtered) > 0 && cm.selected < len(filtered) {
-		filtered[cm.selected].Action(app)
-		cm.Hide()
+	selected := cm.selected
+	filter := cm.filter
+	cm.Hide()
+
+	if len(filtered) > 0 && selected < len(filtered) {
+		cmd := filtered[selected]
+		if !cmd.SkipHistory {
+			cm.recordHistory(cmd.Name)
+			cm.recordUsage(cmd.Name)
+		}
+		if arg, ok := commandArg(cmd, filter); ok && arg != "" {
+			cmd.ArgAction(app, arg)
+		} else {
+			cmd.Action(app)
+		}
 	}
 }
@@ -0,0 +1,255 @@
+package internal
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestCommandMenuWordSetPreview(t *testing.T) {
+	menu := NewCommandMenu()
+	menu.SetCommands([]Command{
+		{Name: "restart test"},
+		{Name: "words: english", WordSet: "english"},
+		{Name: "words: german", WordSet: "german"},
+	})
+
+	var calls []string
+	menu.SetPreviewGenerator(func(wordSet string) string {
+		calls = append(calls, wordSet)
+		return "sample " + wordSet
+	})
+	menu.Show()
+
+	// Non word-set commands have no preview
+	if preview := menu.GetPreview(); preview != "" {
+		t.Errorf("Expected no preview for plain command, got %q", preview)
+	}
+
+	menu.MoveDown()
+	if preview := menu.GetPreview(); preview != "sample english" {
+		t.Errorf("Expected english preview, got %q", preview)
+	}
+	// Repeated frames reuse the cached sample
+	menu.GetPreview()
+
+	menu.MoveDown()
+	if preview := menu.GetPreview(); preview != "sample german" {
+		t.Errorf("Expected german preview, got %q", preview)
+	}
+
+	expected := []string{"english", "german"}
+	if len(calls) != len(expected) {
+		t.Fatalf("Expected generator calls %v, got %v", expected, calls)
+	}
+	for i := range expected {
+		if calls[i] != expected[i] {
+			t.Errorf("Call %d: expected %q, got %q", i, expected[i], calls[i])
+		}
+	}
+}
+
+func TestCommandMenuHistoryRecording(t *testing.T) {
+	menu := NewCommandMenu()
+	noop := func(*App) {}
+	menu.SetCommands([]Command{
+		{Name: "alpha", Action: noop},
+		{Name: "beta", Action: noop},
+		{Name: "history", Action: noop, SkipHistory: true},
+	})
+
+	run := func(index int) {
+		menu.Show()
+		for i := 0; i < index; i++ {
+			menu.MoveDown()
+		}
+		menu.ExecuteSelected(nil)
+	}
+
+	run(0) // alpha
+	run(1) // beta
+	run(0) // alpha again moves to the front
+	run(2) // history is not recorded
+
+	history := menu.GetHistory()
+	if len(history) != 2 || history[0] != "alpha" || history[1] != "beta" {
+		t.Errorf("Expected [alpha beta], got %v", history)
+	}
+	if menu.IsVisible() {
+		t.Error("Expected menu to be hidden after execution")
+	}
+}
+
+func TestCommandMenuHistoryCap(t *testing.T) {
+	menu := NewCommandMenu()
+	for i := 0; i < maxCommandHistory+5; i++ {
+		menu.recordHistory(string(rune('a' + i)))
+	}
+
+	history := menu.GetHistory()
+	if len(history) != maxCommandHistory {
+		t.Fatalf("Expected history capped at %d, got %d", maxCommandHistory, len(history))
+	}
+	if history[0] != string(rune('a'+maxCommandHistory+4)) {
+		t.Errorf("Expected most recent command first, got %q", history[0])
+	}
+}
+
+func TestCommandMenuHistoryRerun(t *testing.T) {
+	menu := NewCommandMenu()
+	runs := map[string]int{}
+	command := func(name string) Command {
+		return Command{Name: name, Action: func(*App) { runs[name]++ }}
+	}
+	menu.SetCommands([]Command{command("alpha"), command("beta"), command("gamma")})
+
+	menu.recordHistory("alpha")
+	menu.recordHistory("gamma")
+
+	menu.ShowHistory()
+	listed := menu.GetFilteredCommands()
+	if len(listed) != 2 || listed[0].Name != "gamma" || listed[1].Name != "alpha" {
+		t.Fatalf("Expected history view [gamma alpha], got %v", listed)
+	}
+
+	menu.MoveDown()
+	menu.ExecuteSelected(nil)
+
+	if runs["alpha"] != 1 || runs["gamma"] != 0 {
+		t.Errorf("Expected alpha to be re-run once, got %v", runs)
+	}
+	if history := menu.GetHistory(); history[0] != "alpha" {
+		t.Errorf("Expected re-run command at the front of the history, got %v", history)
+	}
+	if menu.IsHistoryView() {
+		t.Error("Expected history view to close after re-running")
+	}
+}
+
+func commandNames(commands []Command) []string {
+	names := make([]string, len(commands))
+	for i, cmd := range commands {
+		names[i] = cmd.Name
+	}
+	return names
+}
+
+func TestCommandMenuSortsByUsage(t *testing.T) {
+	menu := NewCommandMenu()
+	menu.SetCommands([]Command{
+		{Name: "theme: dracula"},
+		{Name: "theme: gruvbox"},
+		{Name: "text: random"},
+		{Name: "text: browse"},
+		{Name: "quit"},
+	})
+	menu.SetUsageCounts(map[string]int{"text: browse": 5, "theme: gruvbox": 2, "quit": 2})
+	menu.Show()
+
+	got := commandNames(menu.GetFilteredCommands())
+	want := []string{"text: browse", "theme: gruvbox", "quit", "theme: dracula", "text: random"}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("Expected most used first, ties and unused in original order:\n%v\ngot\n%v", want, got)
+	}
+}
+
+func TestCommandMenuFilterIgnoresUsage(t *testing.T) {
+	menu := NewCommandMenu()
+	menu.SetCommands([]Command{
+		{Name: "theme: dracula"},
+		{Name: "theme: gruvbox"},
+		{Name: "text: browse"},
+	})
+	menu.SetUsageCounts(map[string]int{"theme: gruvbox": 10})
+	menu.Show()
+	menu.AddChar('t')
+	menu.AddChar('h')
+
+	got := commandNames(menu.GetFilteredCommands())
+	if fmt.Sprint(got) != fmt.Sprint([]string{"theme: dracula", "theme: gruvbox"}) {
+		t.Errorf("Expected matches in their original order while filtering, got %v", got)
+	}
+}
+
+func TestCommandMenuExactKeywordFirst(t *testing.T) {
+	menu := NewCommandMenu()
+	menu.SetCommands([]Command{
+		{Name: "theme: gruvbox-light", Keywords: []string{"gruvbox-light"}},
+		{Name: "auto theme: toggle", Description: "Switch between gruvbox-light and gruvbox"},
+		{Name: "theme: gruvbox", Keywords: []string{"gruvbox"}},
+		{Name: "text: browse"},
+	})
+	menu.Show()
+	for _, ch := range "Gruvbox" {
+		menu.AddChar(ch)
+	}
+
+	got := commandNames(menu.GetFilteredCommands())
+	want := []string{"theme: gruvbox", "theme: gruvbox-light", "auto theme: toggle"}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("Expected the exact keyword match first, got %v", got)
+	}
+}
+
+func TestCommandMenuRecordsUsage(t *testing.T) {
+	menu := NewCommandMenu()
+	noop := func(*App) {}
+	menu.SetCommands([]Command{
+		{Name: "alpha", Action: noop},
+		{Name: "beta", Action: noop},
+		{Name: "history", Action: noop, SkipHistory: true},
+	})
+
+	menu.Show()
+	menu.MoveDown() // beta
+	menu.ExecuteSelected(nil)
+
+	menu.Show() // beta is now listed first
+	menu.ExecuteSelected(nil)
+
+	menu.Show()
+	menu.AddChar('h')
+	menu.AddChar('i')
+	menu.ExecuteSelected(nil) // history is not counted
+
+	usage := menu.GetUsageCounts()
+	if usage["beta"] != 2 || usage["alpha"] != 0 || usage["history"] != 0 {
+		t.Errorf("Expected only beta counted twice, got %v", usage)
+	}
+
+	menu.Show()
+	if first := menu.GetFilteredCommands()[0].Name; first != "beta" {
+		t.Errorf("Expected the most used command first, got %q", first)
+	}
+}
+
+func TestCommandMenuArgument(t *testing.T) {
+	menu := NewCommandMenu()
+	var got []string
+	menu.SetCommands([]Command{
+		{Name: "words: english", Action: func(*App) { got = append(got, "english") }},
+		{
+			Name:      "words: min length",
+			Action:    func(*App) { got = append(got, "no argument") },
+			ArgAction: func(_ *App, arg string) { got = append(got, "arg "+arg) },
+		},
+	})
+
+	menu.Show()
+	for _, ch := range "Words: Min Length 5" {
+		menu.AddChar(ch)
+	}
+	if names := commandNames(menu.GetFilteredCommands()); len(names) != 1 || names[0] != "words: min length" {
+		t.Fatalf("Expected only the command taking the argument to match, got %v", names)
+	}
+	menu.ExecuteSelected(nil)
+
+	menu.Show()
+	for _, ch := range "words: min length " {
+		menu.AddChar(ch)
+	}
+	menu.ExecuteSelected(nil)
+
+	if fmt.Sprint(got) != "[arg 5 no argument]" {
+		t.Errorf("Expected the argument to be passed only when typed, got %v", got)
+	}
+}
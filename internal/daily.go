@@ -0,0 +1,24 @@
+package internal
+
+import (
+	"fmt"
+	"time"
+)
+
+// dailyChallengeWords is the length of the daily challenge in words.
+const dailyChallengeWords = 50
+
+// SeedFromDate returns the random seed of the daily challenge for the calendar
+// day of t in its location, as the number YYYYMMDD. All times of a day share the
+// seed, so everyone typing on the same day gets the same words.
+func SeedFromDate(t time.Time) int64 {
+	year, month, day := t.Date()
+	return int64(year)*10000 + int64(month)*100 + int64(day)
+}
+
+// DailyChallengeName returns the text name of the daily challenge for the day
+// of t drawn from the given word set. Its leaderboard key keeps a separate best
+// for each day and word set.
+func DailyChallengeName(t time.Time, wordSet string) string {
+	return fmt.Sprintf("Daily challenge %s (%s)", t.Format(time.DateOnly), wordSet)
+}
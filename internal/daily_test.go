@@ -0,0 +1,56 @@
+package internal
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSeedFromDate(t *testing.T) {
+	morning := time.Date(2026, time.March, 14, 6, 30, 0, 0, time.UTC)
+	evening := time.Date(2026, time.March, 14, 23, 59, 59, 0, time.UTC)
+	if SeedFromDate(morning) != SeedFromDate(evening) {
+		t.Errorf("Expected the same seed for the whole day, got %d and %d", SeedFromDate(morning), SeedFromDate(evening))
+	}
+
+	for _, other := range []time.Time{
+		time.Date(2026, time.March, 15, 6, 30, 0, 0, time.UTC),
+		time.Date(2026, time.April, 14, 6, 30, 0, 0, time.UTC),
+		time.Date(2027, time.March, 14, 6, 30, 0, 0, time.UTC),
+	} {
+		if SeedFromDate(other) == SeedFromDate(morning) {
+			t.Errorf("Expected %s to get a different seed than %s", other.Format(time.DateOnly), morning.Format(time.DateOnly))
+		}
+	}
+}
+
+func TestGenerateSeededWords(t *testing.T) {
+	wl := newTestWordLibrary(t, "apple banana cherry date elder fig grape")
+
+	seed := SeedFromDate(time.Date(2026, time.March, 14, 0, 0, 0, 0, time.UTC))
+	first := wl.GenerateSeededWords(seed, 30)
+	wl.GenerateRandomWords(10)
+	if second := wl.GenerateSeededWords(seed, 30); second != first {
+		t.Errorf("Expected the same words for the same seed, got %q and %q", first, second)
+	}
+	if other := wl.GenerateSeededWords(seed+1, 30); other == first {
+		t.Errorf("Expected different words for another seed, got %q twice", first)
+	}
+}
+
+func TestSeededWordsIgnoreWordOptions(t *testing.T) {
+	wl := newTestWordLibrary(t, "a,1\nbe,2\ncat,3\ndoe,4\nelder,5")
+	seed := SeedFromDate(time.Date(2026, time.March, 14, 0, 0, 0, 0, time.UTC))
+	plain := wl.GenerateSeededWords(seed, 50)
+
+	wl.SetWordCase(WordCaseRandomCapitals)
+	wl.SetMinWordLength(3)
+	wl.SetHardOnly(true)
+	wl.SetGenOptions(WordGenOptions{Punctuation: true, Capitalization: true, Numbers: true, NumberChance: 0.5})
+	if styled := wl.GenerateSeededWords(seed, 50); styled != plain {
+		t.Errorf("Expected the daily words to ignore the word options, got %q and %q", plain, styled)
+	}
+	if plain != strings.ToLower(plain) || strings.ContainsAny(plain, ".,;?!0123456789") {
+		t.Errorf("Expected the daily words as listed, got %q", plain)
+	}
+}
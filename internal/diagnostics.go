@@ -0,0 +1,55 @@
+package internal
+
+import (
+	"os"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// Glyph samples used to check whether the terminal can display the characters
+// the UI depends on.
+const (
+	diagnosticsBrailleSample = "⠁⠃⠇⡇⣇⣧⣷⣿"
+	diagnosticsBlockSample   = "░▒▓█▏"
+	diagnosticsBoxSample     = "┌─┐│└┘"
+)
+
+// Diagnostics describes terminal capabilities relevant to rendering.
+// Shown by the "diagnostics" command to help users report display issues.
+type Diagnostics struct {
+	Width, Height int    // Screen size in cells
+	Colors        int    // Number of colors reported by the terminal (0 = monochrome)
+	TrueColor     bool   // Whether 24-bit RGB colors are supported
+	Term          string // $TERM
+	Lang          string // $LANG
+	Braille       bool   // Whether braille glyphs (WPM graph) can be displayed
+	Blocks        bool   // Whether block elements can be displayed
+	BoxDrawing    bool   // Whether box-drawing characters can be displayed
+}
+
+// GatherDiagnostics collects terminal capabilities from the screen and environment.
+func GatherDiagnostics(screen tcell.Screen) Diagnostics {
+	width, height := screen.Size()
+	colors := screen.Colors()
+	return Diagnostics{
+		Width:      width,
+		Height:     height,
+		Colors:     colors,
+		TrueColor:  colors >= 1<<24,
+		Term:       os.Getenv("TERM"),
+		Lang:       os.Getenv("LANG"),
+		Braille:    canDisplayAll(screen, diagnosticsBrailleSample),
+		Blocks:     canDisplayAll(screen, diagnosticsBlockSample),
+		BoxDrawing: canDisplayAll(screen, diagnosticsBoxSample),
+	}
+}
+
+// canDisplayAll reports whether the screen can display every rune in s without fallbacks.
+func canDisplayAll(screen tcell.Screen, s string) bool {
+	for _, r := range s {
+		if !screen.CanDisplay(r, false) {
+			return false
+		}
+	}
+	return true
+}
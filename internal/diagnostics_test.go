@@ -0,0 +1,36 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestGatherDiagnostics(t *testing.T) {
+	t.Setenv("TERM", "xterm-256color")
+
+	screen := tcell.NewSimulationScreen("UTF-8")
+	if err := screen.Init(); err != nil {
+		t.Fatal(err)
+	}
+	defer screen.Fini()
+	screen.SetSize(120, 30)
+
+	diag := GatherDiagnostics(screen)
+
+	if diag.Width != 120 || diag.Height != 30 {
+		t.Errorf("Expected size 120x30, got %dx%d", diag.Width, diag.Height)
+	}
+	if diag.Colors != 256 {
+		t.Errorf("Expected 256 colors from simulation screen, got %d", diag.Colors)
+	}
+	if diag.TrueColor {
+		t.Error("Expected no true color support on a 256-color screen")
+	}
+	if diag.Term != "xterm-256color" {
+		t.Errorf("Expected TERM from environment, got %q", diag.Term)
+	}
+	if !diag.Braille || !diag.Blocks || !diag.BoxDrawing {
+		t.Errorf("Expected UTF-8 screen to display all glyph samples, got %+v", diag)
+	}
+}
@@ -30,6 +30,8 @@
 //
 //   - TextLibrary (textlib.go): Manages loading and selection of practice texts from files.
 //
+//   - TextBrowser (textbrowser.go): Two-pane text picker with a preview of the highlighted text.
+//
 // Design Principles:
 //
 // 1. Separation of Concerns: UI rendering, business logic, and input handling are separate.
@@ -0,0 +1,38 @@
+package internal
+
+import (
+	"fmt"
+	"os"
+)
+
+// envOverrides maps environment variables to the settings keys they override.
+// Values are validated with the same rules as rocketype.conf.
+var envOverrides = []struct {
+	variable string
+	key      string
+}{
+	{"ROCKETYPE_THEME", "theme"},
+	{"ROCKETYPE_MODE", "mode"},
+	{"ROCKETYPE_TIME_LIMIT", "time_limit"},
+}
+
+// ApplyEnvOverrides applies ROCKETYPE_* environment variables to settings.
+// Precedence is command-line flags > environment > rocketype.conf > settings.json,
+// so this is called after the config files are loaded. Like rocketype.conf,
+// the overrides only apply at runtime; App.saveAllSettings doesn't write them
+// to settings.json.
+//
+// Invalid values are ignored; a warning is returned for each of them.
+func ApplyEnvOverrides(settings *Settings) []string {
+	var warnings []string
+	for _, override := range envOverrides {
+		value, ok := os.LookupEnv(override.variable)
+		if !ok || value == "" {
+			continue
+		}
+		if err := rcSetters[override.key](settings, value); err != nil {
+			warnings = append(warnings, fmt.Sprintf("ignoring %s: %v", override.variable, err))
+		}
+	}
+	return warnings
+}
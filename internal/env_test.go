@@ -0,0 +1,37 @@
+package internal
+
+import "testing"
+
+func TestApplyEnvOverrides(t *testing.T) {
+	tests := []struct {
+		name      string
+		variable  string
+		value     string
+		check     func(Settings) bool
+		wantWarns int
+	}{
+		{"theme", "ROCKETYPE_THEME", "dracula", func(s Settings) bool { return s.ThemeName == "dracula" }, 0},
+		{"mode", "ROCKETYPE_MODE", "words", func(s Settings) bool { return s.Mode == "words" }, 0},
+		{"time limit", "ROCKETYPE_TIME_LIMIT", "15", func(s Settings) bool { return s.TimeLimit == 15 }, 0},
+		{"unknown theme", "ROCKETYPE_THEME", "neon", func(s Settings) bool { return s.ThemeName == "default" }, 1},
+		{"invalid mode", "ROCKETYPE_MODE", "poems", func(s Settings) bool { return s.Mode == "text" }, 1},
+		{"invalid time limit", "ROCKETYPE_TIME_LIMIT", "soon", func(s Settings) bool { return s.TimeLimit == 60 }, 1},
+		{"empty value", "ROCKETYPE_MODE", "", func(s Settings) bool { return s.Mode == "text" }, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(tt.variable, tt.value)
+			settings := DefaultSettings()
+
+			warnings := ApplyEnvOverrides(&settings)
+
+			if len(warnings) != tt.wantWarns {
+				t.Errorf("Expected %d warnings, got %v", tt.wantWarns, warnings)
+			}
+			if !tt.check(settings) {
+				t.Errorf("Unexpected settings after override: %+v", settings)
+			}
+		})
+	}
+}
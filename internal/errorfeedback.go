@@ -0,0 +1,42 @@
+package internal
+
+import (
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// Error feedback levels for Settings.ErrorFeedback.
+const (
+	ErrorFeedbackNone   = "none"   // Mistakes are only shown in the text
+	ErrorFeedbackSubtle = "subtle" // Occasional bell and a muted flash
+	ErrorFeedbackStrong = "strong" // Frequent bell and a bright flash
+)
+
+// ErrorFeedback describes how mistyped keys are signaled at one level.
+type ErrorFeedback struct {
+	BellInterval  time.Duration // Minimum time between two bells (0 = no bell)
+	FlashDuration time.Duration // How long the screen edges flash (0 = no flash)
+	StrongFlash   bool          // Flash in the error color instead of a muted one
+}
+
+// ErrorFeedbackFor returns the feedback for a level. Unknown levels give no
+// feedback, like ErrorFeedbackNone.
+func ErrorFeedbackFor(level string) ErrorFeedback {
+	switch level {
+	case ErrorFeedbackSubtle:
+		return ErrorFeedback{BellInterval: time.Second, FlashDuration: 80 * time.Millisecond}
+	case ErrorFeedbackStrong:
+		return ErrorFeedback{BellInterval: 200 * time.Millisecond, FlashDuration: 150 * time.Millisecond, StrongFlash: true}
+	default:
+		return ErrorFeedback{}
+	}
+}
+
+// FlashStyle returns the style the screen edges flash in.
+func (f ErrorFeedback) FlashStyle(theme Theme) tcell.Style {
+	if f.StrongFlash {
+		return tcell.StyleDefault.Background(theme.TextIncorrect)
+	}
+	return tcell.StyleDefault.Background(theme.MenuDimText)
+}
@@ -0,0 +1,74 @@
+package internal
+
+import (
+	"testing"
+	"time"
+)
+
+func TestErrorFeedbackFor(t *testing.T) {
+	tests := []struct {
+		level        string
+		bellInterval time.Duration
+		flash        bool
+		strongFlash  bool
+	}{
+		{ErrorFeedbackNone, 0, false, false},
+		{ErrorFeedbackSubtle, time.Second, true, false},
+		{ErrorFeedbackStrong, 200 * time.Millisecond, true, true},
+		{"", 0, false, false},
+		{"loud", 0, false, false},
+	}
+
+	for _, tt := range tests {
+		got := ErrorFeedbackFor(tt.level)
+		if got.BellInterval != tt.bellInterval {
+			t.Errorf("%q: expected bell interval %v, got %v", tt.level, tt.bellInterval, got.BellInterval)
+		}
+		if (got.FlashDuration > 0) != tt.flash || got.StrongFlash != tt.strongFlash {
+			t.Errorf("%q: expected flash %v (strong %v), got %+v", tt.level, tt.flash, tt.strongFlash, got)
+		}
+	}
+}
+
+func TestErrorFeedbackFlashStyle(t *testing.T) {
+	theme := DefaultTheme
+
+	_, strongBg, _ := ErrorFeedbackFor(ErrorFeedbackStrong).FlashStyle(theme).Decompose()
+	if strongBg != theme.TextIncorrect {
+		t.Errorf("Expected the strong flash in the error color, got %v", strongBg)
+	}
+	_, subtleBg, _ := ErrorFeedbackFor(ErrorFeedbackSubtle).FlashStyle(theme).Decompose()
+	if subtleBg != theme.MenuDimText {
+		t.Errorf("Expected the subtle flash in the muted color, got %v", subtleBg)
+	}
+}
+
+func TestOnErrorThrottlesBell(t *testing.T) {
+	app := newTestApp(t)
+	app.settings.ErrorFeedback = ErrorFeedbackSubtle
+	start := time.Now()
+
+	app.onError(start)
+	if !app.lastBell.Equal(start) || !app.flashUntil.After(start) {
+		t.Fatal("Expected the first mistake to ring the bell and flash")
+	}
+
+	app.onError(start.Add(500 * time.Millisecond))
+	if !app.lastBell.Equal(start) {
+		t.Error("Expected no second bell within the interval")
+	}
+	if !app.flashUntil.After(start.Add(500 * time.Millisecond)) {
+		t.Error("Expected every mistake to flash")
+	}
+
+	app.onError(start.Add(time.Second))
+	if !app.lastBell.Equal(start.Add(time.Second)) {
+		t.Error("Expected the bell again once the interval passed")
+	}
+
+	app.settings.BlindMode = true
+	app.onError(start.Add(5 * time.Second))
+	if !app.lastBell.Equal(start.Add(time.Second)) {
+		t.Error("Expected no feedback in blind mode")
+	}
+}
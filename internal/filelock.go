@@ -0,0 +1,25 @@
+package internal
+
+import (
+	"fmt"
+	"os"
+)
+
+// lockFile takes an exclusive lock on path's ".lock" file, creating it if
+// needed, and waits until other processes release it. It guards
+// read-modify-write cycles of files that several running instances update.
+// The returned function releases the lock.
+func lockFile(path string) (func(), error) {
+	f, err := os.OpenFile(path+".lock", os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open lock file: %w", err)
+	}
+	if err := lockExclusive(f); err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("failed to lock %s: %w", path, err)
+	}
+	return func() {
+		_ = unlockFile(f)
+		_ = f.Close()
+	}, nil
+}
@@ -0,0 +1,16 @@
+//go:build !unix && !windows
+
+package internal
+
+import "os"
+
+// lockExclusive does nothing on systems without file locks; only the
+// in-process mutexes guard the files there.
+func lockExclusive(f *os.File) error {
+	return nil
+}
+
+// unlockFile releases the lock taken by lockExclusive.
+func unlockFile(f *os.File) error {
+	return nil
+}
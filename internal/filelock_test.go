@@ -0,0 +1,44 @@
+package internal
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLockFileExcludesOtherHolders(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stats.json")
+
+	unlock, err := lockFile(path)
+	if err != nil {
+		t.Fatalf("failed to lock: %v", err)
+	}
+
+	// A second lock file handle stands in for another process
+	acquired := make(chan func())
+	go func() {
+		unlockSecond, err := lockFile(path)
+		if err != nil {
+			t.Errorf("failed to lock again: %v", err)
+			close(acquired)
+			return
+		}
+		acquired <- unlockSecond
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("Expected the second lock to wait for the first")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	unlock()
+	select {
+	case unlockSecond := <-acquired:
+		if unlockSecond != nil {
+			unlockSecond()
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the second lock once the first was released")
+	}
+}
@@ -0,0 +1,18 @@
+//go:build unix
+
+package internal
+
+import (
+	"os"
+	"syscall"
+)
+
+// lockExclusive blocks until it holds an exclusive flock on f.
+func lockExclusive(f *os.File) error {
+	return syscall.Flock(int(f.Fd()), syscall.LOCK_EX)
+}
+
+// unlockFile releases the lock taken by lockExclusive.
+func unlockFile(f *os.File) error {
+	return syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+}
@@ -0,0 +1,19 @@
+//go:build windows
+
+package internal
+
+import (
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// lockExclusive blocks until it holds an exclusive lock on the first byte of f.
+func lockExclusive(f *os.File) error {
+	return windows.LockFileEx(windows.Handle(f.Fd()), windows.LOCKFILE_EXCLUSIVE_LOCK, 0, 1, 0, &windows.Overlapped{})
+}
+
+// unlockFile releases the lock taken by lockExclusive.
+func unlockFile(f *os.File) error {
+	return windows.UnlockFileEx(windows.Handle(f.Fd()), 0, 1, 0, &windows.Overlapped{})
+}
@@ -0,0 +1,134 @@
+package internal
+
+import "strings"
+
+// ASCII mode settings for Settings.ASCIIMode.
+const (
+	ASCIIModeAuto = "auto" // Detect from the locale and terminal
+	ASCIIModeOn   = "on"   // Always use ASCII glyphs
+	ASCIIModeOff  = "off"  // Always use Unicode glyphs
+)
+
+// Glyphs holds the decorative characters used by the renderer, so Unicode and
+// ASCII rendering share the same drawing code.
+type Glyphs struct {
+	// Box drawing
+	BoxTopLeft     rune
+	BoxTopRight    rune
+	BoxBottomLeft  rune
+	BoxBottomRight rune
+	BoxHorizontal  rune
+	BoxVertical    rune
+
+	Newline     rune // Marks newlines in the sample text
+	InputCursor rune // Cursor in text input fields
+	ScrollUp    rune // More items above
+	ScrollDown  rune // More items below
+	ErrorMarker rune // Error positions on the WPM graph
+	Separator   rune // Between items on one line of text
+	Pending     rune // After the text while more words are generated
+
+	// WPM graph
+	Braille    bool // Draw the graph line with braille dots
+	GraphPoint rune // Data points (ASCII only)
+	GraphLink  rune // Vertical links between data points (ASCII only)
+
+	// Bar charts
+	BarLevels   string // Bar cells from the lowest partial height up to a full cell
+	SparkLevels string // Sparkline cells from the lowest to the highest value
+}
+
+// UnicodeGlyphs uses box-drawing characters, symbols and braille.
+var UnicodeGlyphs = Glyphs{
+	BoxTopLeft:     '┌',
+	BoxTopRight:    '┐',
+	BoxBottomLeft:  '└',
+	BoxBottomRight: '┘',
+	BoxHorizontal:  '─',
+	BoxVertical:    '│',
+	Newline:        '↵',
+	InputCursor:    '▏',
+	ScrollUp:       '▲',
+	ScrollDown:     '▼',
+	ErrorMarker:    '×',
+	Separator:      '·',
+	Pending:        '…',
+	Braille:        true,
+	BarLevels:      "▁▂▃▄▅▆▇█",
+	SparkLevels:    "▁▂▃▄▅▆▇█",
+}
+
+// ASCIIGlyphs only uses 7-bit ASCII, for terminals without Unicode support.
+var ASCIIGlyphs = Glyphs{
+	BoxTopLeft:     '+',
+	BoxTopRight:    '+',
+	BoxBottomLeft:  '+',
+	BoxBottomRight: '+',
+	BoxHorizontal:  '-',
+	BoxVertical:    '|',
+	Newline:        '$',
+	InputCursor:    '_',
+	ScrollUp:       '^',
+	ScrollDown:     'v',
+	ErrorMarker:    'x',
+	Separator:      '|',
+	Pending:        '~',
+	Braille:        false,
+	GraphPoint:     '*',
+	GraphLink:      '.',
+	BarLevels:      "#",
+	SparkLevels:    "_.-^",
+}
+
+// GlyphsFor returns the glyph set for the given mode.
+func GlyphsFor(ascii bool) Glyphs {
+	if ascii {
+		return ASCIIGlyphs
+	}
+	return UnicodeGlyphs
+}
+
+// asciiOnlyTerms are terminal types that can't display Unicode.
+var asciiOnlyTerms = map[string]bool{
+	"dumb":  true,
+	"vt52":  true,
+	"vt100": true,
+	"vt102": true,
+	"vt220": true,
+}
+
+// DetectASCIIMode guesses whether the terminal lacks Unicode support from the
+// locale variables (LC_ALL > LC_CTYPE > LANG) and $TERM. An unset locale is
+// assumed to be Unicode-capable, as most modern terminals are.
+func DetectASCIIMode(lcAll, lcCtype, lang, term string) bool {
+	if asciiOnlyTerms[term] {
+		return true
+	}
+
+	locale := lcAll
+	if locale == "" {
+		locale = lcCtype
+	}
+	if locale == "" {
+		locale = lang
+	}
+	if locale == "" {
+		return false
+	}
+
+	locale = strings.ToLower(locale)
+	return !strings.Contains(locale, "utf-8") && !strings.Contains(locale, "utf8")
+}
+
+// ResolveASCIIMode turns an ASCIIMode setting into a decision, running
+// detection for "auto" using the given environment lookup.
+func ResolveASCIIMode(mode string, getenv func(string) string) bool {
+	switch mode {
+	case ASCIIModeOn:
+		return true
+	case ASCIIModeOff:
+		return false
+	default:
+		return DetectASCIIMode(getenv("LC_ALL"), getenv("LC_CTYPE"), getenv("LANG"), getenv("TERM"))
+	}
+}
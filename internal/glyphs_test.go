@@ -0,0 +1,66 @@
+package internal
+
+import "testing"
+
+func TestGlyphsForMode(t *testing.T) {
+	unicode := GlyphsFor(false)
+	if !unicode.Braille || unicode.BoxTopLeft != '┌' || unicode.Newline != '↵' {
+		t.Errorf("Expected Unicode glyphs, got %+v", unicode)
+	}
+
+	ascii := GlyphsFor(true)
+	if ascii.Braille {
+		t.Error("Expected ASCII mode to draw the graph without braille")
+	}
+	glyphs := []rune{
+		ascii.BoxTopLeft, ascii.BoxTopRight, ascii.BoxBottomLeft, ascii.BoxBottomRight,
+		ascii.BoxHorizontal, ascii.BoxVertical, ascii.Newline, ascii.InputCursor,
+		ascii.ScrollUp, ascii.ScrollDown, ascii.ErrorMarker, ascii.GraphPoint, ascii.GraphLink,
+	}
+	for _, g := range glyphs {
+		if g == 0 || g > 127 {
+			t.Errorf("Expected printable ASCII glyph, got %q", g)
+		}
+	}
+}
+
+func TestDetectASCIIMode(t *testing.T) {
+	tests := []struct {
+		name                 string
+		lcAll, lcCtype, lang string
+		term                 string
+		want                 bool
+	}{
+		{"utf-8 locale", "", "", "en_US.UTF-8", "xterm-256color", false},
+		{"utf8 spelling", "", "", "de_DE.utf8", "xterm", false},
+		{"unset locale", "", "", "", "xterm", false},
+		{"posix locale", "", "", "C", "xterm", true},
+		{"latin1 locale", "", "", "en_US.ISO-8859-1", "xterm", true},
+		{"LC_ALL wins", "C", "", "en_US.UTF-8", "xterm", true},
+		{"LC_CTYPE before LANG", "", "en_US.UTF-8", "C", "xterm", false},
+		{"ascii-only terminal", "", "", "en_US.UTF-8", "vt100", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DetectASCIIMode(tt.lcAll, tt.lcCtype, tt.lang, tt.term); got != tt.want {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestResolveASCIIMode(t *testing.T) {
+	env := map[string]string{"LANG": "C"}
+	getenv := func(key string) string { return env[key] }
+
+	if !ResolveASCIIMode(ASCIIModeOn, getenv) {
+		t.Error("Expected on to force ASCII")
+	}
+	if ResolveASCIIMode(ASCIIModeOff, getenv) {
+		t.Error("Expected off to force Unicode")
+	}
+	if !ResolveASCIIMode(ASCIIModeAuto, getenv) {
+		t.Error("Expected auto to detect ASCII from the C locale")
+	}
+}
@@ -0,0 +1,48 @@
+package internal
+
+import (
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/rivo/uniseg"
+)
+
+// GraphemeCount returns the number of user-perceived characters (grapheme
+// clusters) in s. A Devanagari syllable like "कि" is one character made of
+// two runes, a consonant and a vowel sign.
+func GraphemeCount(s string) int {
+	return uniseg.GraphemeClusterCount(s)
+}
+
+// maxClusterRunes bounds how far clusterBreak looks ahead for the end of a
+// grapheme cluster; real clusters are much shorter.
+const maxClusterRunes = 32
+
+// clusterBreak returns where to cut text at index i (start < i) without
+// splitting a grapheme cluster: the start of the cluster containing runes[i],
+// looking no further back than start. If that cluster begins at start itself,
+// cutting before it would make no progress, so its end is returned instead.
+func clusterBreak(runes []rune, start, i int) int {
+	pos, last := start, start
+	state := -1
+	rest := string(runes[start : i+1])
+	for rest != "" {
+		var cluster string
+		cluster, rest, _, state = uniseg.FirstGraphemeClusterInString(rest, state)
+		last = pos
+		pos += utf8.RuneCountInString(cluster)
+	}
+	if last > start {
+		return last
+	}
+
+	end := min(len(runes), start+maxClusterRunes)
+	first, _, _, _ := uniseg.FirstGraphemeClusterInString(string(runes[start:end]), -1)
+	return start + utf8.RuneCountInString(first)
+}
+
+// isCombiningMark reports whether r is drawn together with the character
+// before it, like Devanagari vowel signs or combining accents.
+func isCombiningMark(r rune) bool {
+	return unicode.In(r, unicode.Mn, unicode.Mc, unicode.Me)
+}
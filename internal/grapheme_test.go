@@ -0,0 +1,89 @@
+package internal
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+// namaste is "नमस्ते": न, म, स + virama, त + vowel sign e.
+const namaste = "नमस्ते"
+
+func TestGraphemeCount(t *testing.T) {
+	tests := []struct {
+		text string
+		want int
+	}{
+		{"abc", 3},
+		{"कि", 1}, // कि: consonant with vowel sign
+		{namaste, 4},
+		{"é", 1}, // e with a combining acute accent
+	}
+
+	for _, tt := range tests {
+		if got := GraphemeCount(tt.text); got != tt.want {
+			t.Errorf("GraphemeCount(%q) = %d, want %d", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestWrapKeepsClustersTogether(t *testing.T) {
+	// A long word without spaces is broken at the width; none of the widths
+	// may split a consonant from its vowel sign or virama
+	text := strings.Repeat(namaste, 4)
+	for width := 1; width <= 8; width++ {
+		lines := wrapText(text, width)
+		if strings.Join(lines, "") != text {
+			t.Fatalf("width %d: expected the lines to add up to the text, got %q", width, lines)
+		}
+		for _, line := range lines {
+			if first := []rune(line)[0]; isCombiningMark(first) {
+				t.Errorf("width %d: line %q starts with a combining mark", width, line)
+			}
+		}
+	}
+}
+
+func TestWordLibraryDevanagari(t *testing.T) {
+	// कि has two runes but is a single character
+	wl := newTestWordLibrary(t, "कि "+namaste)
+	words := strings.Fields(wl.GenerateRandomWords(30))
+	if len(words) != 30 {
+		t.Fatalf("Expected 30 words, got %d", len(words))
+	}
+	for _, word := range words {
+		if word != "कि" && word != namaste {
+			t.Fatalf("Expected only whole words from the set, got %q", word)
+		}
+	}
+
+	wl.SetMinWordLength(2)
+	for _, word := range strings.Fields(wl.GenerateRandomWords(30)) {
+		if word != namaste {
+			t.Fatalf("Expected the minimum length to count characters, not runes, got %q", word)
+		}
+	}
+}
+
+func TestDrawTypingViewCombinesMarks(t *testing.T) {
+	renderer, screen := newTestRenderer(t, 40, 12)
+	renderer.DrawTypingView(TypingViewData{
+		SampleText:  namaste,
+		SampleRunes: []rune(namaste),
+		Theme:       DefaultTheme,
+	})
+	screen.Show()
+
+	cells, width, height := screen.GetContents()
+	var drawn []string
+	for i := 0; i < width*height; i++ {
+		if runes := cells[i].Runes; len(runes) > 0 && runes[0] != ' ' {
+			drawn = append(drawn, string(runes))
+		}
+	}
+
+	want := []string{"न", "म", "स्", "ते"}
+	if !slices.Equal(drawn, want) {
+		t.Errorf("Expected one cell per character %q, got %q", want, drawn)
+	}
+}
@@ -0,0 +1,36 @@
+package internal
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// gutenbergStartPattern matches the line that ends the Project Gutenberg
+	// header, e.g. "*** START OF THE PROJECT GUTENBERG EBOOK MOBY DICK ***".
+	gutenbergStartPattern = regexp.MustCompile(`(?im)^\*{3}\s*START OF (?:THE|THIS) PROJECT GUTENBERG[^\n]*`)
+
+	// gutenbergEndPattern matches the line that starts the license footer.
+	gutenbergEndPattern = regexp.MustCompile(`(?im)^\*{3}\s*END OF (?:THE|THIS) PROJECT GUTENBERG[^\n]*`)
+)
+
+// CleanGutenberg removes the Project Gutenberg header and license footer
+// around a book, keeping the text between the "*** START OF ..." and
+// "*** END OF ..." marker lines. If only one marker is present, only that side
+// is cut. Text without markers is returned unchanged.
+func CleanGutenberg(text string) string {
+	start := gutenbergStartPattern.FindStringIndex(text)
+	end := gutenbergEndPattern.FindStringIndex(text)
+	if start == nil && end == nil {
+		return text
+	}
+
+	body := text
+	if end != nil {
+		body = body[:end[0]]
+	}
+	if start != nil && start[1] <= len(body) {
+		body = body[start[1]:]
+	}
+	return strings.TrimSpace(body)
+}
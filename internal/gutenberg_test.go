@@ -0,0 +1,59 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const gutenbergSample = `The Project Gutenberg eBook of The Hobbit
+
+This eBook is for the use of anyone anywhere at no cost.
+
+*** START OF THE PROJECT GUTENBERG EBOOK THE HOBBIT ***
+
+In a hole in the ground there lived a hobbit.
+Not a nasty, dirty, wet hole.
+
+*** END OF THE PROJECT GUTENBERG EBOOK THE HOBBIT ***
+
+Updated editions will replace the previous one.
+`
+
+func TestCleanGutenberg(t *testing.T) {
+	want := "In a hole in the ground there lived a hobbit.\nNot a nasty, dirty, wet hole."
+	if got := CleanGutenberg(gutenbergSample); got != want {
+		t.Errorf("CleanGutenberg() = %q, want %q", got, want)
+	}
+
+	// Older books use "THIS" and carriage returns
+	older := "Header\r\n*** START OF THIS PROJECT GUTENBERG EBOOK ***\r\nBody text\r\n*** END OF THIS PROJECT GUTENBERG EBOOK ***\r\nFooter"
+	if got := CleanGutenberg(older); got != "Body text" {
+		t.Errorf("Expected only the body of the older format, got %q", got)
+	}
+}
+
+func TestCleanGutenbergWithoutMarkers(t *testing.T) {
+	text := "Roads go ever ever on,\n*** not a marker ***\nOver rock and under tree"
+	if got := CleanGutenberg(text); got != text {
+		t.Errorf("Expected text without markers to be unchanged, got %q", got)
+	}
+}
+
+func TestLoadTextCleansGutenberg(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "hobbit.txt"), []byte(gutenbergSample), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	library := NewTextLibrary([]string{dir})
+	want := "In a hole in the ground there lived a hobbit.\nNot a nasty, dirty, wet hole."
+	if got := library.GetCurrentText().Content; got != want {
+		t.Errorf("Expected the cleaned book, got %q", got)
+	}
+
+	library.SetPipeline(nil)
+	if got := library.GetCurrentText().Content; got == want {
+		t.Error("Expected the boilerplate to be kept when cleaning is off")
+	}
+}
@@ -0,0 +1,144 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"time"
+)
+
+// TestResult is one completed test in the results history.
+// Unlike the leaderboard, which keeps only the best entries, the history
+// records every attempt.
+type TestResult struct {
+	Timestamp time.Time `json:"timestamp"`
+	Mode      string    `json:"mode"`      // "text", "words" or "quote"
+	TextName  string    `json:"text_name"` // Text or word set name
+	WPM       float64   `json:"wpm"`
+	Accuracy  float64   `json:"accuracy"`
+}
+
+// AppendResult adds a result to the end of the results history.
+// The history is stored as JSON lines, so appending never rewrites the file.
+func AppendResult(result TestResult) error {
+	path, err := GetResultsHistoryPath()
+	if err != nil {
+		return fmt.Errorf("failed to resolve results history path: %w", err)
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("failed to marshal result: %w", err)
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open results history: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write result: %w", err)
+	}
+	return nil
+}
+
+// LoadResultsHistory reads all results in the order they were recorded.
+// Returns an empty slice if the file does not exist. Lines that can't be
+// parsed (e.g. a partially written last line) are skipped.
+func LoadResultsHistory() ([]TestResult, error) {
+	path, err := GetResultsHistoryPath()
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve results history path: %w", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return []TestResult{}, nil
+		}
+		return nil, fmt.Errorf("failed to read results history: %w", err)
+	}
+
+	results := []TestResult{}
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		var result TestResult
+		if err := json.Unmarshal(line, &result); err != nil {
+			continue
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// StatsHistory answers questions about earlier results, like the personal best
+// for a text.
+type StatsHistory struct {
+	results []TestResult
+}
+
+// NewStatsHistory creates a StatsHistory over the given results.
+func NewStatsHistory(results []TestResult) *StatsHistory {
+	return &StatsHistory{results: results}
+}
+
+// LoadStatsHistory reads the results history (see LoadResultsHistory).
+func LoadStatsHistory() (*StatsHistory, error) {
+	results, err := LoadResultsHistory()
+	if err != nil {
+		return nil, err
+	}
+	return NewStatsHistory(results), nil
+}
+
+// BestWPM returns the highest WPM recorded for the text or word set in the
+// given mode, or 0 if it has no results yet.
+func (h *StatsHistory) BestWPM(textName, mode string) float64 {
+	best := 0.0
+	for _, result := range h.results {
+		if result.Mode == mode && result.TextName == textName {
+			best = max(best, result.WPM)
+		}
+	}
+	return best
+}
+
+// AttemptCounts returns how many results exist per text name for the given mode.
+func AttemptCounts(results []TestResult, mode string) map[string]int {
+	counts := make(map[string]int)
+	for _, result := range results {
+		if result.Mode == mode {
+			counts[result.TextName]++
+		}
+	}
+	return counts
+}
+
+// SelectLeastPracticed returns the name with the fewest attempts. Names without
+// any attempts count as zero. Ties are broken randomly using rng.
+// Returns false if names is empty.
+func SelectLeastPracticed(names []string, attempts map[string]int, rng *rand.Rand) (string, bool) {
+	var candidates []string
+	fewest := 0
+	for _, name := range names {
+		count := attempts[name]
+		switch {
+		case len(candidates) == 0 || count < fewest:
+			candidates = []string{name}
+			fewest = count
+		case count == fewest:
+			candidates = append(candidates, name)
+		}
+	}
+
+	if len(candidates) == 0 {
+		return "", false
+	}
+	return candidates[rng.Intn(len(candidates))], true
+}
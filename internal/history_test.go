@@ -0,0 +1,123 @@
+package internal
+
+import (
+	"math/rand"
+	"os"
+	"testing"
+)
+
+func TestAttemptCounts(t *testing.T) {
+	results := []TestResult{
+		{Mode: "text", TextName: "a"},
+		{Mode: "text", TextName: "a"},
+		{Mode: "text", TextName: "b"},
+		{Mode: "words", TextName: "a"},
+	}
+
+	counts := AttemptCounts(results, "text")
+	if counts["a"] != 2 || counts["b"] != 1 || len(counts) != 2 {
+		t.Errorf("Expected a=2 b=1, got %v", counts)
+	}
+}
+
+func TestStatsHistoryBestWPM(t *testing.T) {
+	history := NewStatsHistory([]TestResult{
+		{Mode: "text", TextName: "a", WPM: 60},
+		{Mode: "text", TextName: "a", WPM: 72.5},
+		{Mode: "words", TextName: "a", WPM: 90},
+		{Mode: "text", TextName: "b", WPM: 80},
+	})
+
+	if got := history.BestWPM("a", "text"); got != 72.5 {
+		t.Errorf("Expected the best of text a to be 72.5, got %.1f", got)
+	}
+	if got := history.BestWPM("a", "words"); got != 90 {
+		t.Errorf("Expected modes to keep separate bests, got %.1f", got)
+	}
+	if got := history.BestWPM("c", "text"); got != 0 {
+		t.Errorf("Expected 0 without results, got %.1f", got)
+	}
+}
+
+func TestSelectLeastPracticed(t *testing.T) {
+	names := []string{"a", "b", "c"}
+	attempts := map[string]int{"a": 3, "b": 1, "c": 2}
+
+	got, ok := SelectLeastPracticed(names, attempts, rand.New(rand.NewSource(1)))
+	if !ok || got != "b" {
+		t.Errorf("Expected \"b\", got %q (ok=%v)", got, ok)
+	}
+}
+
+func TestSelectLeastPracticedPrefersUnpracticed(t *testing.T) {
+	names := []string{"a", "b", "c"}
+	attempts := map[string]int{"a": 3, "b": 1}
+
+	got, _ := SelectLeastPracticed(names, attempts, rand.New(rand.NewSource(1)))
+	if got != "c" {
+		t.Errorf("Expected text without attempts to be selected, got %q", got)
+	}
+}
+
+func TestSelectLeastPracticedTies(t *testing.T) {
+	names := []string{"a", "b", "c", "d"}
+	attempts := map[string]int{"a": 1, "b": 5, "c": 1, "d": 1}
+
+	seen := map[string]bool{}
+	for seed := int64(0); seed < 50; seed++ {
+		got, _ := SelectLeastPracticed(names, attempts, rand.New(rand.NewSource(seed)))
+		if got == "b" {
+			t.Fatalf("Seed %d: selected a text with more attempts", seed)
+		}
+		seen[got] = true
+	}
+
+	if len(seen) != 3 {
+		t.Errorf("Expected ties to be broken randomly across a, c and d, got %v", seen)
+	}
+
+	// The same seed always makes the same choice
+	first, _ := SelectLeastPracticed(names, attempts, rand.New(rand.NewSource(7)))
+	second, _ := SelectLeastPracticed(names, attempts, rand.New(rand.NewSource(7)))
+	if first != second {
+		t.Errorf("Expected deterministic choice for a fixed seed, got %q and %q", first, second)
+	}
+}
+
+func TestSelectLeastPracticedNoNames(t *testing.T) {
+	if _, ok := SelectLeastPracticed(nil, map[string]int{"a": 1}, rand.New(rand.NewSource(1))); ok {
+		t.Error("Expected no selection without names")
+	}
+}
+
+func TestResultsHistoryRoundTrip(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	results, err := LoadResultsHistory()
+	if err != nil || len(results) != 0 {
+		t.Fatalf("Expected empty history, got %v (err %v)", results, err)
+	}
+
+	for _, name := range []string{"a", "b"} {
+		if err := AppendResult(TestResult{Mode: "text", TextName: name, WPM: 50}); err != nil {
+			t.Fatalf("AppendResult failed: %v", err)
+		}
+	}
+
+	// A partially written line is skipped
+	path, _ := GetResultsHistoryPath()
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file.WriteString(`{"mode":"te`)
+	file.Close()
+
+	results, err = LoadResultsHistory()
+	if err != nil {
+		t.Fatalf("LoadResultsHistory failed: %v", err)
+	}
+	if len(results) != 2 || results[0].TextName != "a" || results[1].TextName != "b" {
+		t.Errorf("Expected results a, b in order, got %v", results)
+	}
+}
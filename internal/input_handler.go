@@ -12,48 +12,132 @@ const (
 	ModeResults
 	// ModeCommandMenu is when the command menu is visible.
 	ModeCommandMenu
+	// ModeTextBrowser is when the text browser is visible.
+	ModeTextBrowser
+	// ModeReplay is when a finished test is being replayed.
+	ModeReplay
+	// ModeDiagnostics is when the diagnostics overlay is visible.
+	ModeDiagnostics
+	// ModeLineTimings is the per-line timing view of the results screen.
+	ModeLineTimings
+	// ModeProgressReport is when the progress report overlay is visible.
+	ModeProgressReport
+	// ModeLifetimeStats is when the lifetime stats overlay is visible.
+	ModeLifetimeStats
+	// ModeEmpty is when there are neither texts nor word sets to practice.
+	ModeEmpty
+	// ModeConfirm is when a yes/no question or a notice is shown.
+	ModeConfirm
+	// ModeResumePrompt is when asking on startup whether to resume the saved session.
+	ModeResumePrompt
+	// ModePaused is when the running test is paused.
+	ModePaused
 )
 
+// InputCallbacks holds the application actions triggered by keyboard shortcuts.
+type InputCallbacks struct {
+	OnQuit              func()
+	OnToggleCommandMenu func()
+	OnCycleTheme        func()
+	OnToggleLastTheme   func()
+	OnRestartTest       func()
+	OnRegenerateWords   func()
+	OnNextTest          func()
+	OnToggleLimitType   func()
+	OnTogglePause       func()
+	OnToggleLiveStats   func()
+	OnToggleReplayPause func()
+	OnChangeReplaySpeed func(factor float64)
+	OnExitReplay        func()
+	OnCloseDiagnostics  func()
+	OnToggleLineTimings func()
+	OnCloseReport       func()
+	OnSetReportPeriod   func(period ReportPeriod)
+	OnCloseLifetime     func()
+	OnConfirm           func(yes bool)
+	OnAnswerResume      func(resume bool)
+	OnReviewScroll      func(toEnd bool)
+}
+
 // InputHandler handles keyboard input routing based on application mode.
 // It separates input handling logic from the main application controller.
 type InputHandler struct {
 	// Callbacks for different actions
-	onQuit              func()
-	onToggleCommandMenu func()
-	onCycleTheme        func()
-	onRestartTest       func()
+	callbacks InputCallbacks
+
+	tabKey   string // Role of the Tab key while typing (TabKeyAuto, TabKeyRestart or TabKeyType)
+	keymap   Keymap // Key bindings of the typing and results screen actions
+	enterKey string // Action of Enter on the results screen (ResultsEnterRestart or ResultsEnterNext)
 
 	// Mode-specific handlers
 	typingHandler      *TypingInputHandler
 	resultsHandler     *ResultsInputHandler
 	commandMenuHandler *CommandMenuInputHandler
+	textBrowserHandler *TextBrowserInputHandler
 }
 
-// NewInputHandler creates a new input handler with the given callbacks.
+// NewInputHandler creates a new input handler with the given callbacks and
+// the default keymap (see SetKeymap).
 func NewInputHandler(
-	onQuit func(),
-	onToggleCommandMenu func(),
-	onCycleTheme func(),
-	onRestartTest func(),
+	callbacks InputCallbacks,
 	typingTest *TypingTest,
 	commandMenu *CommandMenu,
+	textBrowser *TextBrowser,
 ) *InputHandler {
 	return &InputHandler{
-		onQuit:              onQuit,
-		onToggleCommandMenu: onToggleCommandMenu,
-		onCycleTheme:        onCycleTheme,
-		onRestartTest:       onRestartTest,
-		typingHandler:       NewTypingInputHandler(typingTest),
-		resultsHandler:      NewResultsInputHandler(),
-		commandMenuHandler:  NewCommandMenuInputHandler(commandMenu),
+		callbacks:          callbacks,
+		tabKey:             TabKeyAuto,
+		keymap:             DefaultKeymap(),
+		enterKey:           ResultsEnterRestart,
+		typingHandler:      NewTypingInputHandler(typingTest),
+		resultsHandler:     NewResultsInputHandler(),
+		commandMenuHandler: NewCommandMenuInputHandler(commandMenu),
+		textBrowserHandler: NewTextBrowserInputHandler(textBrowser),
 	}
 }
 
+// SetTabKey sets what the Tab key does while typing. Unknown roles behave like TabKeyAuto.
+func (h *InputHandler) SetTabKey(role string) {
+	h.tabKey = role
+}
+
+// SetKeymap sets the key bindings of the typing and results screen actions.
+func (h *InputHandler) SetKeymap(keymap Keymap) {
+	h.keymap = keymap
+}
+
+// SetResultsEnterAction sets what Enter does on the results screen. With
+// ResultsEnterNext, Enter moves on to the next test and no longer triggers the
+// action the keymap binds it to; other actions leave Enter to the keymap.
+func (h *InputHandler) SetResultsEnterAction(action string) {
+	h.enterKey = action
+}
+
 // HandleKey routes keyboard events to the appropriate handler based on mode.
 func (h *InputHandler) HandleKey(ev *tcell.EventKey, mode AppMode) {
 	switch mode {
 	case ModeCommandMenu:
 		h.handleCommandMenuKey(ev)
+	case ModeTextBrowser:
+		h.handleTextBrowserKey(ev)
+	case ModeReplay:
+		h.handleReplayKey(ev)
+	case ModeDiagnostics:
+		h.handleDiagnosticsKey(ev)
+	case ModeLineTimings:
+		h.handleLineTimingsKey(ev)
+	case ModeProgressReport:
+		h.handleProgressReportKey(ev)
+	case ModeLifetimeStats:
+		h.handleLifetimeStatsKey(ev)
+	case ModeEmpty:
+		h.handleEmptyKey(ev)
+	case ModeConfirm:
+		h.handleConfirmKey(ev)
+	case ModeResumePrompt:
+		h.handleResumePromptKey(ev)
+	case ModePaused:
+		h.handlePausedKey(ev)
 	case ModeResults:
 		h.handleResultsKey(ev)
 	case ModeTyping:
@@ -62,16 +146,41 @@ func (h *InputHandler) HandleKey(ev *tcell.EventKey, mode AppMode) {
 }
 
 // handleTypingKey processes input during typing mode.
+// The bound keys come first; they can't be any of the fixed keys below (see
+// ValidateBinding), and keys that type are never taken by the keymap (see
+// isTypingKey).
 func (h *InputHandler) handleTypingKey(ev *tcell.EventKey) {
+	if !isTypingKey(ev) && h.handleGlobalKey(ev) {
+		return
+	}
+	if !isTypingKey(ev) && h.keymap.Matches(ActionRestart, ev) {
+		h.callbacks.OnRestartTest()
+		return
+	}
+	if !isTypingKey(ev) && h.keymap.Matches(ActionLimitType, ev) {
+		h.callbacks.OnToggleLimitType()
+		return
+	}
+
 	switch ev.Key() {
-	case tcell.KeyEscape, tcell.KeyCtrlC:
-		h.onQuit()
-	case tcell.KeyCtrlP:
-		h.onToggleCommandMenu()
-	case tcell.KeyCtrlT:
-		h.onCycleTheme()
-	case tcell.KeyBackspace, tcell.KeyBackspace2:
+	case tcell.KeyTab:
+		h.handleTypingTab()
+	case tcell.KeyCtrlY:
+		h.callbacks.OnToggleLastTheme()
+	case tcell.KeyCtrlSpace:
+		h.callbacks.OnTogglePause()
+	case tcell.KeyCtrlS:
+		// Not Ctrl+H, which many terminals send for Backspace
+		h.callbacks.OnToggleLiveStats()
+	case tcell.KeyBackspace, tcell.KeyBackspace2, tcell.KeyDelete:
+		// The caret is always at the end of the typed input, so Delete acts as Backspace
 		h.typingHandler.HandleBackspace()
+	case tcell.KeyCtrlU:
+		h.typingHandler.HandleClearWord()
+	case tcell.KeyHome:
+		h.callbacks.OnReviewScroll(false)
+	case tcell.KeyEnd:
+		h.callbacks.OnReviewScroll(true)
 	case tcell.KeyEnter:
 		h.typingHandler.HandleEnter()
 	case tcell.KeyRune:
@@ -79,34 +188,117 @@ func (h *InputHandler) handleTypingKey(ev *tcell.EventKey) {
 	}
 }
 
+// handlePausedKey processes input while the test is paused. Everything but
+// resuming and quitting is ignored, so nothing is typed during the pause.
+func (h *InputHandler) handlePausedKey(ev *tcell.EventKey) {
+	switch ev.Key() {
+	case tcell.KeyCtrlSpace:
+		h.callbacks.OnTogglePause()
+	case tcell.KeyCtrlC:
+		h.callbacks.OnQuit()
+	default:
+		if !isTypingKey(ev) && h.keymap.Matches(ActionQuit, ev) {
+			h.callbacks.OnQuit()
+		}
+	}
+}
+
+// handleGlobalKey handles the quit, command palette and theme keys of the
+// keymap and Ctrl+C. Returns false if the event is none of them.
+func (h *InputHandler) handleGlobalKey(ev *tcell.EventKey) bool {
+	switch {
+	case ev.Key() == tcell.KeyCtrlC, h.keymap.Matches(ActionQuit, ev):
+		h.callbacks.OnQuit()
+	case h.keymap.Matches(ActionCommandMenu, ev):
+		h.callbacks.OnToggleCommandMenu()
+	case h.keymap.Matches(ActionCycleTheme, ev):
+		h.callbacks.OnCycleTheme()
+	default:
+		return false
+	}
+	return true
+}
+
+// handleTypingTab types a tab or restarts the test, depending on the Tab key role.
+// In auto mode Tab only types when the next character of the text is a tab, so
+// texts with tabs stay typeable while Tab restarts everywhere else.
+func (h *InputHandler) handleTypingTab() {
+	switch h.tabKey {
+	case TabKeyType:
+		h.typingHandler.HandleRune('\t')
+	case TabKeyRestart:
+		h.callbacks.OnRestartTest()
+	default:
+		if h.typingHandler.ExpectsTab() {
+			h.typingHandler.HandleRune('\t')
+		} else {
+			h.callbacks.OnRestartTest()
+		}
+	}
+}
+
 // handleResultsKey processes input during results screen mode.
 func (h *InputHandler) handleResultsKey(ev *tcell.EventKey) {
+	if ev.Key() == tcell.KeyEnter && h.enterKey == ResultsEnterNext {
+		h.callbacks.OnNextTest()
+		return
+	}
+
 	switch ev.Key() {
-	case tcell.KeyEscape, tcell.KeyCtrlC:
-		h.onQuit()
-	case tcell.KeyCtrlP:
-		h.onToggleCommandMenu()
-	case tcell.KeyCtrlT:
-		h.onCycleTheme()
-	case tcell.KeyEnter, tcell.KeyRune:
-		if ev.Rune() == 'r' || ev.Key() == tcell.KeyEnter {
-			h.onRestartTest()
+	case tcell.KeyCtrlC:
+		h.callbacks.OnQuit()
+	case tcell.KeyCtrlY:
+		h.callbacks.OnToggleLastTheme()
+	default:
+		switch {
+		case h.keymap.Matches(ActionLimitType, ev):
+			h.callbacks.OnToggleLimitType()
+		case h.keymap.Matches(ActionCommandMenu, ev):
+			h.callbacks.OnToggleCommandMenu()
+		case h.keymap.Matches(ActionCycleTheme, ev):
+			h.callbacks.OnCycleTheme()
+		case h.keymap.Matches(ActionRestart, ev):
+			h.callbacks.OnRestartTest()
+		case h.keymap.Matches(ActionLineTimings, ev):
+			h.callbacks.OnToggleLineTimings()
+		case h.keymap.Matches(ActionNewWords, ev):
+			h.callbacks.OnRegenerateWords()
+		case h.keymap.Matches(ActionQuit, ev):
+			h.callbacks.OnQuit()
+		}
+	}
+}
+
+// handleLineTimingsKey processes input in the per-line timing view.
+func (h *InputHandler) handleLineTimingsKey(ev *tcell.EventKey) {
+	switch ev.Key() {
+	case tcell.KeyEscape, tcell.KeyEnter:
+		h.callbacks.OnToggleLineTimings()
+	case tcell.KeyCtrlC:
+		h.callbacks.OnQuit()
+	case tcell.KeyRune:
+		if ev.Rune() == 'l' {
+			h.callbacks.OnToggleLineTimings()
 		}
 	}
 }
 
 // handleCommandMenuKey processes input when command menu is visible.
 func (h *InputHandler) handleCommandMenuKey(ev *tcell.EventKey) {
+	if !isTypingKey(ev) && h.keymap.Matches(ActionCommandMenu, ev) {
+		h.callbacks.OnToggleCommandMenu()
+		return
+	}
 	switch ev.Key() {
-	case tcell.KeyEscape, tcell.KeyCtrlC, tcell.KeyCtrlP:
-		h.onToggleCommandMenu()
+	case tcell.KeyEscape, tcell.KeyCtrlC:
+		h.callbacks.OnToggleCommandMenu()
 	case tcell.KeyUp, tcell.KeyCtrlK:
 		h.commandMenuHandler.HandleMoveUp()
 	case tcell.KeyDown, tcell.KeyCtrlJ:
 		h.commandMenuHandler.HandleMoveDown()
 	case tcell.KeyEnter:
 		h.commandMenuHandler.HandleExecute()
-		h.onToggleCommandMenu() // Close menu after execution
+		h.callbacks.OnToggleCommandMenu() // Close menu after execution
 	case tcell.KeyBackspace, tcell.KeyBackspace2:
 		h.commandMenuHandler.HandleBackspace()
 	case tcell.KeyRune:
@@ -114,6 +306,105 @@ func (h *InputHandler) handleCommandMenuKey(ev *tcell.EventKey) {
 	}
 }
 
+// handleTextBrowserKey processes input when the text browser is visible.
+// Selection on Enter needs app context and is handled by the App.
+func (h *InputHandler) handleTextBrowserKey(ev *tcell.EventKey) {
+	switch ev.Key() {
+	case tcell.KeyEscape, tcell.KeyCtrlC:
+		h.textBrowserHandler.HandleClose()
+	case tcell.KeyUp, tcell.KeyCtrlK:
+		h.textBrowserHandler.HandleMoveUp()
+	case tcell.KeyDown, tcell.KeyCtrlJ:
+		h.textBrowserHandler.HandleMoveDown()
+	}
+}
+
+// handleReplayKey processes input while a test replay is running.
+func (h *InputHandler) handleReplayKey(ev *tcell.EventKey) {
+	switch ev.Key() {
+	case tcell.KeyEscape, tcell.KeyCtrlC:
+		h.callbacks.OnExitReplay()
+	case tcell.KeyRune:
+		switch ev.Rune() {
+		case ' ':
+			h.callbacks.OnToggleReplayPause()
+		case '+':
+			h.callbacks.OnChangeReplaySpeed(2)
+		case '-':
+			h.callbacks.OnChangeReplaySpeed(0.5)
+		}
+	}
+}
+
+// handleDiagnosticsKey processes input while the diagnostics overlay is visible.
+func (h *InputHandler) handleDiagnosticsKey(ev *tcell.EventKey) {
+	switch ev.Key() {
+	case tcell.KeyEscape, tcell.KeyCtrlC, tcell.KeyEnter:
+		h.callbacks.OnCloseDiagnostics()
+	}
+}
+
+// handleEmptyKey processes input on the empty-state screen, where there is
+// nothing to type: only quitting and the command menu and theme keys work.
+func (h *InputHandler) handleEmptyKey(ev *tcell.EventKey) {
+	if !isTypingKey(ev) && h.handleGlobalKey(ev) {
+		return
+	}
+	if ev.Key() == tcell.KeyCtrlY {
+		h.callbacks.OnToggleLastTheme()
+	}
+}
+
+// handleConfirmKey processes input while a confirmation is shown: 'y' confirms,
+// any other key declines. Ctrl+C still quits.
+func (h *InputHandler) handleConfirmKey(ev *tcell.EventKey) {
+	switch {
+	case ev.Key() == tcell.KeyCtrlC:
+		h.callbacks.OnQuit()
+	case ev.Key() == tcell.KeyRune && (ev.Rune() == 'y' || ev.Rune() == 'Y'):
+		h.callbacks.OnConfirm(true)
+	default:
+		h.callbacks.OnConfirm(false)
+	}
+}
+
+// handleResumePromptKey processes input while asking whether to resume the
+// saved session: 'y' resumes, 'n' or Esc starts fresh. Other keys are ignored
+// so stray typing doesn't decide. Ctrl+C still quits.
+func (h *InputHandler) handleResumePromptKey(ev *tcell.EventKey) {
+	switch {
+	case ev.Key() == tcell.KeyCtrlC:
+		h.callbacks.OnQuit()
+	case ev.Key() == tcell.KeyRune && (ev.Rune() == 'y' || ev.Rune() == 'Y'):
+		h.callbacks.OnAnswerResume(true)
+	case ev.Key() == tcell.KeyEscape || ev.Key() == tcell.KeyRune && (ev.Rune() == 'n' || ev.Rune() == 'N'):
+		h.callbacks.OnAnswerResume(false)
+	}
+}
+
+// handleLifetimeStatsKey processes input while the lifetime stats are visible.
+func (h *InputHandler) handleLifetimeStatsKey(ev *tcell.EventKey) {
+	switch ev.Key() {
+	case tcell.KeyEscape, tcell.KeyCtrlC, tcell.KeyEnter:
+		h.callbacks.OnCloseLifetime()
+	}
+}
+
+// handleProgressReportKey processes input while the progress report is visible.
+func (h *InputHandler) handleProgressReportKey(ev *tcell.EventKey) {
+	switch ev.Key() {
+	case tcell.KeyEscape, tcell.KeyCtrlC, tcell.KeyEnter:
+		h.callbacks.OnCloseReport()
+	case tcell.KeyRune:
+		switch ev.Rune() {
+		case 'd':
+			h.callbacks.OnSetReportPeriod(ReportByDay)
+		case 'w':
+			h.callbacks.OnSetReportPeriod(ReportByWeek)
+		}
+	}
+}
+
 // TypingInputHandler handles input during typing mode.
 type TypingInputHandler struct {
 	test *TypingTest
@@ -129,6 +420,13 @@ func (h *TypingInputHandler) HandleRune(r rune) {
 	h.test.TypeCharacter(r)
 }
 
+// ExpectsTab reports whether the next character to type is a tab.
+func (h *TypingInputHandler) ExpectsTab() bool {
+	sample := h.test.GetSampleRunes()
+	pos := h.test.GetCursorPos()
+	return pos < len(sample) && sample[pos] == '\t'
+}
+
 // HandleEnter handles the Enter key (newline).
 func (h *TypingInputHandler) HandleEnter() {
 	h.test.TypeNewline()
@@ -139,6 +437,11 @@ func (h *TypingInputHandler) HandleBackspace() {
 	h.test.Backspace()
 }
 
+// HandleClearWord handles Ctrl+U (clear the current word).
+func (h *TypingInputHandler) HandleClearWord() {
+	h.test.ClearCurrentWord()
+}
+
 // ResultsInputHandler handles input during results screen mode.
 type ResultsInputHandler struct{}
 
@@ -182,3 +485,28 @@ func (h *CommandMenuInputHandler) HandleBackspace() {
 func (h *CommandMenuInputHandler) HandleRune(r rune) {
 	h.menu.AddChar(r)
 }
+
+// TextBrowserInputHandler handles input when the text browser is visible.
+type TextBrowserInputHandler struct {
+	browser *TextBrowser
+}
+
+// NewTextBrowserInputHandler creates a new text browser input handler.
+func NewTextBrowserInputHandler(browser *TextBrowser) *TextBrowserInputHandler {
+	return &TextBrowserInputHandler{browser: browser}
+}
+
+// HandleMoveUp moves the highlight up in the text browser.
+func (h *TextBrowserInputHandler) HandleMoveUp() {
+	h.browser.MoveUp()
+}
+
+// HandleMoveDown moves the highlight down in the text browser.
+func (h *TextBrowserInputHandler) HandleMoveDown() {
+	h.browser.MoveDown()
+}
+
+// HandleClose closes the text browser without selecting.
+func (h *TextBrowserInputHandler) HandleClose() {
+	h.browser.Hide()
+}
@@ -0,0 +1,66 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestDeleteKeyRemovesLastCharacter(t *testing.T) {
+	test := NewTypingTest("hello")
+	handler := NewInputHandler(InputCallbacks{}, test, NewCommandMenu(), NewTextBrowser())
+
+	handler.HandleKey(tcell.NewEventKey(tcell.KeyRune, 'h', tcell.ModNone), ModeTyping)
+	handler.HandleKey(tcell.NewEventKey(tcell.KeyRune, 'x', tcell.ModNone), ModeTyping)
+	handler.HandleKey(tcell.NewEventKey(tcell.KeyDelete, 0, tcell.ModNone), ModeTyping)
+
+	if test.GetUserInput() != "h" {
+		t.Errorf("Expected Delete to remove the last character, got %q", test.GetUserInput())
+	}
+	if test.GetCursorPos() != 1 {
+		t.Errorf("Expected cursor at 1, got %d", test.GetCursorPos())
+	}
+}
+
+func TestCtrlUClearsCurrentWord(t *testing.T) {
+	test := NewTypingTest("hello world")
+	handler := NewInputHandler(InputCallbacks{}, test, NewCommandMenu(), NewTextBrowser())
+
+	for _, r := range "hello wo" {
+		handler.HandleKey(tcell.NewEventKey(tcell.KeyRune, r, tcell.ModNone), ModeTyping)
+	}
+	handler.HandleKey(tcell.NewEventKey(tcell.KeyCtrlU, 0, tcell.ModCtrl), ModeTyping)
+
+	if test.GetUserInput() != "hello " {
+		t.Errorf("Expected Ctrl+U to clear the current word, got %q", test.GetUserInput())
+	}
+}
+
+func TestTabKeyRole(t *testing.T) {
+	tests := []struct {
+		role        string
+		sample      string
+		wantRestart bool
+		wantInput   string
+	}{
+		{TabKeyAuto, "ab", true, ""},
+		{TabKeyAuto, "\tab", false, "\t"},
+		{TabKeyRestart, "\tab", true, ""},
+		{TabKeyType, "ab", false, "\t"},
+	}
+
+	for _, tt := range tests {
+		test := NewTypingTest(tt.sample)
+		restarted := false
+		handler := NewInputHandler(InputCallbacks{OnRestartTest: func() { restarted = true }},
+			test, NewCommandMenu(), NewTextBrowser())
+		handler.SetTabKey(tt.role)
+
+		handler.HandleKey(tcell.NewEventKey(tcell.KeyTab, '\t', tcell.ModNone), ModeTyping)
+
+		if restarted != tt.wantRestart || test.GetUserInput() != tt.wantInput {
+			t.Errorf("%s with %q: expected restart=%v input %q, got restart=%v input %q",
+				tt.role, tt.sample, tt.wantRestart, tt.wantInput, restarted, test.GetUserInput())
+		}
+	}
+}
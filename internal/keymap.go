@@ -0,0 +1,325 @@
+package internal
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// Actions that can be bound to keys. Quit, restart and the limit type work on
+// the typing and the results screen, the command palette and theme keys
+// everywhere, and the rest on the results screen only.
+const (
+	ActionRestart     = "restart"
+	ActionNewWords    = "new_words"
+	ActionLineTimings = "line_timings"
+	ActionQuit        = "quit"
+	ActionCommandMenu = "command_menu"
+	ActionCycleTheme  = "cycle_theme"
+	ActionLimitType   = "limit_type"
+)
+
+// typingScreenActions are the actions bound while typing. Their keys must not be
+// needed for typing (see isTypingKeyName).
+var typingScreenActions = []string{ActionCommandMenu, ActionCycleTheme, ActionLimitType}
+
+// resultsOnlyActions are the actions bound on the results screen only. All
+// others are also matched while typing (for keys that don't type).
+var resultsOnlyActions = []string{ActionNewWords, ActionLineTimings}
+
+// fixedTypingKeys are the keys with a fixed role while typing (see
+// InputHandler.handleTypingKey), which a binding matched while typing would
+// take over.
+var fixedTypingKeys = map[tcell.Key]string{
+	tcell.KeyTab:       "types a tab or restarts (see tab_key)",
+	tcell.KeyCtrlY:     "toggles the last theme",
+	tcell.KeyCtrlS:     "shows or hides the live stats",
+	tcell.KeyCtrlU:     "clears the word",
+	tcell.KeyCtrlSpace: "pauses the test",
+	tcell.KeyHome:      "scrolls to the start of the text",
+	tcell.KeyEnd:       "scrolls to the end of the text",
+	tcell.KeyDelete:    "deletes the last character",
+}
+
+// namedKeys maps key names usable in a keymap to their tcell keys.
+var namedKeys = map[string]tcell.Key{
+	"enter":     tcell.KeyEnter,
+	"esc":       tcell.KeyEscape,
+	"tab":       tcell.KeyTab,
+	"backspace": tcell.KeyBackspace2,
+}
+
+// terminalAliases are Ctrl keys that terminals send as the same code as another
+// key, so binding them would also bind that key.
+var terminalAliases = map[string]string{
+	"ctrl+h": "Backspace",
+	"ctrl+i": "Tab",
+	"ctrl+m": "Enter",
+}
+
+// Keymap binds actions to keys. Each action maps to a comma separated list of
+// keys: a single character like "r", a named key like "enter", "esc", "tab",
+// "backspace" or "space", "ctrl+" and a letter, or "f1" to "f12". While typing
+// only the keys that don't type are used, so e.g. "enter,r,f5" restarts with F5
+// there. Ctrl+C always quits.
+type Keymap map[string]string
+
+// DefaultKeymap returns the built-in bindings.
+func DefaultKeymap() Keymap {
+	return Keymap{
+		ActionRestart:     "enter,r",
+		ActionNewWords:    "n",
+		ActionLineTimings: "l",
+		ActionQuit:        "esc",
+		ActionCommandMenu: "ctrl+p",
+		ActionCycleTheme:  "ctrl+t",
+		ActionLimitType:   "ctrl+l",
+	}
+}
+
+// WithOverrides returns the keymap with the given bindings replacing the
+// defaults of their actions. Unknown actions are ignored.
+func (k Keymap) WithOverrides(overrides map[string]string) Keymap {
+	merged := make(Keymap, len(k))
+	for action, keys := range k {
+		merged[action] = keys
+	}
+	for action, keys := range overrides {
+		if _, ok := merged[action]; ok {
+			merged[action] = keys
+		}
+	}
+	return merged
+}
+
+// Matches reports whether the event is one of the keys bound to the action.
+func (k Keymap) Matches(action string, ev *tcell.EventKey) bool {
+	for _, name := range keyNames(k[action]) {
+		key, r, err := parseKeyName(name)
+		if err != nil {
+			continue
+		}
+		if ev.Key() == key && (key != tcell.KeyRune || ev.Rune() == r) {
+			return true
+		}
+	}
+	return false
+}
+
+// Label returns how the keys bound to the action are shown in help texts,
+// e.g. "Enter or 'r'".
+func (k Keymap) Label(action string) string {
+	return strings.Join(keyLabels(keyNames(k[action])), " or ")
+}
+
+// typingLabel returns the keys bound to the action that work while typing,
+// joined by "/", e.g. "Esc/F10". It is empty if all of them type.
+func (k Keymap) typingLabel(action string) string {
+	var names []string
+	for _, name := range keyNames(k[action]) {
+		if !isTypingKeyName(name) {
+			names = append(names, name)
+		}
+	}
+	return strings.Join(keyLabels(names), "/")
+}
+
+// keyLabels returns how key names are shown: characters quoted, named keys
+// capitalized, e.g. "'r'", "Enter", "Ctrl+P" and "F2".
+func keyLabels(names []string) []string {
+	labels := make([]string, len(names))
+	for i, name := range names {
+		switch {
+		case strings.HasPrefix(name, "ctrl+"):
+			labels[i] = "Ctrl+" + strings.ToUpper(strings.TrimPrefix(name, "ctrl+"))
+		case utf8.RuneCountInString(name) > 1:
+			labels[i] = strings.ToUpper(name[:1]) + name[1:]
+		default:
+			labels[i] = "'" + name + "'"
+		}
+	}
+	return labels
+}
+
+// TypingHelp returns the help text of the typing screen for the bound keys.
+// The limit type key is only listed in word mode, where the limit applies.
+func (k Keymap) TypingHelp(wordMode bool) string {
+	parts := []string{
+		joinLabels(k.typingLabel(ActionQuit), "Ctrl+C") + ": quit",
+		k.typingLabel(ActionCommandMenu) + ": command menu",
+		k.typingLabel(ActionCycleTheme) + ": change theme",
+	}
+	if wordMode {
+		parts = append(parts, k.typingLabel(ActionLimitType)+": time/words limit")
+	}
+	return strings.Join(parts, "  |  ")
+}
+
+// EmptyStateHelp returns the help text of the empty-state screen for the bound keys.
+func (k Keymap) EmptyStateHelp() string {
+	return joinLabels(k.typingLabel(ActionQuit), "Ctrl+C") + ": quit  |  " +
+		k.typingLabel(ActionCommandMenu) + ": command menu"
+}
+
+// joinLabels joins the non-empty key labels with "/".
+func joinLabels(labels ...string) string {
+	return strings.Join(slices.DeleteFunc(labels, func(label string) bool { return label == "" }), "/")
+}
+
+// ResultsFooter returns the results screen help text for the bound keys.
+// The new words action is only listed in word mode. With enterNext, Enter is
+// listed as moving on to the next test instead of with its bound action.
+func (k Keymap) ResultsFooter(wordMode, enterNext bool) string {
+	var parts []string
+	if enterNext {
+		parts = append(parts, "Enter: next")
+		k = k.withoutKey("enter")
+	}
+	if restart := k.Label(ActionRestart); restart != "" {
+		parts = append(parts, restart+": restart")
+	}
+	if wordMode {
+		parts = append(parts, k.Label(ActionNewWords)+": new words")
+	}
+	parts = append(parts,
+		k.Label(ActionLineTimings)+": line timing",
+		k.Label(ActionQuit)+": quit",
+	)
+	return strings.Join(parts, "  |  ")
+}
+
+// withoutKey returns the keymap with the named key removed from every action.
+func (k Keymap) withoutKey(key string) Keymap {
+	stripped := make(Keymap, len(k))
+	for action, keys := range k {
+		var kept []string
+		for _, name := range keyNames(keys) {
+			if name != key {
+				kept = append(kept, name)
+			}
+		}
+		stripped[action] = strings.Join(kept, ",")
+	}
+	return stripped
+}
+
+// ValidateKeyList checks a comma separated list of keys as used in a Keymap.
+func ValidateKeyList(keys string) error {
+	names := keyNames(keys)
+	if len(names) == 0 {
+		return fmt.Errorf("expected at least one key")
+	}
+	for _, name := range names {
+		if _, _, err := parseKeyName(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateBinding checks the keys bound to an action. Besides ValidateKeyList,
+// actions matched while typing may not be bound to the fixed typing keys, and
+// actions used while typing not to keys that type.
+func ValidateBinding(action, keys string) error {
+	if err := ValidateKeyList(keys); err != nil {
+		return err
+	}
+	if slices.Contains(resultsOnlyActions, action) {
+		return nil
+	}
+	for _, name := range keyNames(keys) {
+		key, _, _ := parseKeyName(name)
+		if role, ok := fixedTypingKeys[key]; ok {
+			return fmt.Errorf("key %q %s while typing", name, role)
+		}
+		if slices.Contains(typingScreenActions, action) && isTypingKeyName(name) {
+			return fmt.Errorf("key %q is needed for typing", name)
+		}
+	}
+	return nil
+}
+
+// dropInvalidBindings removes the bindings ValidateBinding rejects from
+// overrides, so their actions keep the default keys, and returns an error
+// message for each, sorted by action.
+func dropInvalidBindings(overrides map[string]string) []string {
+	var problems []string
+	for _, action := range slices.Sorted(maps.Keys(overrides)) {
+		if err := ValidateBinding(action, overrides[action]); err != nil {
+			problems = append(problems, fmt.Sprintf("key binding %s: %v", action, err))
+			delete(overrides, action)
+		}
+	}
+	return problems
+}
+
+// parseKeyName returns the tcell key of a key name, and the character for
+// KeyRune. Ctrl keys that terminals send as Backspace, Tab or Enter are
+// rejected.
+func parseKeyName(name string) (tcell.Key, rune, error) {
+	if name == "space" {
+		return tcell.KeyRune, ' ', nil
+	}
+	if utf8.RuneCountInString(name) == 1 {
+		r, _ := utf8.DecodeRuneInString(name)
+		return tcell.KeyRune, r, nil
+	}
+	if key, ok := namedKeys[name]; ok {
+		return key, 0, nil
+	}
+	if alias, ok := terminalAliases[name]; ok {
+		return 0, 0, fmt.Errorf("key %q can't be bound, terminals send it as %s", name, alias)
+	}
+	if letter, ok := strings.CutPrefix(name, "ctrl+"); ok {
+		if len(letter) != 1 || letter[0] < 'a' || letter[0] > 'z' {
+			return 0, 0, fmt.Errorf("unknown key %q, expected ctrl+ and a letter", name)
+		}
+		return tcell.KeyCtrlA + tcell.Key(letter[0]-'a'), 0, nil
+	}
+	if number, ok := strings.CutPrefix(name, "f"); ok {
+		n, err := strconv.Atoi(number)
+		if err != nil || n < 1 || n > 12 {
+			return 0, 0, fmt.Errorf("unknown key %q, expected f1 to f12", name)
+		}
+		return tcell.KeyF1 + tcell.Key(n-1), 0, nil
+	}
+	return 0, 0, fmt.Errorf("unknown key %q", name)
+}
+
+// isTypingKeyName reports whether the key types or edits text on the typing
+// screen, so it can't trigger actions there.
+func isTypingKeyName(name string) bool {
+	key, _, err := parseKeyName(name)
+	return err == nil && (key == tcell.KeyRune || key == tcell.KeyEnter || key == tcell.KeyBackspace2)
+}
+
+// isTypingKey reports whether the event types or edits text on the typing screen.
+func isTypingKey(ev *tcell.EventKey) bool {
+	switch ev.Key() {
+	case tcell.KeyRune, tcell.KeyEnter, tcell.KeyBackspace, tcell.KeyBackspace2:
+		return true
+	}
+	return false
+}
+
+// keyNames splits a key list into trimmed names. Named keys are lowercased;
+// single characters keep their case.
+func keyNames(keys string) []string {
+	var names []string
+	for _, name := range strings.Split(keys, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if utf8.RuneCountInString(name) > 1 {
+			name = strings.ToLower(name)
+		}
+		names = append(names, name)
+	}
+	return names
+}
@@ -0,0 +1,195 @@
+package internal
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestResultsFooterFollowsKeymap(t *testing.T) {
+	footer := DefaultKeymap().ResultsFooter(false, false)
+	if !strings.Contains(footer, "Enter or 'r': restart") || !strings.Contains(footer, "Esc: quit") {
+		t.Errorf("Expected the default keys in the footer, got %q", footer)
+	}
+	if strings.Contains(footer, "new words") {
+		t.Errorf("Expected no new words hint outside word mode, got %q", footer)
+	}
+
+	remapped := DefaultKeymap().WithOverrides(map[string]string{ActionRestart: "x"})
+	footer = remapped.ResultsFooter(true, false)
+	if !strings.Contains(footer, "'x': restart") || strings.Contains(footer, "'r'") {
+		t.Errorf("Expected the remapped restart key in the footer, got %q", footer)
+	}
+	if !strings.Contains(footer, "'n': new words") {
+		t.Errorf("Expected the new words hint in word mode, got %q", footer)
+	}
+}
+
+func TestResultsFooterEnterNext(t *testing.T) {
+	footer := DefaultKeymap().ResultsFooter(false, true)
+	if !strings.HasPrefix(footer, "Enter: next  |  'r': restart") {
+		t.Errorf("Expected Enter listed as next and 'r' as restart, got %q", footer)
+	}
+}
+
+func TestResultsEnterAction(t *testing.T) {
+	for _, tc := range []struct {
+		action                  string
+		wantRestarts, wantNexts int
+	}{
+		{ResultsEnterRestart, 2, 0},
+		{ResultsEnterNext, 1, 1},
+	} {
+		restarts, nexts := 0, 0
+		handler := NewInputHandler(InputCallbacks{
+			OnRestartTest: func() { restarts++ },
+			OnNextTest:    func() { nexts++ },
+		}, NewTypingTest("ab"), NewCommandMenu(), NewTextBrowser())
+		handler.SetResultsEnterAction(tc.action)
+
+		handler.HandleKey(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), ModeResults)
+		handler.HandleKey(tcell.NewEventKey(tcell.KeyRune, 'r', tcell.ModNone), ModeResults)
+		if restarts != tc.wantRestarts || nexts != tc.wantNexts {
+			t.Errorf("%s: got %d restarts and %d nexts, want %d and %d",
+				tc.action, restarts, nexts, tc.wantRestarts, tc.wantNexts)
+		}
+	}
+}
+
+func TestRemappedRestartKey(t *testing.T) {
+	restarts := 0
+	handler := NewInputHandler(InputCallbacks{OnRestartTest: func() { restarts++ }},
+		NewTypingTest("ab"), NewCommandMenu(), NewTextBrowser())
+	handler.SetKeymap(DefaultKeymap().WithOverrides(map[string]string{ActionRestart: "x,space"}))
+
+	handler.HandleKey(tcell.NewEventKey(tcell.KeyRune, 'r', tcell.ModNone), ModeResults)
+	handler.HandleKey(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), ModeResults)
+	if restarts != 0 {
+		t.Fatalf("Expected the replaced keys not to restart, got %d restarts", restarts)
+	}
+
+	handler.HandleKey(tcell.NewEventKey(tcell.KeyRune, 'x', tcell.ModNone), ModeResults)
+	handler.HandleKey(tcell.NewEventKey(tcell.KeyRune, ' ', tcell.ModNone), ModeResults)
+	if restarts != 2 {
+		t.Errorf("Expected 'x' and space to restart, got %d restarts", restarts)
+	}
+}
+
+func TestValidateKeyList(t *testing.T) {
+	for _, keys := range []string{"r", "enter,r", "Esc", "space, q", "ctrl+r,f5"} {
+		if err := ValidateKeyList(keys); err != nil {
+			t.Errorf("Expected %q to be valid, got %v", keys, err)
+		}
+	}
+	for _, keys := range []string{"", "ctrl+h", "ctrl+", "rr"} {
+		if err := ValidateKeyList(keys); err == nil {
+			t.Errorf("Expected %q to be rejected", keys)
+		}
+	}
+}
+
+func TestValidateBindingRejectsTypingAndAliasedKeys(t *testing.T) {
+	for _, keys := range []string{"ctrl+o", "F2", "f12", "esc", "Ctrl+P"} {
+		if err := ValidateBinding(ActionCommandMenu, keys); err != nil {
+			t.Errorf("Expected %q to be bindable to the command menu, got %v", keys, err)
+		}
+	}
+	for _, keys := range []string{"x", "space", "enter", "backspace", "ctrl+h", "ctrl+i", "ctrl+m", "ctrl+1", "f13", "alt+o"} {
+		if err := ValidateBinding(ActionCommandMenu, keys); err == nil {
+			t.Errorf("Expected %q to be rejected for the command menu", keys)
+		}
+	}
+
+	// Restart and quit may use typing keys, which only work on the results screen
+	if err := ValidateBinding(ActionRestart, "enter,r,f5"); err != nil {
+		t.Errorf("Expected typing keys to be bindable to restart, got %v", err)
+	}
+	if err := ValidateBinding(ActionQuit, "ctrl+m"); err == nil {
+		t.Error("Expected Ctrl+M to be rejected, terminals send it as Enter")
+	}
+
+	// Keys with a fixed role while typing would be taken over by the binding
+	for _, action := range []string{ActionCommandMenu, ActionCycleTheme, ActionLimitType, ActionRestart, ActionQuit} {
+		for _, keys := range []string{"ctrl+u", "ctrl+s", "ctrl+y", "f5,tab"} {
+			if err := ValidateBinding(action, keys); err == nil {
+				t.Errorf("Expected %q to be rejected for %s", keys, action)
+			}
+		}
+	}
+	if err := ValidateBinding(ActionLineTimings, "ctrl+u"); err != nil {
+		t.Errorf("Expected results screen actions to take typing keys, got %v", err)
+	}
+}
+
+func TestRemappedTypingKeys(t *testing.T) {
+	menus, restarts, quits := 0, 0, 0
+	test := NewTypingTest("a\tb")
+	handler := NewInputHandler(InputCallbacks{
+		OnToggleCommandMenu: func() { menus++ },
+		OnRestartTest:       func() { restarts++ },
+		OnQuit:              func() { quits++ },
+	}, test, NewCommandMenu(), NewTextBrowser())
+	handler.SetKeymap(DefaultKeymap().WithOverrides(map[string]string{
+		ActionCommandMenu: "ctrl+o",
+		ActionRestart:     "enter,r,f5",
+		ActionQuit:        "f10,q",
+	}))
+
+	handler.HandleKey(tcell.NewEventKey(tcell.KeyCtrlP, 0, tcell.ModCtrl), ModeTyping)
+	handler.HandleKey(tcell.NewEventKey(tcell.KeyCtrlO, 0, tcell.ModCtrl), ModeTyping)
+	if menus != 1 {
+		t.Errorf("Expected only Ctrl+O to open the command palette, got %d opens", menus)
+	}
+
+	// Keys that type keep typing; F5 restarts
+	handler.HandleKey(tcell.NewEventKey(tcell.KeyRune, 'a', tcell.ModNone), ModeTyping)
+	handler.HandleKey(tcell.NewEventKey(tcell.KeyTab, '\t', tcell.ModNone), ModeTyping)
+	handler.HandleKey(tcell.NewEventKey(tcell.KeyRune, 'q', tcell.ModNone), ModeTyping)
+	if test.GetUserInput() != "a\tq" || restarts != 0 || quits != 0 {
+		t.Errorf("Expected typing keys to type, got input %q, %d restarts and %d quits", test.GetUserInput(), restarts, quits)
+	}
+	handler.HandleKey(tcell.NewEventKey(tcell.KeyF5, 0, tcell.ModNone), ModeTyping)
+	if restarts != 1 {
+		t.Errorf("Expected F5 to restart, got %d restarts", restarts)
+	}
+
+	// The same quit binding works on the typing and the results screen
+	handler.HandleKey(tcell.NewEventKey(tcell.KeyF10, 0, tcell.ModNone), ModeTyping)
+	handler.HandleKey(tcell.NewEventKey(tcell.KeyRune, 'q', tcell.ModNone), ModeResults)
+	handler.HandleKey(tcell.NewEventKey(tcell.KeyEscape, 0, tcell.ModNone), ModeResults)
+	if quits != 2 {
+		t.Errorf("Expected F10 and 'q' but not Esc to quit, got %d quits", quits)
+	}
+}
+
+func TestHelpTextsFollowKeymap(t *testing.T) {
+	if help := DefaultKeymap().TypingHelp(false); help != "Esc/Ctrl+C: quit  |  Ctrl+P: command menu  |  Ctrl+T: change theme" {
+		t.Errorf("Unexpected default help %q", help)
+	}
+
+	remapped := DefaultKeymap().WithOverrides(map[string]string{ActionQuit: "q,f10", ActionCommandMenu: "ctrl+o"})
+	if help := remapped.TypingHelp(false); help != "F10/Ctrl+C: quit  |  Ctrl+O: command menu  |  Ctrl+T: change theme" {
+		t.Errorf("Expected the remapped keys that work while typing, got %q", help)
+	}
+	if help := DefaultKeymap().WithOverrides(map[string]string{ActionLimitType: "f3"}).TypingHelp(true); !strings.HasSuffix(help, "  |  F3: time/words limit") {
+		t.Errorf("Expected the limit type key in word mode, got %q", help)
+	}
+	if help := remapped.EmptyStateHelp(); help != "F10/Ctrl+C: quit  |  Ctrl+O: command menu" {
+		t.Errorf("Unexpected empty state help %q", help)
+	}
+}
+
+func TestRemappedLimitTypeKey(t *testing.T) {
+	toggles := 0
+	handler := NewInputHandler(InputCallbacks{OnToggleLimitType: func() { toggles++ }},
+		NewTypingTest("ab"), NewCommandMenu(), NewTextBrowser())
+	handler.SetKeymap(DefaultKeymap().WithOverrides(map[string]string{ActionLimitType: "f3"}))
+
+	handler.HandleKey(tcell.NewEventKey(tcell.KeyCtrlL, 0, tcell.ModCtrl), ModeTyping)
+	handler.HandleKey(tcell.NewEventKey(tcell.KeyF3, 0, tcell.ModNone), ModeTyping)
+	handler.HandleKey(tcell.NewEventKey(tcell.KeyF3, 0, tcell.ModNone), ModeResults)
+	if toggles != 2 {
+		t.Errorf("Expected only F3 to switch the limit type, got %d switches", toggles)
+	}
+}
@@ -0,0 +1,113 @@
+package internal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// LifetimeStats accumulates how much has been typed across all completed tests.
+type LifetimeStats struct {
+	Keystrokes     int           `json:"keystrokes"`      // All keys typed, including mistakes
+	Words          int           `json:"words"`           // Words typed
+	TypingTime     time.Duration `json:"typing_time"`     // Time spent in tests
+	TestsCompleted int           `json:"tests_completed"` // Number of finished tests
+}
+
+// Add returns the sum of both stats.
+func (l LifetimeStats) Add(other LifetimeStats) LifetimeStats {
+	return LifetimeStats{
+		Keystrokes:     l.Keystrokes + other.Keystrokes,
+		Words:          l.Words + other.Words,
+		TypingTime:     l.TypingTime + other.TypingTime,
+		TestsCompleted: l.TestsCompleted + other.TestsCompleted,
+	}
+}
+
+// lifetimeMu serializes read-modify-write cycles of lifetime.json within the
+// process; lockFile does so across processes.
+var lifetimeMu sync.Mutex
+
+// LoadLifetimeStats reads the lifetime stats. A missing file yields zero stats.
+// If the file is corrupt, the backup kept by the last save is used instead; if
+// that is unusable too, zero stats are returned along with an error.
+func LoadLifetimeStats() (LifetimeStats, error) {
+	lifetimeMu.Lock()
+	defer lifetimeMu.Unlock()
+	return loadLifetimeStats()
+}
+
+// lockLifetimeStats locks lifetime.json against other processes and returns
+// its path along with the function that releases the lock.
+func lockLifetimeStats() (string, func(), error) {
+	path, err := GetLifetimeStatsPath()
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to resolve lifetime stats path: %w", err)
+	}
+	unlock, err := lockFile(path)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to lock lifetime stats: %w", err)
+	}
+	return path, unlock, nil
+}
+
+// RecordLifetimeStats adds the stats of a completed test to the lifetime totals.
+// A corrupt file is recovered as described in LoadLifetimeStats and then
+// overwritten with the new totals.
+func RecordLifetimeStats(test LifetimeStats) error {
+	lifetimeMu.Lock()
+	defer lifetimeMu.Unlock()
+
+	// Another instance may record a test at the same time
+	path, unlock, err := lockLifetimeStats()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	total, loadErr := loadLifetimeStats()
+	data, err := json.MarshalIndent(total.Add(test), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal lifetime stats: %w", err)
+	}
+	if err := writeFileAtomic(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to save lifetime stats: %w", err)
+	}
+	return loadErr
+}
+
+// loadLifetimeStats implements LoadLifetimeStats; the caller must hold lifetimeMu.
+func loadLifetimeStats() (LifetimeStats, error) {
+	path, err := GetLifetimeStatsPath()
+	if err != nil {
+		return LifetimeStats{}, fmt.Errorf("failed to resolve lifetime stats path: %w", err)
+	}
+
+	stats, err := readLifetimeStats(path)
+	if err == nil || errors.Is(err, os.ErrNotExist) {
+		return stats, nil
+	}
+
+	backup, backupErr := readLifetimeStats(path + ".bak")
+	if backupErr == nil {
+		return backup, fmt.Errorf("lifetime stats corrupt, restored from backup: %w", err)
+	}
+	return LifetimeStats{}, fmt.Errorf("lifetime stats corrupt, starting over: %w", err)
+}
+
+// readLifetimeStats reads and parses one lifetime stats file.
+func readLifetimeStats(path string) (LifetimeStats, error) {
+	var stats LifetimeStats
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return stats, err
+	}
+	if err := json.Unmarshal(data, &stats); err != nil {
+		return LifetimeStats{}, err
+	}
+	return stats, nil
+}
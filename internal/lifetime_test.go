@@ -0,0 +1,87 @@
+package internal
+
+import (
+	"os"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRecordLifetimeStatsAccumulates(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	if stats, err := LoadLifetimeStats(); err != nil || stats != (LifetimeStats{}) {
+		t.Fatalf("Expected zero stats without a file, got %+v (err %v)", stats, err)
+	}
+
+	tests := []LifetimeStats{
+		{Keystrokes: 120, Words: 20, TypingTime: 30 * time.Second, TestsCompleted: 1},
+		{Keystrokes: 300, Words: 55, TypingTime: time.Minute, TestsCompleted: 1},
+		{Keystrokes: 80, Words: 15, TypingTime: 20 * time.Second, TestsCompleted: 1},
+	}
+	for _, test := range tests {
+		if err := RecordLifetimeStats(test); err != nil {
+			t.Fatalf("failed to record: %v", err)
+		}
+	}
+
+	got, err := LoadLifetimeStats()
+	if err != nil {
+		t.Fatalf("failed to load: %v", err)
+	}
+	want := LifetimeStats{Keystrokes: 500, Words: 90, TypingTime: 110 * time.Second, TestsCompleted: 3}
+	if got != want {
+		t.Errorf("Expected %+v, got %+v", want, got)
+	}
+}
+
+func TestRecordLifetimeStatsConcurrent(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	var wg sync.WaitGroup
+	for range 20 {
+		wg.Go(func() {
+			if err := RecordLifetimeStats(LifetimeStats{Keystrokes: 10, TestsCompleted: 1}); err != nil {
+				t.Errorf("failed to record: %v", err)
+			}
+		})
+	}
+	wg.Wait()
+
+	got, _ := LoadLifetimeStats()
+	if got.TestsCompleted != 20 || got.Keystrokes != 200 {
+		t.Errorf("Expected no lost increments, got %+v", got)
+	}
+}
+
+func TestLifetimeStatsCorruptRecovery(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	path, err := GetLifetimeStatsPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Two saves leave the first totals in the backup
+	_ = RecordLifetimeStats(LifetimeStats{Words: 10, TestsCompleted: 1})
+	_ = RecordLifetimeStats(LifetimeStats{Words: 5, TestsCompleted: 1})
+
+	if err := os.WriteFile(path, []byte("{not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	stats, err := LoadLifetimeStats()
+	if err == nil || stats.Words != 10 {
+		t.Errorf("Expected backup restored with an error, got %+v (err %v)", stats, err)
+	}
+
+	// Without a usable backup, counting starts over
+	if err := os.WriteFile(path+".bak", []byte("garbage"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := RecordLifetimeStats(LifetimeStats{Words: 7, TestsCompleted: 1}); err == nil {
+		t.Error("Expected the corruption to be reported")
+	}
+	stats, err = LoadLifetimeStats()
+	if err != nil || stats.Words != 7 || stats.TestsCompleted != 1 {
+		t.Errorf("Expected a fresh count after corruption, got %+v (err %v)", stats, err)
+	}
+}
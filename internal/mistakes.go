@@ -0,0 +1,72 @@
+package internal
+
+import (
+	"time"
+	"unicode"
+)
+
+const (
+	// maxMistakes caps the recorded mistakes per test, like maxKeystrokeLogEntries.
+	maxMistakes = 10000
+
+	// capsLockRunLength is how many consecutive case-only mistakes suggest Caps Lock.
+	capsLockRunLength = 4
+)
+
+// Character categories for the per-category accuracy on the results screen.
+const (
+	CategoryLetters = "Letters"
+	CategoryDigits  = "Digits"
+	CategoryPunct   = "Punct" // Punctuation and symbols
+)
+
+// CharCategories lists the character categories in display order.
+var CharCategories = []string{CategoryLetters, CategoryDigits, CategoryPunct}
+
+// CharCategory returns the category of a character: CategoryLetters,
+// CategoryDigits or CategoryPunct. Whitespace and other characters have no
+// category ("").
+func CharCategory(r rune) string {
+	switch {
+	case unicode.IsLetter(r):
+		return CategoryLetters
+	case unicode.IsDigit(r):
+		return CategoryDigits
+	case unicode.IsPunct(r) || unicode.IsSymbol(r):
+		return CategoryPunct
+	}
+	return ""
+}
+
+// Mistake is a single incorrectly typed character.
+type Mistake struct {
+	Timestamp time.Time // When the wrong key was pressed
+	Position  int       // Index in the sample text (in runes)
+	Expected  rune      // Character in the sample text (0 for letters typed past the end of a word)
+	Typed     rune      // Character the user typed
+}
+
+// IsCaseInversion reports whether the mistake was the right letter in the wrong case.
+func (m Mistake) IsCaseInversion() bool {
+	return m.Typed != m.Expected &&
+		unicode.IsLetter(m.Expected) &&
+		unicode.ToLower(m.Typed) == unicode.ToLower(m.Expected)
+}
+
+// DetectCapsLockPattern reports whether the mistakes contain a run of at least
+// capsLockRunLength consecutive case inversions, which usually means Caps Lock
+// was on. Occasional shift slips don't form such a run.
+func DetectCapsLockPattern(mistakes []Mistake) bool {
+	run := 0
+	for _, mistake := range mistakes {
+		if !mistake.IsCaseInversion() {
+			run = 0
+			continue
+		}
+		run++
+		if run >= capsLockRunLength {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,128 @@
+package internal
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDetectCapsLockPatternAllUppercase(t *testing.T) {
+	const sample = "the quick brown fox"
+	test := NewTypingTest(sample)
+	typeString(test, strings.ToUpper(sample))
+
+	if !DetectCapsLockPattern(test.GetStats().GetMistakes()) {
+		t.Error("Expected text typed with Caps Lock to trigger the hint")
+	}
+}
+
+func TestDetectCapsLockPatternShiftedSample(t *testing.T) {
+	// With Caps Lock on, capitals come out lowercase and vice versa
+	test := NewTypingTest("Hello World")
+	typeString(test, "hELLO wORLD")
+
+	if !DetectCapsLockPattern(test.GetStats().GetMistakes()) {
+		t.Error("Expected inverted case to trigger the hint")
+	}
+}
+
+func TestDetectCapsLockPatternNormalTyping(t *testing.T) {
+	tests := []struct {
+		name  string
+		typed string
+	}{
+		{"no mistakes", "the quick brown fox"},
+		{"ordinary typos", "teh quikc brwon fox"},
+		{"occasional shift slips", "The quick Brown fox"},
+		{"case mistakes broken up by typos", "THx qUIxK"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test := NewTypingTest("the quick brown fox")
+			typeString(test, tt.typed)
+
+			if DetectCapsLockPattern(test.GetStats().GetMistakes()) {
+				t.Errorf("Expected no Caps Lock hint for %q, mistakes: %v", tt.typed, test.GetStats().GetMistakes())
+			}
+		})
+	}
+}
+
+func TestRecordMistake(t *testing.T) {
+	test := NewTypingTest("ab")
+	typeString(test, "aX")
+
+	mistakes := test.GetStats().GetMistakes()
+	if len(mistakes) != 1 {
+		t.Fatalf("Expected 1 mistake, got %v", mistakes)
+	}
+	if m := mistakes[0]; m.Position != 1 || m.Expected != 'b' || m.Typed != 'X' || m.Timestamp.IsZero() {
+		t.Errorf("Unexpected mistake %+v", m)
+	}
+}
+
+func TestCharCategory(t *testing.T) {
+	tests := []struct {
+		char rune
+		want string
+	}{
+		{'a', CategoryLetters},
+		{'Z', CategoryLetters},
+		{'ß', CategoryLetters},
+		{'7', CategoryDigits},
+		{',', CategoryPunct},
+		{'(', CategoryPunct},
+		{'+', CategoryPunct},
+		{'$', CategoryPunct},
+		{' ', ""},
+		{'\n', ""},
+		{0, ""},
+	}
+	for _, tt := range tests {
+		if got := CharCategory(tt.char); got != tt.want {
+			t.Errorf("CharCategory(%q) = %q, want %q", tt.char, got, tt.want)
+		}
+	}
+}
+
+func TestCategoryAccuracy(t *testing.T) {
+	test := NewTypingTest("ab12, cd!")
+	typeString(test, "ab13, cX?")
+
+	got := test.GetStats().GetCategoryAccuracy()
+	want := map[string]float64{
+		CategoryLetters: 75, // d typed as X
+		CategoryDigits:  50, // 2 typed as 3
+		CategoryPunct:   50, // ! typed as ?
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Expected accuracy for %d categories, got %v", len(want), got)
+	}
+	for category, accuracy := range want {
+		if got[category] != accuracy {
+			t.Errorf("%s: expected %.0f%%, got %.1f%%", category, accuracy, got[category])
+		}
+	}
+
+	if got := NewTypingTest("abc").GetStats().GetCategoryAccuracy(); len(got) != 0 {
+		t.Errorf("Expected no categories before typing, got %v", got)
+	}
+}
+
+func TestCategoryAccuracyCountsMistakesPastCap(t *testing.T) {
+	stats := NewStats()
+	for i := range maxMistakes * 2 {
+		stats.RecordExpectedChar('a')
+		stats.RecordMistake(i, 'a', 'b')
+	}
+	for range maxMistakes * 2 {
+		stats.RecordExpectedChar('a')
+	}
+
+	if got := len(stats.GetMistakes()); got != maxMistakes {
+		t.Errorf("Expected %d recorded mistakes, got %d", maxMistakes, got)
+	}
+	if got := stats.GetCategoryAccuracy()[CategoryLetters]; got != 50 {
+		t.Errorf("Expected 50%% letter accuracy, got %.1f%%", got)
+	}
+}
@@ -214,3 +214,43 @@ func GetLeaderboardPath() (string, error) {
 
 	return filepath.Join(configDir, "leaderboard.json"), nil
 }
+
+// GetResultsHistoryPath returns the path to the results history file.
+func GetResultsHistoryPath() (string, error) {
+	configDir, err := GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(configDir, "results.jsonl"), nil
+}
+
+// GetLifetimeStatsPath returns the path to the lifetime stats file.
+func GetLifetimeStatsPath() (string, error) {
+	configDir, err := GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(configDir, "lifetime.json"), nil
+}
+
+// GetProfilePath returns the path to the XP profile file.
+func GetProfilePath() (string, error) {
+	configDir, err := GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(configDir, "profile.json"), nil
+}
+
+// GetAchievementsPath returns the path to the unlocked achievements file.
+func GetAchievementsPath() (string, error) {
+	configDir, err := GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(configDir, "achievements.json"), nil
+}
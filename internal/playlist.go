@@ -0,0 +1,31 @@
+package internal
+
+// PlaylistStep returns the index of the playlist entry step positions away from
+// current (1 for next, -1 for previous), wrapping around at either end. Entries
+// for which exists returns false (e.g. deleted texts) are skipped.
+//
+// A current index outside the playlist means no entry has been played yet, so
+// the next entry is the first one and the previous entry is the last one.
+//
+// Returns -1 if the playlist has no existing entries.
+func PlaylistStep(playlist []string, current, step int, exists func(name string) bool) int {
+	n := len(playlist)
+	if n == 0 || step == 0 {
+		return -1
+	}
+	if current < 0 || current >= n {
+		if step > 0 {
+			current = -1
+		} else {
+			current = n
+		}
+	}
+
+	for i := 1; i <= n; i++ {
+		idx := ((current+step*i)%n + n) % n
+		if exists(playlist[idx]) {
+			return idx
+		}
+	}
+	return -1
+}
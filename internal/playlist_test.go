@@ -0,0 +1,57 @@
+package internal
+
+import "testing"
+
+func TestPlaylistStep(t *testing.T) {
+	playlist := []string{"a", "b", "c"}
+	all := func(string) bool { return true }
+
+	tests := []struct {
+		name    string
+		current int
+		step    int
+		want    int
+	}{
+		{"next", 0, 1, 1},
+		{"next wraps to start", 2, 1, 0},
+		{"previous", 1, -1, 0},
+		{"previous wraps to end", 0, -1, 2},
+		{"next before start", -1, 1, 0},
+		{"previous before start", -1, -1, 2},
+		{"index beyond shrunk playlist", 7, 1, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PlaylistStep(playlist, tt.current, tt.step, all); got != tt.want {
+				t.Errorf("Expected %d, got %d", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestPlaylistStepSkipsMissingTexts(t *testing.T) {
+	playlist := []string{"a", "missing", "c", "gone"}
+	exists := func(name string) bool { return name == "a" || name == "c" }
+
+	if got := PlaylistStep(playlist, 0, 1, exists); got != 2 {
+		t.Errorf("Expected next to skip to 2, got %d", got)
+	}
+	if got := PlaylistStep(playlist, 2, 1, exists); got != 0 {
+		t.Errorf("Expected next to skip and wrap to 0, got %d", got)
+	}
+	if got := PlaylistStep(playlist, 0, -1, exists); got != 2 {
+		t.Errorf("Expected previous to skip and wrap to 2, got %d", got)
+	}
+}
+
+func TestPlaylistStepNoExistingTexts(t *testing.T) {
+	none := func(string) bool { return false }
+
+	if got := PlaylistStep([]string{"a", "b"}, 0, 1, none); got != -1 {
+		t.Errorf("Expected -1 when no text exists, got %d", got)
+	}
+	if got := PlaylistStep(nil, 0, 1, none); got != -1 {
+		t.Errorf("Expected -1 for an empty playlist, got %d", got)
+	}
+}
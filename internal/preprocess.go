@@ -0,0 +1,101 @@
+package internal
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Preprocessor names usable in a preprocessing pipeline (Settings.Preprocess).
+const (
+	PreprocessNormalize      = "normalize"       // Turn unusual whitespace into typeable whitespace
+	PreprocessCollapseSpaces = "collapse-spaces" // Collapse runs of spaces and tabs
+	PreprocessStripComments  = "strip-comments"  // Drop lines starting with '#' or '//'
+	PreprocessGutenberg      = "gutenberg"       // Strip Project Gutenberg boilerplate
+	PreprocessLowercase      = "lowercase"       // Lowercase the whole text
+)
+
+// preprocessors maps each preprocessor name to its implementation.
+var preprocessors = map[string]func(string) string{
+	PreprocessNormalize:      NormalizeWhitespace,
+	PreprocessCollapseSpaces: CollapseSpaces,
+	PreprocessStripComments:  StripComments,
+	PreprocessGutenberg:      CleanGutenberg,
+	PreprocessLowercase:      strings.ToLower,
+}
+
+var (
+	// spaceRunPattern matches runs of spaces and tabs within a line.
+	spaceRunPattern = regexp.MustCompile(`[ \t]+`)
+
+	// commentLinePattern matches a line comment, including its line break.
+	commentLinePattern = regexp.MustCompile(`(?m)^[ \t]*(?:#|//).*(?:\n|$)`)
+)
+
+// ApplyPipeline runs text through the named preprocessors in order. Unknown
+// names are skipped; use ValidatePipeline to reject them up front.
+func ApplyPipeline(text string, pipeline []string) string {
+	for _, name := range pipeline {
+		if preprocess, ok := preprocessors[name]; ok {
+			text = preprocess(text)
+		}
+	}
+	return text
+}
+
+// ValidatePipeline checks that every stage of a pipeline names a preprocessor.
+func ValidatePipeline(pipeline []string) error {
+	for _, name := range pipeline {
+		if _, ok := preprocessors[name]; !ok {
+			return fmt.Errorf("unknown preprocessor %q (expected one of %s)", name, strings.Join(PreprocessorNames(), ", "))
+		}
+	}
+	return nil
+}
+
+// PreprocessorNames returns the names of all preprocessors.
+func PreprocessorNames() []string {
+	return []string{PreprocessNormalize, PreprocessCollapseSpaces, PreprocessStripComments, PreprocessGutenberg, PreprocessLowercase}
+}
+
+// WithGutenberg returns pipeline with the gutenberg stage added in front if
+// enabled, or removed if not. A pipeline that already has the stage keeps it
+// in place when enabled.
+func WithGutenberg(pipeline []string, enabled bool) []string {
+	if !enabled {
+		return slices.DeleteFunc(slices.Clone(pipeline), func(name string) bool {
+			return name == PreprocessGutenberg
+		})
+	}
+	if slices.Contains(pipeline, PreprocessGutenberg) {
+		return slices.Clone(pipeline)
+	}
+	return append([]string{PreprocessGutenberg}, pipeline...)
+}
+
+// ParsePipeline splits a comma separated list of preprocessor names.
+func ParsePipeline(list string) []string {
+	var pipeline []string
+	for _, name := range strings.Split(list, ",") {
+		if name = strings.ToLower(strings.TrimSpace(name)); name != "" {
+			pipeline = append(pipeline, name)
+		}
+	}
+	return pipeline
+}
+
+// CollapseSpaces replaces every run of spaces and tabs with a single space and
+// removes them at the start and end of lines. Line breaks are kept.
+func CollapseSpaces(text string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(spaceRunPattern.ReplaceAllString(line, " "))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// StripComments removes lines whose first non-blank characters are '#' or '//'.
+func StripComments(text string) string {
+	return commentLinePattern.ReplaceAllString(text, "")
+}
@@ -0,0 +1,50 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestApplyPipeline(t *testing.T) {
+	text := "# notes\nThe  Quick  Fox\n  // skip me\n\tJumps   Over"
+
+	got := ApplyPipeline(text, []string{PreprocessStripComments, PreprocessNormalize, PreprocessCollapseSpaces, PreprocessLowercase})
+	if want := "the quick fox\njumps over"; got != want {
+		t.Errorf("ApplyPipeline() = %q, want %q", got, want)
+	}
+
+	// Later stages see the output of earlier ones
+	if got := ApplyPipeline("x\n  \t#A  b\nY  Z", []string{PreprocessStripComments, PreprocessCollapseSpaces}); got != "x\nY Z" {
+		t.Errorf("Expected indented comments to be stripped before collapsing, got %q", got)
+	}
+	if got := ApplyPipeline("A  B", []string{PreprocessCollapseSpaces, PreprocessLowercase}); got != "a b" {
+		t.Errorf("Expected both stages to apply, got %q", got)
+	}
+	if got := ApplyPipeline(text, nil); got != text {
+		t.Errorf("Expected an empty pipeline to keep the text, got %q", got)
+	}
+}
+
+func TestValidatePipeline(t *testing.T) {
+	if err := ValidatePipeline(ParsePipeline(" Normalize, collapse-spaces ,gutenberg")); err != nil {
+		t.Errorf("Expected known preprocessors to validate, got %v", err)
+	}
+	if err := ValidatePipeline([]string{"normalize", "spellcheck"}); err == nil {
+		t.Error("Expected an unknown preprocessor to be rejected")
+	}
+}
+
+func TestTextLibraryPipeline(t *testing.T) {
+	dir := t.TempDir()
+	content := "// header\nHello   World"
+	if err := os.WriteFile(filepath.Join(dir, "a.txt"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tl := NewTextLibrary([]string{dir})
+	tl.SetPipeline([]string{PreprocessStripComments, PreprocessCollapseSpaces, PreprocessLowercase})
+	if got := tl.GetCurrentText().Content; got != "hello world" {
+		t.Errorf("Expected the pipeline to apply on reload, got %q", got)
+	}
+}
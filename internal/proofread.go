@@ -0,0 +1,60 @@
+package internal
+
+import (
+	"math/rand"
+	"unicode"
+)
+
+// proofreadTypoRate is the share of words that get a typo in proofreading mode.
+const proofreadTypoRate = 0.15
+
+// InsertTypos returns text with typos for proofreading practice: each word of
+// at least three letters swaps two adjacent letters with probability rate, and
+// at least one word does if any can. Only letters move, so the result has the
+// same length and spaces and punctuation stay in place.
+func InsertTypos(text string, rate float64, rng *rand.Rand) string {
+	runes := []rune(text)
+
+	// Positions where two adjacent, different letters of a long enough word could be swapped
+	var words [][]int
+	start := -1
+	for i := 0; i <= len(runes); i++ {
+		if i < len(runes) && unicode.IsLetter(runes[i]) {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+		if start >= 0 && i-start >= 3 {
+			var swaps []int
+			for j := start; j < i-1; j++ {
+				if runes[j] != runes[j+1] {
+					swaps = append(swaps, j)
+				}
+			}
+			if len(swaps) > 0 {
+				words = append(words, swaps)
+			}
+		}
+		start = -1
+	}
+	if len(words) == 0 {
+		return text
+	}
+
+	swap := func(swaps []int) {
+		j := swaps[rng.Intn(len(swaps))]
+		runes[j], runes[j+1] = runes[j+1], runes[j]
+	}
+	inserted := false
+	for _, swaps := range words {
+		if rng.Float64() < rate {
+			swap(swaps)
+			inserted = true
+		}
+	}
+	if !inserted {
+		swap(words[rng.Intn(len(words))])
+	}
+	return string(runes)
+}
@@ -0,0 +1,41 @@
+package internal
+
+import (
+	"math/rand"
+	"testing"
+	"unicode"
+)
+
+func TestInsertTypos(t *testing.T) {
+	text := "The quick brown fox jumps over the lazy dog, again and again."
+	rng := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 50; i++ {
+		got := []rune(InsertTypos(text, proofreadTypoRate, rng))
+		want := []rune(text)
+		if len(got) != len(want) {
+			t.Fatalf("Expected the same length, got %q", string(got))
+		}
+
+		changed := false
+		for j := range want {
+			if got[j] == want[j] {
+				continue
+			}
+			changed = true
+			if !unicode.IsLetter(got[j]) || !unicode.IsLetter(want[j]) {
+				t.Fatalf("Expected only letters to change, got %q", string(got))
+			}
+		}
+		if !changed {
+			t.Fatalf("Expected at least one typo, got %q", string(got))
+		}
+	}
+}
+
+func TestInsertTyposWithoutLongWords(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	if got := InsertTypos("a to be, 12345 aaa", 1, rng); got != "a to be, 12345 aaa" {
+		t.Errorf("Expected a text without swappable words unchanged, got %q", got)
+	}
+}
@@ -0,0 +1,30 @@
+package internal
+
+import (
+	"regexp"
+	"strings"
+)
+
+// sentenceEndPattern matches the end of a sentence: '.', '!' or '?' followed by
+// whitespace. The whitespace is captured so it can be left out of the sentence.
+var sentenceEndPattern = regexp.MustCompile(`[.!?]+(\s+)`)
+
+// SplitSentences splits text into sentences at '.', '!' or '?' followed by
+// whitespace, keeping the punctuation. Line breaks and runs of whitespace within
+// a sentence become single spaces; empty segments are dropped.
+func SplitSentences(text string) []string {
+	var sentences []string
+	add := func(segment string) {
+		if sentence := strings.Join(strings.Fields(segment), " "); sentence != "" {
+			sentences = append(sentences, sentence)
+		}
+	}
+
+	start := 0
+	for _, loc := range sentenceEndPattern.FindAllStringSubmatchIndex(text, -1) {
+		add(text[start:loc[2]])
+		start = loc[3]
+	}
+	add(text[start:])
+	return sentences
+}
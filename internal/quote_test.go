@@ -0,0 +1,101 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestSplitSentences(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"One. Two! Three?", []string{"One.", "Two!", "Three?"}},
+		{"It was late...  Very late.\nThe end", []string{"It was late...", "Very late.", "The end"}},
+		{"A sentence\nspanning lines. Next.", []string{"A sentence spanning lines.", "Next."}},
+		{"v1.2 is out. Version 3.0!", []string{"v1.2 is out.", "Version 3.0!"}},
+		{"No break at all", []string{"No break at all"}},
+		{"  \n ", nil},
+	}
+
+	for _, tt := range tests {
+		if got := SplitSentences(tt.text); !slices.Equal(got, tt.want) {
+			t.Errorf("SplitSentences(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestSelectRandomQuote(t *testing.T) {
+	dir := t.TempDir()
+	content := "First sentence here. Second one!\nThird, on a new line?"
+	if err := os.WriteFile(filepath.Join(dir, "story.txt"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	library := NewTextLibrary([]string{dir})
+
+	sentences := SplitSentences(content)
+	for range 20 {
+		quote := library.SelectRandomQuote()
+		if quote.Name != "story" || !slices.Contains(sentences, quote.Content) {
+			t.Fatalf("Expected a sentence of story, got %q from %q", quote.Content, quote.Name)
+		}
+	}
+}
+
+func TestQuoteModeFinishesAfterSentence(t *testing.T) {
+	app := newTestApp(t)
+	app.selectRandomQuote()
+	if app.mode != "quote" {
+		t.Fatalf("Expected quote mode, got %q", app.mode)
+	}
+
+	quote := app.typingTest.GetSampleText()
+	if strings.Contains(quote, "\n") {
+		t.Errorf("Expected a single sentence, got %q", quote)
+	}
+	for _, r := range quote {
+		app.handleKey(tcell.NewEventKey(tcell.KeyRune, r, tcell.ModNone))
+	}
+	if !app.showResults {
+		t.Error("Expected the results after typing the whole sentence")
+	}
+	if key := app.getLeaderboardKey(); !strings.HasPrefix(key, "quote:") || len(app.leaderboards[key]) != 1 {
+		t.Errorf("Expected the result under a quote leaderboard key, got %q", key)
+	}
+}
+
+func TestQuoteRecordsPerSentence(t *testing.T) {
+	app := newTestApp(t)
+	app.selectRandomQuote()
+
+	app.typingTest.SetSampleText("First sentence.")
+	first := app.getLeaderboardKey()
+	app.typingTest.SetSampleText("Second sentence.")
+	second := app.getLeaderboardKey()
+	if first == second {
+		t.Errorf("Expected sentences of the same text to have their own leaderboards, both got %q", first)
+	}
+	if !strings.Contains(first, app.textLibrary.GetCurrentText().Name) {
+		t.Errorf("Expected the quote key to name its text, got %q", first)
+	}
+}
+
+func TestProofreadRestartsQuote(t *testing.T) {
+	app := newTestApp(t)
+	app.selectRandomQuote()
+	typeString(app.typingTest, "Ro")
+
+	app.toggleProofread()
+	if app.mode != "quote" || app.typingTest.GetUserInput() != "" {
+		t.Fatalf("Expected a new quote mode test, got mode %q with input %q", app.mode, app.typingTest.GetUserInput())
+	}
+	sentences := SplitSentences(app.textLibrary.GetCurrentText().Content)
+	if !slices.Contains(sentences, app.typingTest.GetSampleText()) {
+		t.Errorf("Expected a sentence of the current text, got %q", app.typingTest.GetSampleText())
+	}
+}
@@ -0,0 +1,372 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// rcFileName is the optional plain-text config file in the config directory.
+// It uses key=value lines and overrides settings.json.
+const rcFileName = "rocketype.conf"
+
+// rcSetters maps rc file keys to functions that validate a value and apply it to Settings.
+var rcSetters = map[string]func(settings *Settings, value string) error{
+	"theme": func(s *Settings, v string) error {
+		if _, found := FindTheme(v); !found {
+			return fmt.Errorf("unknown theme %q", v)
+		}
+		s.ThemeName = v
+		return nil
+	},
+	"favorite_themes": func(s *Settings, v string) error {
+		var names []string
+		for _, name := range strings.Split(v, ",") {
+			if name = strings.TrimSpace(name); name == "" {
+				continue
+			}
+			if _, found := FindTheme(name); !found {
+				return fmt.Errorf("unknown theme %q", name)
+			}
+			names = append(names, name)
+		}
+		s.FavoriteThemes = names
+		return nil
+	},
+	"mode": func(s *Settings, v string) error {
+		return setChoice(&s.Mode, v, "text", "words", "quote")
+	},
+	"limit_type": func(s *Settings, v string) error {
+		return setChoice(&s.LimitType, v, "time", "words")
+	},
+	"time_limit": func(s *Settings, v string) error {
+		return setPositiveInt(&s.TimeLimit, v)
+	},
+	"word_limit": func(s *Settings, v string) error {
+		return setPositiveInt(&s.WordLimit, v)
+	},
+	"word_set": func(s *Settings, v string) error {
+		s.LastWordSet = v
+		return nil
+	},
+	"word_case": func(s *Settings, v string) error {
+		return setChoice(&s.WordCase, v, string(WordCaseAsIs), string(WordCaseCapitalized), string(WordCaseRandomCapitals))
+	},
+	"min_word_length": func(s *Settings, v string) error {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return fmt.Errorf("expected a non-negative number, got %q", v)
+		}
+		s.MinWordLength = n
+		return nil
+	},
+	"punctuation": func(s *Settings, v string) error {
+		return setBool(&s.Punctuation, v)
+	},
+	"capitalization": func(s *Settings, v string) error {
+		return setBool(&s.Capitalization, v)
+	},
+	"hard_words_only": func(s *Settings, v string) error {
+		return setBool(&s.HardWordsOnly, v)
+	},
+	"include_numbers": func(s *Settings, v string) error {
+		return setBool(&s.IncludeNumbers, v)
+	},
+	"playlist": func(s *Settings, v string) error {
+		var names []string
+		for _, name := range strings.Split(v, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				names = append(names, name)
+			}
+		}
+		s.Playlist = names
+		return nil
+	},
+	"playlist_mode": func(s *Settings, v string) error {
+		return setBool(&s.PlaylistMode, v)
+	},
+	"typing_semantics": func(s *Settings, v string) error {
+		return setChoice(&s.TypingSemantics, v, string(SemanticsCharacter), string(SemanticsWord))
+	},
+	"timer_start": func(s *Settings, v string) error {
+		return setChoice(&s.TimerStart, v, TimerStartFirstKey, TimerStartOnLoad)
+	},
+	"skip_to_next_word_on_space": func(s *Settings, v string) error {
+		return setBool(&s.SkipToNextWordOnSpace, v)
+	},
+	"track_errors": func(s *Settings, v string) error {
+		return setBool(&s.TrackErrors, v)
+	},
+	"require_exact_to_finish": func(s *Settings, v string) error {
+		return setBool(&s.RequireExactToFinish, v)
+	},
+	"strict_finish": func(s *Settings, v string) error {
+		return setBool(&s.StrictFinish, v)
+	},
+	"blind_mode": func(s *Settings, v string) error {
+		return setBool(&s.BlindMode, v)
+	},
+	"error_feedback": func(s *Settings, v string) error {
+		return setChoice(&s.ErrorFeedback, v, ErrorFeedbackNone, ErrorFeedbackSubtle, ErrorFeedbackStrong)
+	},
+	"startup_prompt": func(s *Settings, v string) error {
+		return setBool(&s.StartupPrompt, v)
+	},
+	"proofread": func(s *Settings, v string) error {
+		return setBool(&s.Proofread, v)
+	},
+	"tab_key": func(s *Settings, v string) error {
+		return setChoice(&s.TabKey, v, TabKeyAuto, TabKeyRestart, TabKeyType)
+	},
+	"wpm_lead_in_ms": func(s *Settings, v string) error {
+		ms, err := strconv.Atoi(v)
+		if err != nil || ms < 0 {
+			return fmt.Errorf("expected a non-negative number, got %q", v)
+		}
+		s.WPMLeadInMS = ms
+		return nil
+	},
+	"key_restart": func(s *Settings, v string) error {
+		return setKeyBinding(s, ActionRestart, v)
+	},
+	"key_new_words": func(s *Settings, v string) error {
+		return setKeyBinding(s, ActionNewWords, v)
+	},
+	"key_line_timings": func(s *Settings, v string) error {
+		return setKeyBinding(s, ActionLineTimings, v)
+	},
+	"key_quit": func(s *Settings, v string) error {
+		return setKeyBinding(s, ActionQuit, v)
+	},
+	"key_command_menu": func(s *Settings, v string) error {
+		return setKeyBinding(s, ActionCommandMenu, v)
+	},
+	"key_cycle_theme": func(s *Settings, v string) error {
+		return setKeyBinding(s, ActionCycleTheme, v)
+	},
+	"key_limit_type": func(s *Settings, v string) error {
+		return setKeyBinding(s, ActionLimitType, v)
+	},
+	"clean_gutenberg": func(s *Settings, v string) error {
+		var enabled bool
+		if err := setBool(&enabled, v); err != nil {
+			return err
+		}
+		s.Preprocess = WithGutenberg(s.Preprocess, enabled)
+		return nil
+	},
+	"preprocess": func(s *Settings, v string) error {
+		pipeline := ParsePipeline(v)
+		if err := ValidatePipeline(pipeline); err != nil {
+			return err
+		}
+		s.Preprocess = pipeline
+		return nil
+	},
+	"chunk_words": func(s *Settings, v string) error {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return fmt.Errorf("expected a non-negative number, got %q", v)
+		}
+		s.ChunkWords = n
+		return nil
+	},
+	"results_enter_action": func(s *Settings, v string) error {
+		return setChoice(&s.ResultsEnterAction, v, ResultsEnterRestart, ResultsEnterNext)
+	},
+	"results_footer": func(s *Settings, v string) error {
+		s.ResultsFooter = v
+		return nil
+	},
+	"ascii_mode": func(s *Settings, v string) error {
+		return setChoice(&s.ASCIIMode, v, ASCIIModeAuto, ASCIIModeOn, ASCIIModeOff)
+	},
+	"transparent_background": func(s *Settings, v string) error {
+		return setBool(&s.TransparentBackground, v)
+	},
+	"auto_theme": func(s *Settings, v string) error {
+		return setBool(&s.AutoTheme.Enabled, v)
+	},
+	"show_graph": func(s *Settings, v string) error {
+		return setBool(&s.ShowGraph, v)
+	},
+	"show_sparkline": func(s *Settings, v string) error {
+		return setBool(&s.ShowSparkline, v)
+	},
+	"dim_stopwords": func(s *Settings, v string) error {
+		return setBool(&s.DimStopwords, v)
+	},
+	"celebrate": func(s *Settings, v string) error {
+		return setBool(&s.CelebrateMode, v)
+	},
+	"big_word": func(s *Settings, v string) error {
+		return setBool(&s.BigWord, v)
+	},
+	"bold_text": func(s *Settings, v string) error {
+		return setBool(&s.BoldText, v)
+	},
+	"underline_whitespace": func(s *Settings, v string) error {
+		return setBool(&s.UnderlineWhitespace, v)
+	},
+	"show_mistyped_overlay": func(s *Settings, v string) error {
+		return setBool(&s.ShowMistypedOverlay, v)
+	},
+	"scroll_anchor": func(s *Settings, v string) error {
+		return setChoice(&s.ScrollAnchor, v, ScrollAnchorSmooth, ScrollAnchorTop, ScrollAnchorCenter, ScrollAnchorBottom)
+	},
+	"top_margin": func(s *Settings, v string) error {
+		return setMargin(&s.TopMargin, v)
+	},
+	"bottom_margin": func(s *Settings, v string) error {
+		return setMargin(&s.BottomMargin, v)
+	},
+	"focus_fade": func(s *Settings, v string) error {
+		return setBool(&s.FocusFade, v)
+	},
+	"horizontal_focus": func(s *Settings, v string) error {
+		return setBool(&s.HorizontalFocus, v)
+	},
+	"show_live_stats": func(s *Settings, v string) error {
+		return setBool(&s.ShowLiveStats, v)
+	},
+	"dim_behind_overlays": func(s *Settings, v string) error {
+		return setBool(&s.DimBehindOverlays, v)
+	},
+	"generation_indicator": func(s *Settings, v string) error {
+		return setBool(&s.GenerationIndicator, v)
+	},
+	"show_line_numbers": func(s *Settings, v string) error {
+		return setBool(&s.ShowLineNumbers, v)
+	},
+	"line_numbering": func(s *Settings, v string) error {
+		return setChoice(&s.LineNumbering, v, LineNumbersLogical, LineNumbersWrapped)
+	},
+	"speed_unit": func(s *Settings, v string) error {
+		return setChoice(&s.SpeedUnit, v, SpeedUnitWPM, SpeedUnitKPM, SpeedUnitBoth)
+	},
+}
+
+// ParseRCFile parses key=value lines. Blank lines and lines starting with #
+// are ignored; keys and values are trimmed and keys are lowercased.
+// Later lines override earlier ones with the same key.
+//
+// Returns an error naming the first line that isn't a key=value pair.
+func ParseRCFile(data []byte) (map[string]string, error) {
+	values := make(map[string]string)
+
+	for i, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		key = strings.ToLower(strings.TrimSpace(key))
+		if !ok || key == "" {
+			return nil, fmt.Errorf("line %d: expected key=value, got %q", i+1, line)
+		}
+		values[key] = strings.TrimSpace(value)
+	}
+
+	return values, nil
+}
+
+// ApplyRCValues applies parsed rc file values to settings. Invalid values and
+// unknown keys are skipped; all of them are reported in the returned error.
+func ApplyRCValues(settings *Settings, values map[string]string) error {
+	var errs []error
+	for key, value := range values {
+		setter, ok := rcSetters[key]
+		if !ok {
+			errs = append(errs, fmt.Errorf("unknown key %q", key))
+			continue
+		}
+		if err := setter(settings, value); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", key, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// LoadRCFile applies the optional rocketype.conf from the config directory on top
+// of the given settings. A missing file is not an error.
+func LoadRCFile(settings *Settings) error {
+	configDir, err := GetConfigDir()
+	if err != nil {
+		return fmt.Errorf("failed to get config directory: %w", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(configDir, rcFileName))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", rcFileName, err)
+	}
+
+	values, err := ParseRCFile(data)
+	if err != nil {
+		return fmt.Errorf("%s: %w", rcFileName, err)
+	}
+	if err := ApplyRCValues(settings, values); err != nil {
+		return fmt.Errorf("%s: %w", rcFileName, err)
+	}
+	return nil
+}
+
+// setChoice sets target to value if it is one of the allowed choices.
+func setChoice(target *string, value string, choices ...string) error {
+	for _, choice := range choices {
+		if value == choice {
+			*target = value
+			return nil
+		}
+	}
+	return fmt.Errorf("expected one of %s, got %q", strings.Join(choices, ", "), value)
+}
+
+// setPositiveInt sets target to value if it parses as a positive integer.
+func setPositiveInt(target *int, value string) error {
+	n, err := strconv.Atoi(value)
+	if err != nil || n <= 0 {
+		return fmt.Errorf("expected a positive number, got %q", value)
+	}
+	*target = n
+	return nil
+}
+
+// setMargin sets a typing text margin to value if it parses as a number of
+// rows between 0 and maxTypingMargin.
+func setMargin(target *int, value string) error {
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 || n > maxTypingMargin {
+		return fmt.Errorf("expected a number from 0 to %d, got %q", maxTypingMargin, value)
+	}
+	*target = n
+	return nil
+}
+
+// setKeyBinding binds an action to a comma separated list of keys.
+func setKeyBinding(s *Settings, action, keys string) error {
+	if err := ValidateBinding(action, keys); err != nil {
+		return err
+	}
+	if s.Keymap == nil {
+		s.Keymap = make(map[string]string)
+	}
+	s.Keymap[action] = keys
+	return nil
+}
+
+// setBool sets target to value if it parses as a boolean.
+func setBool(target *bool, value string) error {
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return fmt.Errorf("expected true or false, got %q", value)
+	}
+	*target = b
+	return nil
+}
@@ -0,0 +1,127 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseRCFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name:  "key value pairs",
+			input: "theme=gruvbox\nmode = words\n",
+			want:  map[string]string{"theme": "gruvbox", "mode": "words"},
+		},
+		{
+			name:  "comments and blank lines",
+			input: "# my config\n\n  # indented comment\ntime_limit=30\n",
+			want:  map[string]string{"time_limit": "30"},
+		},
+		{
+			name:  "keys are lowercased and later lines win",
+			input: "Theme=dracula\ntheme=midnight",
+			want:  map[string]string{"theme": "midnight"},
+		},
+		{
+			name:  "value may contain equals sign",
+			input: "word_set=a=b",
+			want:  map[string]string{"word_set": "a=b"},
+		},
+		{
+			name:    "line without equals sign",
+			input:   "theme=gruvbox\njust some text\n",
+			wantErr: true,
+		},
+		{
+			name:    "missing key",
+			input:   "=value",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRCFile([]byte(tt.input))
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Expected %v, got %v", tt.want, got)
+			}
+			for k, v := range tt.want {
+				if got[k] != v {
+					t.Errorf("Expected %s=%q, got %q", k, v, got[k])
+				}
+			}
+		})
+	}
+}
+
+func TestApplyRCValues(t *testing.T) {
+	settings := DefaultSettings()
+	err := ApplyRCValues(&settings, map[string]string{
+		"theme":                      "dracula",
+		"mode":                       "words",
+		"time_limit":                 "30",
+		"skip_to_next_word_on_space": "true",
+		"word_limit":                 "-5",    // Invalid, ignored
+		"limit_type":                 "pages", // Invalid, ignored
+		"colour":                     "red",   // Unknown, ignored
+		"top_margin":                 "3",
+		"bottom_margin":              "-1", // Invalid, ignored
+	})
+
+	if err == nil {
+		t.Error("Expected error listing the invalid entries")
+	}
+	if settings.ThemeName != "dracula" || settings.Mode != "words" || settings.TimeLimit != 30 {
+		t.Errorf("Expected valid values applied, got %+v", settings)
+	}
+	if !settings.SkipToNextWordOnSpace {
+		t.Error("Expected boolean value applied")
+	}
+	if settings.TopMargin != 3 || settings.BottomMargin != 0 {
+		t.Errorf("Expected only the valid margin applied, got top=%d bottom=%d", settings.TopMargin, settings.BottomMargin)
+	}
+	defaults := DefaultSettings()
+	if settings.WordLimit != defaults.WordLimit || settings.LimitType != defaults.LimitType {
+		t.Errorf("Expected invalid values to be ignored, got word_limit=%d limit_type=%q",
+			settings.WordLimit, settings.LimitType)
+	}
+}
+
+func TestLoadRCFile(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	configDir, err := GetConfigDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	settings := DefaultSettings()
+	if err := LoadRCFile(&settings); err != nil {
+		t.Fatalf("Expected missing rc file to be ignored, got %v", err)
+	}
+
+	path := filepath.Join(configDir, rcFileName)
+	if err := os.WriteFile(path, []byte("# overrides\ntheme=midnight\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := LoadRCFile(&settings); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if settings.ThemeName != "midnight" {
+		t.Errorf("Expected theme from rc file, got %q", settings.ThemeName)
+	}
+}
@@ -2,6 +2,8 @@ package internal
 
 import (
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -13,15 +15,69 @@ import (
 // for rendering the typing test UI, command menu, and results screen.
 type Renderer struct {
 	screen tcell.Screen
+	glyphs Glyphs // Decorative characters (Unicode or ASCII)
+
+	speedUnit string // How typing speed is shown (SpeedUnitWPM, SpeedUnitKPM or SpeedUnitBoth)
+
+	// Extra empty rows around the typing text (see FitTypingMargins)
+	topMargin    int
+	bottomMargin int
+
+	// Color downgrade for terminals without true color support
+	downgradeColors bool                        // Map RGB colors to the 256-color palette
+	paletteCache    map[tcell.Color]tcell.Color // Memoized NearestPaletteColor results
+
+	layout layoutCache // Wrapped layout of the last typing text
 }
 
 // NewRenderer creates a new Renderer instance with the given screen.
 func NewRenderer(screen tcell.Screen) *Renderer {
+	colors := screen.Colors()
 	return &Renderer{
-		screen: screen,
+		screen:          screen,
+		glyphs:          UnicodeGlyphs,
+		speedUnit:       SpeedUnitWPM,
+		downgradeColors: colors >= 256 && colors < 1<<24,
+		paletteCache:    make(map[tcell.Color]tcell.Color),
 	}
 }
 
+// setContent draws a cell, mapping RGB colors to the nearest palette entry
+// on terminals without true color support.
+func (r *Renderer) setContent(x, y int, ch rune, combc []rune, style tcell.Style) {
+	if r.downgradeColors {
+		fg, bg, _ := style.Decompose()
+		style = style.Foreground(r.paletteColor(fg)).Background(r.paletteColor(bg))
+	}
+	r.screen.SetContent(x, y, ch, combc, style)
+}
+
+// paletteColor returns the cached nearest palette color for c.
+func (r *Renderer) paletteColor(c tcell.Color) tcell.Color {
+	if mapped, ok := r.paletteCache[c]; ok {
+		return mapped
+	}
+	mapped := NearestPaletteColor(c)
+	r.paletteCache[c] = mapped
+	return mapped
+}
+
+// SetASCIIMode switches between Unicode and ASCII-only decorations.
+func (r *Renderer) SetASCIIMode(enabled bool) {
+	r.glyphs = GlyphsFor(enabled)
+}
+
+// SetSpeedUnit sets how the live stats and the results show typing speed.
+func (r *Renderer) SetSpeedUnit(unit string) {
+	r.speedUnit = unit
+}
+
+// SetTypingMargins sets the extra empty rows above and below the typing text.
+func (r *Renderer) SetTypingMargins(top, bottom int) {
+	r.topMargin = top
+	r.bottomMargin = bottom
+}
+
 // Clear clears the entire screen.
 func (r *Renderer) Clear() {
 	r.screen.Clear()
@@ -43,21 +99,32 @@ func (r *Renderer) FillBackground(bg tcell.Color) {
 	style := tcell.StyleDefault.Background(bg)
 	for y := range height {
 		for x := range width {
-			r.screen.SetContent(x, y, ' ', nil, style)
+			r.setContent(x, y, ' ', nil, style)
 		}
 	}
 }
 
+// DrawErrorFlash fills the top and bottom screen rows with style to signal a
+// mistyped key.
+func (r *Renderer) DrawErrorFlash(style tcell.Style) {
+	width, height := r.screen.Size()
+	for x := range width {
+		r.setContent(x, 0, ' ', nil, style)
+		r.setContent(x, height-1, ' ', nil, style)
+	}
+}
+
 // DrawText renders a string at the specified coordinates with the given colors.
 func (r *Renderer) DrawText(x, y int, text string, fg, bg tcell.Color) {
 	style := tcell.StyleDefault.Foreground(fg).Background(bg)
 	for i, ch := range text {
-		r.screen.SetContent(x+i, y, ch, nil, style)
+		r.setContent(x+i, y, ch, nil, style)
 	}
 }
 
 // DrawTitle renders the title bar with theme and text information.
-func (r *Renderer) DrawTitle(themeName, textName, modeInfo string, theme Theme) {
+// It is dimmed while typingActive is set (focus fade).
+func (r *Renderer) DrawTitle(themeName, textName, modeInfo string, theme Theme, typingActive bool) {
 	width, _ := r.screen.Size()
 	var title string
 	if modeInfo != "" {
@@ -66,42 +133,149 @@ func (r *Renderer) DrawTitle(themeName, textName, modeInfo string, theme Theme)
 		title = fmt.Sprintf("rocketype [%s] - %s", themeName, textName)
 	}
 	x := width/2 - len(title)/2
-	r.DrawText(x, 2, title, theme.Title, theme.Background)
+	r.drawRunes(x, 2, title, chromeStyle(theme.Title, theme, typingActive))
 }
 
-// DrawHelpText renders the help text at the bottom of the screen.
-func (r *Renderer) DrawHelpText(theme Theme) {
+// DrawHelpText renders the help text (see Keymap.TypingHelp) at the bottom of
+// the screen. It is dimmed while typingActive is set (focus fade).
+func (r *Renderer) DrawHelpText(help string, theme Theme, typingActive bool) {
 	width, height := r.screen.Size()
-	help := "Esc/Ctrl+C: quit  |  Ctrl+P: command menu  |  Ctrl+T: change theme"
 	x := width/2 - len(help)/2
-	r.DrawText(x, height-2, help, theme.Help, theme.Background)
+	r.drawRunes(x, height-2, help, chromeStyle(theme.Help, theme, typingActive))
+}
+
+// DrawStats renders the live statistics (speed, keystroke and word accuracy and
+// the correct keystroke combo) at the bottom. A negative wordAccuracy leaves it
+// out. They are dimmed while typingActive is set (focus fade).
+func (r *Renderer) DrawStats(wpm, accuracy, wordAccuracy float64, combo, bestCombo int, theme Theme, typingActive bool) {
+	width, height := r.screen.Size()
+	speedText := FormatSpeed(wpm, r.speedUnit, 0, "  |  ")
+	statsText := fmt.Sprintf("%s  |  Accuracy: %.1f%%", speedText, accuracy)
+	if wordAccuracy >= 0 {
+		statsText += fmt.Sprintf("  |  Word acc: %.0f%%", wordAccuracy)
+	}
+	statsText += fmt.Sprintf("  |  Combo: %d, Best: %d", combo, bestCombo)
+	x := width/2 - len(statsText)/2
+	r.drawRunes(x, height-3, statsText, chromeStyle(theme.Help, theme, typingActive))
 }
 
-// DrawStats renders the live statistics (WPM and accuracy) at the bottom.
-func (r *Renderer) DrawStats(wpm, accuracy float64, theme Theme) {
+// DrawWPM renders only the live speed at the bottom, for modes that hide accuracy.
+// It is dimmed while typingActive is set (focus fade).
+func (r *Renderer) DrawWPM(wpm float64, theme Theme, typingActive bool) {
 	width, height := r.screen.Size()
-	statsText := fmt.Sprintf("WPM: %.0f  |  Accuracy: %.1f%%", wpm, accuracy)
+	statsText := FormatSpeed(wpm, r.speedUnit, 0, "  |  ")
 	x := width/2 - len(statsText)/2
-	r.DrawText(x, height-3, statsText, theme.Help, theme.Background)
+	r.drawRunes(x, height-3, statsText, chromeStyle(theme.Help, theme, typingActive))
+}
+
+// FormatSpeed formats a speed given in WPM in the chosen unit (SpeedUnitWPM,
+// SpeedUnitKPM or SpeedUnitBoth), e.g. "WPM: 82", "KPM: 410" or both joined by
+// separator. WPM is shown with the given number of decimals, KPM without.
+// Unknown units show WPM.
+func FormatSpeed(wpm float64, unit string, decimals int, separator string) string {
+	return joinSpeeds(unit, separator,
+		fmt.Sprintf("WPM: %.*f", decimals, wpm),
+		fmt.Sprintf("KPM: %.0f", wpm*CharsPerWord))
+}
+
+// formatResultSpeed formats the net and raw speed for the results screen in
+// the chosen unit like FormatSpeed, e.g. "WPM: 82.0 (raw 91.0)".
+func formatResultSpeed(wpm, rawWPM float64, unit, separator string) string {
+	return joinSpeeds(unit, separator,
+		fmt.Sprintf("WPM: %.1f (raw %.1f)", wpm, rawWPM),
+		fmt.Sprintf("KPM: %.0f (raw %.0f)", wpm*CharsPerWord, rawWPM*CharsPerWord))
 }
 
+// joinSpeeds returns the text of a speed in the chosen unit: wpmText, kpmText
+// or both joined by separator. Unknown units show WPM.
+func joinSpeeds(unit, separator, wpmText, kpmText string) string {
+	switch unit {
+	case SpeedUnitKPM:
+		return kpmText
+	case SpeedUnitBoth:
+		return wpmText + separator + kpmText
+	default:
+		return wpmText
+	}
+}
+
+// chromeStyle returns the style for UI chrome (title, help and live stats)
+// drawn in fg. While typing is active the chrome fades into the background,
+// so the text being typed stands out.
+func chromeStyle(fg tcell.Color, theme Theme, typingActive bool) tcell.Style {
+	if typingActive {
+		return tcell.StyleDefault.Foreground(theme.MenuDimText).Background(theme.Background).Dim(true)
+	}
+	return tcell.StyleDefault.Foreground(fg).Background(theme.Background)
+}
+
+// DimBackground redraws everything drawn so far in a dimmed style (see
+// dimStyle), so an overlay drawn afterwards stands out from the view behind it.
+// tcell has no transparency, so the cells are restyled instead.
+func (r *Renderer) DimBackground(theme Theme) {
+	width, height := r.screen.Size()
+	for y := range height {
+		for x := range width {
+			mainc, combc, style, _ := r.screen.GetContent(x, y)
+			r.setContent(x, y, mainc, combc, dimStyle(style, theme))
+		}
+	}
+}
+
+// dimStyle returns style faded for the view behind an overlay: the foreground
+// becomes the theme's dimmed menu text (if it has one) without bold, and the
+// background is kept so the view's layout stays visible.
+func dimStyle(style tcell.Style, theme Theme) tcell.Style {
+	if theme.MenuDimText != tcell.ColorDefault {
+		style = style.Foreground(theme.MenuDimText)
+	}
+	return style.Bold(false).Dim(true)
+}
+
+// Rows of the typing screen chrome below the text, counted up from the
+// bottom edge of the screen. The text ends above textBottomRows.
+const (
+	progressRowFromBottom = 4
+	liveWPMRowFromBottom  = 5
+	noticeRowFromBottom   = 6
+	textBottomRows        = noticeRowFromBottom
+)
+
 // DrawProgress renders progress information (timer or word count) above stats.
 func (r *Renderer) DrawProgress(progressText string, theme Theme) {
 	width, height := r.screen.Size()
 	x := width/2 - len(progressText)/2
-	r.DrawText(x, height-4, progressText, theme.Help, theme.Background)
+	r.DrawText(x, height-progressRowFromBottom, progressText, theme.Help, theme.Background)
+}
+
+// DrawNotice renders a short message that needs the user's attention on its
+// own row above the live WPM line.
+func (r *Renderer) DrawNotice(text string, theme Theme) {
+	width, height := r.screen.Size()
+	x := width/2 - len(text)/2
+	r.DrawText(x, height-noticeRowFromBottom, text, theme.TextIncorrect, theme.Background)
 }
 
 // TypingViewData contains all data needed to render the typing test view.
 type TypingViewData struct {
-	SampleText  string
-	SampleRunes []rune // Cached rune slice to avoid repeated conversions
-	UserInput   string
-	UserRunes   []rune // Cached rune slice to avoid repeated conversions
-	CursorPos   int
-	ScrollLine  int // Which wrapped line should be at the top of the viewport
-	Theme       Theme
-	WordMode    bool // True if in word mode (shows only 2 lines below cursor)
+	SampleText          string
+	SampleRunes         []rune // Cached rune slice to avoid repeated conversions
+	TargetRunes         []rune // Hidden correct text when proofreading (nil if input is checked against SampleRunes)
+	UserInput           string
+	UserRunes           []rune // Cached rune slice to avoid repeated conversions
+	CursorPos           int
+	ScrollLine          int // Which wrapped line should be at the top of the viewport
+	Theme               Theme
+	WordMode            bool   // True if in word mode (shows only 2 lines below cursor)
+	BoldText            bool   // Draw all characters bold for readability
+	UnderlineWhitespace bool   // Underline mistyped whitespace instead of showing '_'
+	ShowMistypedOverlay bool   // Draw the mistyped character above incorrect positions
+	BlindMode           bool   // Hide correctness: typed characters all look the same
+	ShowLineNumbers     bool   // Draw a line number gutter left of the text
+	LineNumbering       string // LineNumbersLogical or LineNumbersWrapped
+	StopwordMask        []bool // Characters of function words, dimmed until typed (nil = no dimming)
+	HorizontalFocus     bool   // Shift the text so the cursor stays at the center column
+	Generating          bool   // More words are being generated; marked after the end of the text
 }
 
 // DrawTypingView renders the main typing test interface with wrapped text and visual feedback.
@@ -116,168 +290,366 @@ func (r *Renderer) DrawTypingView(data TypingViewData) {
 		maxWidth = width
 	}
 
-	// Wrap text to fit screen width
-	lines := wrapText(data.SampleText, maxWidth)
+	// Find where each wrapped line starts; only the visible lines are built
+	// below, and the line starts are cached, so very long texts aren't
+	// wrapped again on each frame
+	layout := r.textLayout(data.SampleRunes, maxWidth)
+	lineStarts := layout.starts
 
 	// Calculate available height for text lines
-	availableHeight := height - 8
+	availableHeight := TypingAvailableHeight(height, r.topMargin, r.bottomMargin)
 	maxVisibleLines := availableHeight / 2 // 2 screen rows per text line
 
 	// In word mode, only show 3 lines (cursor line + 2 below)
-	// This constant should match wordModeVisibleLines in app.go
-	const wordModeVisibleLines = 3
 	if data.WordMode {
 		maxVisibleLines = wordModeVisibleLines
 	}
 
 	// Adjust scroll position if needed
 	scrollLine := data.ScrollLine
+	if scrollLine > len(lineStarts)-1 {
+		scrollLine = len(lineStarts) - 1
+	}
 	if scrollLine < 0 {
 		scrollLine = 0
 	}
-	if scrollLine > len(lines)-1 {
-		scrollLine = len(lines) - 1
+
+	var lines []WrappedLine
+	if len(lineStarts) > 0 {
+		lines = wrapRunesWindow(data.SampleRunes, maxWidth, lineStarts[scrollLine], maxVisibleLines)
 	}
+	visibleLineCount := len(lines)
+
+	startY := typingViewStartY(height, visibleLineCount, r.topMargin, r.bottomMargin)
 
-	// Calculate how many lines will actually be rendered
-	endLine := scrollLine + maxVisibleLines
-	if endLine > len(lines) {
-		endLine = len(lines)
+	// Center horizontally by the longest line
+	maxLineLen := layout.longest
+
+	gutterWidth := 0
+	var numbers []int
+	if data.ShowLineNumbers && len(lines) > 0 {
+		numbers = visibleLineNumbers(layout, scrollLine, len(lines), data.LineNumbering)
+		gutterWidth = lineNumberGutterWidth(lastLineNumber(layout, data.LineNumbering))
 	}
-	visibleLineCount := endLine - scrollLine
 
-	// Calculate vertical centering
-	// Each line takes 2 rows (text + space), calculate total height needed
-	contentHeight := visibleLineCount * 2
-	// Center the content vertically in available space
-	startY := (height - contentHeight) / 2
-	if startY < 4 {
-		startY = 4 // Keep minimum spacing from top
+	startX := typingTextStartX(width, maxLineLen, gutterWidth)
+	if data.HorizontalFocus {
+		startX = focusStartX(width/2, lines, data.CursorPos, startX)
 	}
 
-	// Center horizontally by finding the longest line
-	maxLineLen := 0
-	for _, line := range lines {
-		lineLen := 0
-		for _, ch := range line {
-			if ch != '\n' {
-				lineLen++
+	r.drawLineNumbers(numbers, startX-gutterWidth, gutterWidth, startY, height, data.Theme)
+	r.drawTypingText(lines, startX, startY, height, data)
+}
+
+// typingTextStartX returns the column of the first text character. The text
+// and its line number gutter (gutterWidth columns, 0 if hidden) are centered
+// together, so the text is shifted right by the gutter.
+func typingTextStartX(width, maxLineLen, gutterWidth int) int {
+	startX := (width-maxLineLen-gutterWidth)/2 + gutterWidth
+	if startX < gutterWidth {
+		startX = gutterWidth + 2
+	}
+	return startX
+}
+
+// focusStartX returns the column of the first text character that puts the
+// cursor at focusX, so the text scrolls under a cursor at a fixed column.
+// Characters shifted off screen are clipped. If the cursor is not on one of
+// the lines, startX is kept.
+func focusStartX(focusX int, lines []WrappedLine, cursorPos, startX int) int {
+	for i, line := range lines {
+		lineRunes := []rune(line.Text)
+		end := line.Start + len(lineRunes)
+		last := i == len(lines)-1
+		if cursorPos < line.Start || cursorPos > end || cursorPos == end && !last {
+			continue
+		}
+		// Combining marks and newlines take no column of their own
+		column := 0
+		for _, ch := range lineRunes[:cursorPos-line.Start] {
+			if ch != '\n' && !isCombiningMark(ch) {
+				column++
 			}
 		}
-		if lineLen > maxLineLen {
-			maxLineLen = lineLen
+		return focusX - column
+	}
+	return startX
+}
+
+// lineNumberGutterWidth returns the columns needed for line numbers up to
+// lastNumber: its digits plus a two column gap before the text.
+func lineNumberGutterWidth(lastNumber int) int {
+	return len(strconv.Itoa(max(lastNumber, 1))) + 2
+}
+
+// lastLineNumber returns the highest line number shown for the text: the
+// number of wrapped lines, or of source lines for LineNumbersLogical.
+func lastLineNumber(layout textLayout, numbering string) int {
+	if numbering == LineNumbersWrapped || len(layout.starts) == 0 {
+		return len(layout.starts)
+	}
+	return layout.sourceLines[len(layout.sourceLines)-1]
+}
+
+// visibleLineNumbers returns the number shown next to each of count wrapped
+// lines starting at firstLine. With logical numbering only the first wrapped
+// line of each source line is numbered; continuation lines get 0.
+func visibleLineNumbers(layout textLayout, firstLine, count int, numbering string) []int {
+	numbers := make([]int, 0, count)
+	if numbering == LineNumbersWrapped {
+		for i := range count {
+			numbers = append(numbers, firstLine+i+1)
 		}
+		return numbers
 	}
 
-	startX := (width - maxLineLen) / 2
-	if startX < 0 {
-		startX = 2
+	for i := firstLine; i < firstLine+count && i < len(layout.sourceLines); i++ {
+		if i == 0 || layout.sourceLines[i] != layout.sourceLines[i-1] {
+			numbers = append(numbers, layout.sourceLines[i])
+		} else {
+			numbers = append(numbers, 0)
+		}
 	}
+	return numbers
+}
 
-	r.drawTypingText(lines, startX, startY, height, scrollLine, maxVisibleLines, data)
+// drawLineNumbers draws the dimmed line number gutter of gutterWidth columns
+// starting at column x, one number per text line (0 leaves the row empty).
+// Numbers are right-aligned, leaving a two column gap before the text.
+func (r *Renderer) drawLineNumbers(numbers []int, x, gutterWidth, startY, height int, theme Theme) {
+	for i, n := range numbers {
+		y := startY + i*2
+		if y >= height-textBottomRows {
+			break
+		}
+		if n == 0 {
+			continue
+		}
+		label := strconv.Itoa(n)
+		r.DrawText(x+gutterWidth-2-len(label), y, label, theme.MenuDimText, theme.Background)
+	}
 }
 
-// drawTypingText renders each character of the typing test with appropriate styling.
-func (r *Renderer) drawTypingText(lines []string, startX, startY, height, scrollLine, maxVisibleLines int, data TypingViewData) {
-	currentY := startY
-	charIndex := 0
+// typingViewStartY returns the first row of the typing text, centering the
+// visible lines vertically between the margins. Each line takes 2 rows
+// (text + space).
+func typingViewStartY(height, visibleLineCount, topMargin, bottomMargin int) int {
+	topMargin, bottomMargin = FitTypingMargins(height, topMargin, bottomMargin)
+	startY := topMargin + (height-topMargin-bottomMargin-visibleLineCount*2)/2
+	if startY < 4+topMargin {
+		startY = 4 + topMargin // Keep minimum spacing from top
+	}
+	return startY
+}
 
-	// Use the cached rune slices from data (no conversion needed!)
-	sampleRunes := data.SampleRunes
-	userRunes := data.UserRunes
+// TypingAvailableHeight returns the rows available for the typing text on a
+// screen of the given height, without the chrome and the fitted margins.
+// IMPORTANT: app.go's scroll calculations and DrawTypingView must both use it.
+func TypingAvailableHeight(height, topMargin, bottomMargin int) int {
+	topMargin, bottomMargin = FitTypingMargins(height, topMargin, bottomMargin)
+	return height - typingChromeRows - topMargin - bottomMargin
+}
 
-	// Calculate the end line to render
-	endLine := scrollLine + maxVisibleLines
-	if endLine > len(lines) {
-		endLine = len(lines)
+// FitTypingMargins returns the margins to use on a screen of the given
+// height: as configured if at least one text line still fits between them,
+// otherwise none.
+func FitTypingMargins(height, topMargin, bottomMargin int) (int, int) {
+	if height-typingChromeRows-topMargin-bottomMargin < 2 {
+		return 0, 0
 	}
+	return topMargin, bottomMargin
+}
+
+// DrawCurrentWord renders the word being typed in large, letter-spaced form
+// above the word mode text. Typed letters are colored by correctness; extra
+// letters beyond the word are shown as errors. In blind mode typed letters all
+// get the neutral foreground instead, like in the text. Nothing is drawn if
+// there is no room between the title and the text.
+func (r *Renderer) DrawCurrentWord(word, typed string, theme Theme, blind bool) {
+	width, height := r.screen.Size()
 
-	// Skip characters before scrollLine
-	for lineIdx := 0; lineIdx < scrollLine && lineIdx < len(lines); lineIdx++ {
-		charIndex += len([]rune(lines[lineIdx]))
+	// Two rows above the word mode text, which always shows wordModeVisibleLines lines
+	y := typingViewStartY(height, wordModeVisibleLines, r.topMargin, r.bottomMargin) - 3
+	if y < 4 || word == "" {
+		return
 	}
 
-	// Render only visible lines
-	for lineIdx := scrollLine; lineIdx < endLine; lineIdx++ {
-		line := lines[lineIdx]
+	wordRunes := []rune(word)
+	typedRunes := []rune(typed)
+	letters := max(len(wordRunes), len(typedRunes))
+	x := (width - (letters*2 - 1)) / 2
+
+	for i := 0; i < letters; i++ {
+		ch := ' '
+		color := theme.TextDefault
+		switch {
+		case i >= len(wordRunes):
+			ch, color = typedRunes[i], theme.TextIncorrect
+		case i >= len(typedRunes):
+			ch = wordRunes[i]
+		case typedRunes[i] == wordRunes[i]:
+			ch, color = wordRunes[i], theme.TextCorrect
+		default:
+			ch, color = wordRunes[i], theme.TextIncorrect
+		}
+		if blind && i < len(typedRunes) {
+			color = theme.Foreground
+		}
+		style := tcell.StyleDefault.Foreground(color).Background(theme.Background).Bold(true)
+		if i == len(typedRunes) {
+			style = style.Underline(true) // Next letter to type
+		}
+		r.setContent(x+i*2, y, ch, nil, style)
+	}
+}
+
+// drawTypingText renders each character of the visible lines with appropriate styling.
+func (r *Renderer) drawTypingText(lines []WrappedLine, startX, startY, height int, data TypingViewData) {
+	currentY := startY
+
+	// Use the cached rune slices from data (no conversion needed!)
+	sampleRunes := data.SampleRunes
+	userRunes := data.UserRunes
+
+	for _, line := range lines {
+		charIndex := line.Start
 		currentX := startX
 
-		for _, ch := range line {
+		// The last drawn cell, which combining marks are added to
+		var cellX int
+		var cellChar rune
+		var cellMarks []rune
+		var cellStyle tcell.Style
+
+		for _, ch := range line.Text {
 			if charIndex >= len(sampleRunes) {
 				break
 			}
 
-			if currentY >= height-4 {
+			if currentY >= height-textBottomRows {
 				break
 			}
 
-			style, displayChar := r.getCharStyle(charIndex, ch, sampleRunes, userRunes, data)
+			// When proofreading, input is checked against the hidden text and
+			// corrected typos show the correct character once typed
+			expected := ch
+			if data.TargetRunes != nil {
+				expected = data.TargetRunes[charIndex]
+				if charIndex < len(userRunes) && userRunes[charIndex] == expected {
+					ch = expected
+				}
+			}
+
+			style, displayChar := r.getCharStyle(charIndex, ch, expected, userRunes, data)
+			mistyped := charIndex < len(userRunes) && userRunes[charIndex] != expected
+
+			// Combining marks (e.g. Devanagari vowel signs) share the cell of the
+			// character before them; a mistyped mark or the cursor on it shows on that cell
+			if isCombiningMark(ch) && currentX > startX {
+				cellMarks = append(cellMarks, displayChar)
+				if mistyped || charIndex == data.CursorPos {
+					cellStyle = style
+				}
+				if data.ShowMistypedOverlay && !data.BlindMode && mistyped {
+					r.drawMistypedChar(cellX, currentY-1, userRunes[charIndex], data.UnderlineWhitespace, data.Theme)
+				}
+				r.setContent(cellX, currentY, cellChar, cellMarks, cellStyle)
+				charIndex++
+				continue
+			}
 
 			// Draw mistyped character above if incorrect
-			if charIndex < len(userRunes) && userRunes[charIndex] != ch {
-				r.drawMistypedChar(currentX, currentY-1, userRunes[charIndex], data.Theme)
+			if data.ShowMistypedOverlay && !data.BlindMode && mistyped {
+				r.drawMistypedChar(currentX, currentY-1, userRunes[charIndex], data.UnderlineWhitespace, data.Theme)
 			}
 
 			// Draw the character
+			r.setContent(currentX, currentY, displayChar, nil, style)
+			cellX, cellChar, cellMarks, cellStyle = currentX, displayChar, nil, style
 			if ch != '\n' {
-				r.screen.SetContent(currentX, currentY, displayChar, nil, style)
 				currentX++
-			} else {
-				r.screen.SetContent(currentX, currentY, displayChar, nil, style)
 			}
 
 			charIndex++
 		}
 
+		if data.Generating && charIndex == len(sampleRunes) && currentY < height-textBottomRows {
+			style := tcell.StyleDefault.Foreground(data.Theme.MenuDimText).Background(data.Theme.Background).Dim(true)
+			r.setContent(currentX+1, currentY, r.glyphs.Pending, nil, style)
+		}
+
 		currentY += 2
 	}
 }
 
 // getCharStyle determines the style and display character for a given position.
-func (r *Renderer) getCharStyle(charIndex int, ch rune, sampleRunes, userRunes []rune, data TypingViewData) (tcell.Style, rune) {
+// ch is the displayed character and expected the one the input must match.
+func (r *Renderer) getCharStyle(charIndex int, ch, expected rune, userRunes []rune, data TypingViewData) (tcell.Style, rune) {
 	displayChar := ch
 	var style tcell.Style
 
 	if charIndex < len(userRunes) {
 		// Already typed
-		if userRunes[charIndex] == ch {
+		if data.BlindMode {
+			// Neutral color whether correct or not; mistakes are revealed in the results
+			style = tcell.StyleDefault.Foreground(data.Theme.Foreground).Background(data.Theme.Background)
+			if ch == '\n' {
+				displayChar = r.glyphs.Newline
+			}
+		} else if userRunes[charIndex] == expected {
 			// Correct
 			style = tcell.StyleDefault.Foreground(data.Theme.TextCorrect).Background(data.Theme.Background)
 		} else {
 			// Incorrect
 			style = tcell.StyleDefault.Foreground(data.Theme.TextIncorrect).Background(data.Theme.Background).Bold(true)
-			if ch == ' ' {
+			if ch == ' ' && !data.UnderlineWhitespace {
 				displayChar = '_'
 			} else if ch == '\n' {
-				displayChar = '↵'
+				displayChar = r.glyphs.Newline
+			}
+			if data.UnderlineWhitespace && isWordSeparator(ch) {
+				style = style.Underline(true)
 			}
 		}
 	} else if charIndex == data.CursorPos {
 		// Cursor position
 		style = tcell.StyleDefault.Foreground(data.Theme.TextCursor).Background(data.Theme.Background).Underline(true).Bold(true)
 		if ch == '\n' {
-			displayChar = '↵'
+			displayChar = r.glyphs.Newline
 		}
+	} else if charIndex < len(data.StopwordMask) && data.StopwordMask[charIndex] {
+		// Not yet typed function word, dimmed so content words stand out
+		style = tcell.StyleDefault.Foreground(data.Theme.MenuDimText).Background(data.Theme.Background).Dim(true)
 	} else {
 		// Not yet typed
 		style = tcell.StyleDefault.Foreground(data.Theme.TextDefault).Background(data.Theme.Background)
 		if ch == '\n' {
-			displayChar = '↵'
+			displayChar = r.glyphs.Newline
 		}
 	}
 
+	if data.BoldText {
+		style = style.Bold(true)
+	}
+
 	return style, displayChar
 }
 
 // drawMistypedChar renders a mistyped character above the expected character.
-func (r *Renderer) drawMistypedChar(x, y int, mistypedChar rune, theme Theme) {
-	if mistypedChar == ' ' {
+// Mistyped whitespace is shown as '_', or underlined at full brightness when
+// underlineWhitespace is set.
+func (r *Renderer) drawMistypedChar(x, y int, mistypedChar rune, underlineWhitespace bool, theme Theme) {
+	style := tcell.StyleDefault.Foreground(theme.TextIncorrect).Background(theme.Background).Dim(true)
+	if underlineWhitespace && isWordSeparator(mistypedChar) {
+		style = style.Dim(false).Underline(true)
+	}
+
+	if mistypedChar == ' ' && !underlineWhitespace {
 		mistypedChar = '_'
 	} else if mistypedChar == '\n' {
-		mistypedChar = '↵'
+		mistypedChar = r.glyphs.Newline
 	}
-	style := tcell.StyleDefault.Foreground(theme.TextIncorrect).Background(theme.Background).Dim(true)
-	r.screen.SetContent(x, y, mistypedChar, nil, style)
+	r.setContent(x, y, mistypedChar, nil, style)
 }
 
 // CommandMenuData contains all data needed to render the command menu.
@@ -286,6 +658,8 @@ type CommandMenuData struct {
 	FilteredCommands []Command
 	Selected         int
 	ScrollOffset     int
+	Preview          string // Sample words for the highlighted word set (empty if none)
+	History          bool   // Whether the menu lists the command history
 	Theme            Theme
 }
 
@@ -299,23 +673,278 @@ func (r *Renderer) DrawCommandMenu(data CommandMenuData) {
 	menuY := (height - menuHeight) / 2
 
 	r.drawBox(menuX, menuY, menuWidth, menuHeight, data.Theme)
-	r.drawBoxTitle(menuX, menuY, menuWidth, " command menu ", data.Theme)
+	title := " command menu "
+	if data.History {
+		title = " command history "
+	}
+	r.drawBoxTitle(menuX, menuY, menuWidth, title, data.Theme)
 	r.drawFilterInput(menuX, menuY, data.Filter, data.Theme)
 	r.drawCommandList(menuX, menuY, menuWidth, menuHeight, data)
+
+	if data.Preview != "" {
+		r.drawCommandPreview(menuX, menuY+menuHeight, menuWidth, data.Preview, data.Theme)
+	}
+}
+
+// drawCommandPreview draws a small panel below the command menu with sample
+// content for the highlighted command.
+func (r *Renderer) drawCommandPreview(x, y, width int, preview string, theme Theme) {
+	const previewHeight = 4
+	_, screenHeight := r.screen.Size()
+	if y+previewHeight > screenHeight {
+		return
+	}
+
+	r.drawBox(x, y, width, previewHeight, theme)
+	r.drawBoxTitle(x, y, width, " preview ", theme)
+
+	style := tcell.StyleDefault.Foreground(theme.TextDefault).Background(theme.Background)
+	lines := PreviewLines(preview, previewHeight-2, width-4)
+	for i, line := range lines {
+		for j, ch := range []rune(line) {
+			r.setContent(x+2+j, y+1+i, ch, nil, style)
+		}
+	}
+}
+
+// TextBrowserData contains all data needed to render the text browser.
+type TextBrowserData struct {
+	Texts        []TextSource
+	Selected     int
+	ScrollOffset int
+	Theme        Theme
+}
+
+// textBrowserBoxHeight returns the height of the text browser box on a screen
+// of the given height.
+func textBrowserBoxHeight(height int) int {
+	return min(height*2/3, defaultMaxVisibleCommands+5)
+}
+
+// TextBrowserRows returns how many entries of the text browser's list fit on
+// a screen of the given height.
+func TextBrowserRows(height int) int {
+	return textBrowserBoxHeight(height) - 4
+}
+
+// DrawTextBrowser renders the text browser overlay with a list of texts on the
+// left and a preview of the highlighted text on the right.
+func (r *Renderer) DrawTextBrowser(data TextBrowserData) {
+	width, height := r.screen.Size()
+
+	boxWidth := min(width*4/5, 90)
+	boxHeight := textBrowserBoxHeight(height)
+	boxX := (width - boxWidth) / 2
+	boxY := (height - boxHeight) / 2
+
+	r.drawBox(boxX, boxY, boxWidth, boxHeight, data.Theme)
+	r.drawBoxTitle(boxX, boxY, boxWidth, " texts ", data.Theme)
+
+	listWidth := boxWidth / 3
+	dividerX := boxX + listWidth
+	borderStyle := tcell.StyleDefault.Foreground(data.Theme.Border).Background(data.Theme.Background)
+	for y := boxY + 1; y < boxY+boxHeight-1; y++ {
+		r.setContent(dividerX, y, r.glyphs.BoxVertical, nil, borderStyle)
+	}
+
+	if len(data.Texts) == 0 {
+		r.drawNoResults(boxX, listWidth, boxY+1, data.Theme)
+		return
+	}
+
+	// Draw the list of texts
+	maxItems := TextBrowserRows(height)
+	startIdx := data.ScrollOffset
+	endIdx := min(startIdx+maxItems, len(data.Texts))
+	for i := startIdx; i < endIdx; i++ {
+		y := boxY + 2 + i - startIdx
+
+		var style tcell.Style
+		if i == data.Selected {
+			style = tcell.StyleDefault.Foreground(data.Theme.MenuSelectedFg).Background(data.Theme.MenuSelectedBg).Bold(true)
+		} else {
+			style = tcell.StyleDefault.Foreground(data.Theme.Foreground).Background(data.Theme.Background)
+		}
+
+		for x := boxX + 1; x < dividerX; x++ {
+			r.setContent(x, y, ' ', nil, style)
+		}
+
+		name := data.Texts[i].Name
+		maxLen := listWidth - 3
+		if len([]rune(name)) > maxLen && maxLen > 3 {
+			name = SafeRunes(name, maxLen-3) + "..."
+		}
+		for j, ch := range []rune(name) {
+			r.setContent(boxX+2+j, y, ch, nil, style)
+		}
+	}
+
+	// Draw the preview of the highlighted text
+	if data.Selected < 0 || data.Selected >= len(data.Texts) {
+		return
+	}
+	previewX := dividerX + 2
+	previewWidth := boxX + boxWidth - 2 - previewX
+	previewLines := PreviewLines(data.Texts[data.Selected].Content, min(textBrowserPreviewLines, boxHeight-4), previewWidth)
+	previewStyle := tcell.StyleDefault.Foreground(data.Theme.TextDefault).Background(data.Theme.Background)
+	for i, line := range previewLines {
+		for j, ch := range []rune(line) {
+			r.setContent(previewX+j, boxY+2+i, ch, nil, previewStyle)
+		}
+	}
+
+	help := "Up/Down: navigate  |  Enter: select  |  Esc: close"
+	helpX := boxX + (boxWidth-len(help))/2
+	r.DrawText(helpX, boxY+boxHeight-1, help, data.Theme.Help, data.Theme.Background)
+}
+
+// DiagnosticsData contains all data needed to render the diagnostics overlay.
+type DiagnosticsData struct {
+	Info  Diagnostics
+	Theme Theme
+}
+
+// DrawDiagnostics renders terminal capabilities, glyph samples and swatches of
+// the active theme's colors.
+func (r *Renderer) DrawDiagnostics(data DiagnosticsData) {
+	width, height := r.screen.Size()
+
+	boxWidth := min(width*4/5, 70)
+	boxHeight := min(height-2, 20)
+	boxX := (width - boxWidth) / 2
+	boxY := (height - boxHeight) / 2
+
+	r.drawBox(boxX, boxY, boxWidth, boxHeight, data.Theme)
+	r.drawBoxTitle(boxX, boxY, boxWidth, " diagnostics ", data.Theme)
+
+	info := data.Info
+	status := func(ok bool) string {
+		if ok {
+			return "ok"
+		}
+		return "missing"
+	}
+	trueColor := "no"
+	if info.TrueColor {
+		trueColor = "yes"
+	}
+
+	lines := []struct{ label, value string }{
+		{"Terminal", fmt.Sprintf("%s (LANG=%s)", info.Term, info.Lang)},
+		{"Size", fmt.Sprintf("%dx%d", info.Width, info.Height)},
+		{"Colors", fmt.Sprintf("%d (true color: %s)", info.Colors, trueColor)},
+		{"Braille", fmt.Sprintf("%s  %s", diagnosticsBrailleSample, status(info.Braille))},
+		{"Blocks", fmt.Sprintf("%s  %s", diagnosticsBlockSample, status(info.Blocks))},
+		{"Box", fmt.Sprintf("%s  %s", diagnosticsBoxSample, status(info.BoxDrawing))},
+	}
+
+	x := boxX + 3
+	y := boxY + 2
+	labelStyle := tcell.StyleDefault.Foreground(data.Theme.Help).Background(data.Theme.Background)
+	valueStyle := tcell.StyleDefault.Foreground(data.Theme.Foreground).Background(data.Theme.Background)
+	for _, line := range lines {
+		r.drawRunes(x, y, line.label+":", labelStyle)
+		r.drawRunes(x+11, y, line.value, valueStyle)
+		y++
+	}
+
+	// Theme color swatches in two columns
+	y++
+	r.drawRunes(x, y, "Theme: "+data.Theme.Name, labelStyle)
+	y++
+	swatches := []struct {
+		label string
+		color tcell.Color
+	}{
+		{"background", data.Theme.Background},
+		{"foreground", data.Theme.Foreground},
+		{"untyped", data.Theme.TextDefault},
+		{"correct", data.Theme.TextCorrect},
+		{"incorrect", data.Theme.TextIncorrect},
+		{"cursor", data.Theme.TextCursor},
+		{"title", data.Theme.Title},
+		{"border", data.Theme.Border},
+		{"help", data.Theme.Help},
+		{"menu selected", data.Theme.MenuSelectedBg},
+	}
+	columnWidth := (boxWidth - 6) / 2
+	for i, swatch := range swatches {
+		sx := x + (i%2)*columnWidth
+		sy := y + i/2
+		if sy >= boxY+boxHeight-2 {
+			break
+		}
+		swatchStyle := tcell.StyleDefault.Background(swatch.color)
+		r.setContent(sx, sy, ' ', nil, swatchStyle)
+		r.setContent(sx+1, sy, ' ', nil, swatchStyle)
+		r.drawRunes(sx+3, sy, swatch.label, valueStyle)
+	}
+
+	help := "Esc: close"
+	r.DrawText(boxX+(boxWidth-len(help))/2, boxY+boxHeight-1, help, data.Theme.Help, data.Theme.Background)
+}
+
+// drawRunes draws text one rune per cell, so non-ASCII glyphs are positioned correctly.
+func (r *Renderer) drawRunes(x, y int, text string, style tcell.Style) {
+	for i, ch := range []rune(text) {
+		r.setContent(x+i, y, ch, nil, style)
+	}
 }
 
 // ResultsData contains all data needed to render the results screen.
 type ResultsData struct {
-	WPM             float64
-	Accuracy        float64
-	MisspelledWords []string
-	WordCounts      map[string]int
-	WPMHistory      []WPMSnapshot // Timeline of WPM measurements
-	ErrorTimestamps []time.Time   // Timestamps when errors occurred
-	Leaderboard     []LeaderboardEntry
-	Theme           Theme
+	WPM              float64
+	RawWPM           float64 // Speed from all keystrokes, including mistakes
+	Consistency      float64 // Steadiness of the speed in percent (see Stats.GetConsistency)
+	Accuracy         float64
+	CategoryAccuracy map[string]float64 // Accuracy per character category (see Stats.GetCategoryAccuracy); empty hides the line
+	MisspelledWords  []string
+	WordCounts       map[string]int
+	WPMHistory       []WPMSnapshot     // Timeline of WPM measurements
+	ErrorTimestamps  []time.Time       // Timestamps when errors occurred
+	Duration         time.Duration     // How long the test took
+	CompletedAt      time.Time         // When the test was completed
+	CorrectedWords   int               // Words with an error that was fixed before finishing
+	BestCombo        int               // Longest run of correct keystrokes
+	XP               XPAward           // XP earned by the test; a zero Level hides the XP line
+	Challenge        *ChallengeOutcome // Verdict of the active challenge (nil without one)
+	Achievements     []Achievement     // Achievements first unlocked by the test
+	PreviousBest     float64           // Best WPM of earlier runs of the text and mode; 0 hides the comparison
+	CapsLockHint     bool              // Mistakes look like Caps Lock was on
+	Leaderboard      []LeaderboardEntry
+	ShowGraph        bool      // Draw the WPM timeline
+	HideMisspelled   bool      // Omit the misspelled words section (error tracking is off)
+	LatencyCounts    []int     // Keystroke intervals per LatencyBuckets bucket; nil hides the histogram
+	RhythmSeries     []float64 // Rolling variation of keystroke intervals (see Stats.GetRhythmSeries)
+	HelpText         string    // Footer listing the results screen keys
+	Theme            Theme
+}
+
+// ChallengeOutcome is the verdict of a challenge for the results screen (see
+// EvaluateChallenge).
+type ChallengeOutcome struct {
+	Passed bool
+	Reason string
+}
+
+// LatencyBuckets are the upper bounds (milliseconds) of the keystroke latency
+// histogram on the results screen; a last bucket collects slower intervals.
+var LatencyBuckets = []float64{50, 100, 150, 200, 300, 500}
+
+// latencyLabels returns the bar labels for LatencyBuckets.
+func latencyLabels() []string {
+	labels := make([]string, 0, len(LatencyBuckets)+1)
+	for _, bound := range LatencyBuckets {
+		labels = append(labels, fmt.Sprintf("%.0f", bound))
+	}
+	return append(labels, ">")
 }
 
+// resultsBoxHeightNoGraph is the results box height limit when the graph is hidden.
+// It fits the stats, a full leaderboard and a few lines of misspelled words.
+const resultsBoxHeightNoGraph = 30
+
 // DrawResults renders the results screen overlay.
 func (r *Renderer) DrawResults(data ResultsData) {
 	width, height := r.screen.Size()
@@ -323,6 +952,9 @@ func (r *Renderer) DrawResults(data ResultsData) {
 	// Make box larger to accommodate taller graph
 	boxWidth := min(width*4/5, 80)
 	boxHeight := min(height*4/5, 45)
+	if !data.ShowGraph {
+		boxHeight = min(boxHeight, resultsBoxHeightNoGraph)
+	}
 	boxX := (width - boxWidth) / 2
 	boxY := (height - boxHeight) / 2
 
@@ -331,33 +963,279 @@ func (r *Renderer) DrawResults(data ResultsData) {
 	r.drawResultsContent(boxX, boxY, boxWidth, boxHeight, data)
 }
 
+// LineTiming is the typing speed for one line of the sample text.
+type LineTiming struct {
+	Text string
+	WPM  float64 // 0 if the line wasn't completed
+}
+
+// LineTimingsData contains all data needed to render the per-line timing view.
+type LineTimingsData struct {
+	Lines []LineTiming
+	Theme Theme
+}
+
+// DrawLineTimings renders the typing speed of each line, highlighting the slowest one.
+func (r *Renderer) DrawLineTimings(data LineTimingsData) {
+	width, height := r.screen.Size()
+
+	boxWidth := min(width*4/5, 80)
+	boxHeight := min(height*4/5, 45)
+	boxX := (width - boxWidth) / 2
+	boxY := (height - boxHeight) / 2
+
+	r.drawBox(boxX, boxY, boxWidth, boxHeight, data.Theme)
+	r.drawBoxTitle(boxX, boxY, boxWidth, " line timing ", data.Theme)
+
+	// Find the slowest completed line
+	slowest := -1
+	for i, line := range data.Lines {
+		if line.WPM > 0 && (slowest == -1 || line.WPM < data.Lines[slowest].WPM) {
+			slowest = i
+		}
+	}
+
+	maxLines := boxHeight - 5
+	textWidth := boxWidth - 18
+	for i, line := range data.Lines {
+		y := boxY + 2 + i
+		if i >= maxLines {
+			r.DrawText(boxX+4, y, "...", data.Theme.MenuDimText, data.Theme.Background)
+			break
+		}
+
+		wpmText := "    -    "
+		if line.WPM > 0 {
+			wpmText = fmt.Sprintf("%5.1f wpm", line.WPM)
+		}
+		color := data.Theme.Foreground
+		if i == slowest && len(data.Lines) > 1 {
+			color = data.Theme.TextIncorrect
+		}
+		r.DrawText(boxX+4, y, wpmText, color, data.Theme.Background)
+
+		text := line.Text
+		if len([]rune(text)) > textWidth && textWidth > 3 {
+			text = SafeRunes(text, textWidth-3) + "..."
+		}
+		textStyle := tcell.StyleDefault.Foreground(data.Theme.TextDefault).Background(data.Theme.Background)
+		r.drawRunes(boxX+15, y, text, textStyle)
+	}
+
+	help := "'l' or Esc: back to results"
+	r.DrawText(boxX+(boxWidth-len(help))/2, boxY+boxHeight-2, help, data.Theme.Help, data.Theme.Background)
+}
+
+// ChartPoint is one labeled value in a line chart.
+type ChartPoint struct {
+	Label string
+	Value float64
+}
+
+// LifetimeStatsData contains all data needed to render the lifetime stats.
+type LifetimeStatsData struct {
+	Stats LifetimeStats
+	Theme Theme
+}
+
+// DrawLifetimeStats renders the lifetime totals in a small centered box.
+func (r *Renderer) DrawLifetimeStats(data LifetimeStatsData) {
+	width, height := r.screen.Size()
+
+	rows := []string{
+		fmt.Sprintf("Tests completed:  %d", data.Stats.TestsCompleted),
+		fmt.Sprintf("Words typed:      %d", data.Stats.Words),
+		fmt.Sprintf("Keystrokes:       %d", data.Stats.Keystrokes),
+		fmt.Sprintf("Time typing:      %s", formatDuration(data.Stats.TypingTime)),
+	}
+	help := "Esc: close"
+
+	boxWidth := min(width-4, 44)
+	boxHeight := len(rows) + 6
+	boxX := (width - boxWidth) / 2
+	boxY := (height - boxHeight) / 2
+
+	r.drawBox(boxX, boxY, boxWidth, boxHeight, data.Theme)
+	r.drawBoxTitle(boxX, boxY, boxWidth, " lifetime stats ", data.Theme)
+
+	for i, row := range rows {
+		r.DrawText(boxX+4, boxY+2+i, row, data.Theme.Foreground, data.Theme.Background)
+	}
+	r.DrawText(boxX+(boxWidth-len(help))/2, boxY+boxHeight-2, help, data.Theme.Help, data.Theme.Background)
+}
+
+// EmptyStateData contains all data needed to render the empty-state screen.
+type EmptyStateData struct {
+	TextsDirs []string // Where text files are loaded from
+	WordsDir  string   // Where word lists are loaded from
+	Help      string   // Key help shown below (see Keymap.EmptyStateHelp)
+	Theme     Theme
+}
+
+// DrawEmptyState explains that there is nothing to practice and where to put
+// texts or word lists, in a centered box.
+func (r *Renderer) DrawEmptyState(data EmptyStateData) {
+	width, height := r.screen.Size()
+
+	rows := []string{
+		"There are no texts or word lists to practice.",
+		"",
+		"Add .txt files to:",
+	}
+	for _, dir := range data.TextsDirs {
+		rows = append(rows, "  "+dir)
+	}
+	rows = append(rows,
+		"or word lists to:",
+		"  "+data.WordsDir,
+		"and restart rocketype, or pipe text in:",
+		"  cat file.txt | rocketype",
+	)
+	help := data.Help
+
+	longest := len(help)
+	for _, row := range rows {
+		longest = max(longest, len(row))
+	}
+	boxWidth := min(width-4, longest+8)
+	boxHeight := len(rows) + 6
+	boxX := (width - boxWidth) / 2
+	boxY := (height - boxHeight) / 2
+
+	r.drawBox(boxX, boxY, boxWidth, boxHeight, data.Theme)
+	r.drawBoxTitle(boxX, boxY, boxWidth, " nothing to type ", data.Theme)
+
+	style := tcell.StyleDefault.Foreground(data.Theme.Foreground).Background(data.Theme.Background)
+	for i, row := range rows {
+		r.drawRunes(boxX+4, boxY+2+i, SafeRunes(row, boxWidth-8), style)
+	}
+	r.DrawText(boxX+(boxWidth-len(help))/2, boxY+boxHeight-2, help, data.Theme.Help, data.Theme.Background)
+}
+
+// ConfirmData contains all data needed to render a confirmation box.
+type ConfirmData struct {
+	Message  string // Question or notice
+	Question bool   // Ask for y/n instead of any key to close
+	Theme    Theme
+}
+
+// DrawConfirm renders a centered box with a question to confirm with 'y', or a
+// notice that any key closes.
+func (r *Renderer) DrawConfirm(data ConfirmData) {
+	width, height := r.screen.Size()
+
+	help := "Any key: close"
+	if data.Question {
+		help = "y: yes  |  n or Esc: no"
+	}
+
+	boxWidth := min(width-4, max(len([]rune(data.Message)), len(help))+8)
+	boxHeight := 6
+	boxX := (width - boxWidth) / 2
+	boxY := (height - boxHeight) / 2
+
+	r.drawBox(boxX, boxY, boxWidth, boxHeight, data.Theme)
+	style := tcell.StyleDefault.Foreground(data.Theme.Foreground).Background(data.Theme.Background)
+	r.drawRunes(boxX+4, boxY+2, SafeRunes(data.Message, boxWidth-8), style)
+	r.DrawText(boxX+(boxWidth-len(help))/2, boxY+boxHeight-2, help, data.Theme.Help, data.Theme.Background)
+}
+
+// DrawPausedBanner renders the "PAUSED" banner over the typing view of a
+// paused test.
+func (r *Renderer) DrawPausedBanner(theme Theme) {
+	width, height := r.screen.Size()
+
+	const title = "PAUSED"
+	const help = "Ctrl+Space: resume"
+
+	boxWidth := min(width-4, len(help)+8)
+	boxHeight := 6
+	boxX := (width - boxWidth) / 2
+	boxY := (height - boxHeight) / 2
+
+	r.drawBox(boxX, boxY, boxWidth, boxHeight, theme)
+	style := tcell.StyleDefault.Foreground(theme.Foreground).Background(theme.Background).Bold(true)
+	r.drawRunes(boxX+(boxWidth-len(title))/2, boxY+2, title, style)
+	r.DrawText(boxX+(boxWidth-len(help))/2, boxY+boxHeight-2, help, theme.Help, theme.Background)
+}
+
+// ProgressReportData contains all data needed to render the progress report.
+type ProgressReportData struct {
+	Period ReportPeriod
+	Points []ChartPoint // Average WPM per period, oldest first
+	Tests  int          // Number of tests in the plotted periods
+	Theme  Theme
+}
+
+// DrawProgressReport renders the average WPM per day or week as a line graph.
+func (r *Renderer) DrawProgressReport(data ProgressReportData) {
+	width, height := r.screen.Size()
+
+	boxWidth := min(width*4/5, 80)
+	boxHeight := min(height*4/5, 24)
+	boxX := (width - boxWidth) / 2
+	boxY := (height - boxHeight) / 2
+
+	r.drawBox(boxX, boxY, boxWidth, boxHeight, data.Theme)
+	r.drawBoxTitle(boxX, boxY, boxWidth, " progress report ", data.Theme)
+
+	contentX := boxX + 3
+	contentWidth := boxWidth - 6
+
+	if len(data.Points) < 2 {
+		msg := fmt.Sprintf("Complete tests on at least two different %ss to see a trend.", data.Period)
+		r.DrawText(boxX+(boxWidth-len(msg))/2, boxY+boxHeight/2, msg, data.Theme.MenuDimText, data.Theme.Background)
+	} else {
+		series := make([]float64, len(data.Points))
+		labels := make([]string, len(data.Points))
+		for i, point := range data.Points {
+			series[i] = point.Value
+			labels[i] = point.Label
+		}
+
+		r.DrawLineChart(contentX, boxY+2, contentWidth, boxHeight-6, series, ChartOptions{
+			Title:       fmt.Sprintf("Average WPM per %s", data.Period),
+			XLabels:     EvenlySpacedLabels(labels),
+			Interpolate: true,
+			Theme:       data.Theme,
+		})
+
+		last := data.Points[len(data.Points)-1]
+		summary := fmt.Sprintf("%d tests  |  latest %s: %.1f wpm", data.Tests, data.Period, last.Value)
+		r.DrawText(boxX+(boxWidth-len(summary))/2, boxY+boxHeight-3, summary, data.Theme.Foreground, data.Theme.Background)
+	}
+
+	help := "'d': by day  |  'w': by week  |  Esc: close"
+	r.DrawText(boxX+(boxWidth-len(help))/2, boxY+boxHeight-2, help, data.Theme.Help, data.Theme.Background)
+}
+
 // drawBox draws a bordered box at the specified position.
 func (r *Renderer) drawBox(x, y, width, height int, theme Theme) {
 	borderStyle := tcell.StyleDefault.Foreground(theme.Border).Background(theme.Background)
 	bgStyle := tcell.StyleDefault.Background(theme.Background)
 
 	// Top border
-	r.screen.SetContent(x, y, '┌', nil, borderStyle)
+	r.setContent(x, y, r.glyphs.BoxTopLeft, nil, borderStyle)
 	for i := x + 1; i < x+width-1; i++ {
-		r.screen.SetContent(i, y, '─', nil, borderStyle)
+		r.setContent(i, y, r.glyphs.BoxHorizontal, nil, borderStyle)
 	}
-	r.screen.SetContent(x+width-1, y, '┐', nil, borderStyle)
+	r.setContent(x+width-1, y, r.glyphs.BoxTopRight, nil, borderStyle)
 
 	// Side borders and fill
 	for j := y + 1; j < y+height-1; j++ {
-		r.screen.SetContent(x, j, '│', nil, borderStyle)
+		r.setContent(x, j, r.glyphs.BoxVertical, nil, borderStyle)
 		for i := x + 1; i < x+width-1; i++ {
-			r.screen.SetContent(i, j, ' ', nil, bgStyle)
+			r.setContent(i, j, ' ', nil, bgStyle)
 		}
-		r.screen.SetContent(x+width-1, j, '│', nil, borderStyle)
+		r.setContent(x+width-1, j, r.glyphs.BoxVertical, nil, borderStyle)
 	}
 
 	// Bottom border
-	r.screen.SetContent(x, y+height-1, '└', nil, borderStyle)
+	r.setContent(x, y+height-1, r.glyphs.BoxBottomLeft, nil, borderStyle)
 	for i := x + 1; i < x+width-1; i++ {
-		r.screen.SetContent(i, y+height-1, '─', nil, borderStyle)
+		r.setContent(i, y+height-1, r.glyphs.BoxHorizontal, nil, borderStyle)
 	}
-	r.screen.SetContent(x+width-1, y+height-1, '┘', nil, borderStyle)
+	r.setContent(x+width-1, y+height-1, r.glyphs.BoxBottomRight, nil, borderStyle)
 }
 
 // drawBoxTitle draws a centered title on the top border of a box.
@@ -374,7 +1252,7 @@ func (r *Renderer) drawFilterInput(menuX, menuY int, filter string, theme Theme)
 	// Draw cursor
 	cursorX := menuX + 2 + len(filterPrompt)
 	cursorStyle := tcell.StyleDefault.Foreground(theme.TextCursor).Background(theme.Background)
-	r.screen.SetContent(cursorX, menuY+2, '▏', nil, cursorStyle)
+	r.setContent(cursorX, menuY+2, r.glyphs.InputCursor, nil, cursorStyle)
 }
 
 // drawCommandList draws the list of filtered commands in the command menu.
@@ -382,7 +1260,7 @@ func (r *Renderer) drawCommandList(menuX, menuY, menuWidth, menuHeight int, data
 	// Draw separator
 	borderStyle := tcell.StyleDefault.Foreground(data.Theme.Border).Background(data.Theme.Background)
 	for x := menuX + 1; x < menuX+menuWidth-1; x++ {
-		r.screen.SetContent(x, menuY+3, '─', nil, borderStyle)
+		r.setContent(x, menuY+3, r.glyphs.BoxHorizontal, nil, borderStyle)
 	}
 
 	maxCommands := menuHeight - 5
@@ -400,11 +1278,11 @@ func (r *Renderer) drawCommandList(menuX, menuY, menuWidth, menuHeight int, data
 	// Draw scroll indicators if needed
 	if startIdx > 0 {
 		// Show "more above" indicator
-		r.DrawText(menuX+menuWidth-3, menuY+3, "▲", data.Theme.Border, data.Theme.Background)
+		r.DrawText(menuX+menuWidth-3, menuY+3, string(r.glyphs.ScrollUp), data.Theme.Border, data.Theme.Background)
 	}
 	if endIdx < len(data.FilteredCommands) {
 		// Show "more below" indicator
-		r.DrawText(menuX+menuWidth-3, menuY+menuHeight-2, "▼", data.Theme.Border, data.Theme.Background)
+		r.DrawText(menuX+menuWidth-3, menuY+menuHeight-2, string(r.glyphs.ScrollDown), data.Theme.Border, data.Theme.Background)
 	}
 
 	// Draw visible commands
@@ -422,7 +1300,7 @@ func (r *Renderer) drawCommandList(menuX, menuY, menuWidth, menuHeight int, data
 
 		// Clear line with style
 		for x := menuX + 2; x < menuX+menuWidth-2; x++ {
-			r.screen.SetContent(x, y, ' ', nil, style)
+			r.setContent(x, y, ' ', nil, style)
 		}
 
 		// Draw command name (truncated if needed)
@@ -433,7 +1311,7 @@ func (r *Renderer) drawCommandList(menuX, menuY, menuWidth, menuHeight int, data
 		}
 
 		for j, ch := range displayText {
-			r.screen.SetContent(menuX+2+j, y, ch, nil, style)
+			r.setContent(menuX+2+j, y, ch, nil, style)
 		}
 	}
 }
@@ -457,6 +1335,7 @@ func (r *Renderer) drawResultsContent(boxX, boxY, boxWidth, boxHeight int, data
 		r.DrawText(contentX, contentY, wpmText, data.Theme.Foreground, data.Theme.Background)
 		accuracyText := fmt.Sprintf("Accuracy: %.1f%%", data.Accuracy)
 		r.DrawText(contentX, contentY+1, accuracyText, data.Theme.Foreground, data.Theme.Background)
+		r.drawResultsTime(contentX, contentY+2, data)
 		helpText := "Press Enter or 'r' to restart  |  Esc to quit"
 		helpX := boxX + (boxWidth-len(helpText))/2
 		r.DrawText(helpX, boxY+boxHeight-2, helpText, data.Theme.Help, data.Theme.Background)
@@ -468,9 +1347,21 @@ func (r *Renderer) drawResultsContent(boxX, boxY, boxWidth, boxHeight int, data
 	const minChartWidth = 18
 	const chartDefaultHeight = 17
 
-	wantChart := len(data.WPMHistory) > 1
+	wantChart := data.ShowGraph && len(data.WPMHistory) > 1
 	chartHeight := min(chartDefaultHeight, contentHeight-2)
-	statsHeight := 3
+	statsHeight := 5
+	if data.XP.Level > 0 {
+		statsHeight++
+	}
+	if data.Challenge != nil {
+		statsHeight++
+	}
+	if len(data.CategoryAccuracy) > 0 {
+		statsHeight++
+	}
+	if len(data.Achievements) > 0 {
+		statsHeight++
+	}
 	leaderboardMinHeight := 3
 	separatorHeight := 2
 	misspellMinHeight := 2
@@ -502,14 +1393,71 @@ func (r *Renderer) drawResultsContent(boxX, boxY, boxWidth, boxHeight int, data
 
 	currentY := contentY
 
+	separator := fmt.Sprintf("  %c  ", r.glyphs.Separator)
+	wpmText := formatResultSpeed(data.WPM, data.RawWPM, r.speedUnit, separator) + separator + fmt.Sprintf("Best combo: %d", data.BestCombo)
+
+	// Draw the latency histogram right of the stats if they leave room for it;
+	// the speed line is the widest unless it is short
+	statsTextWidth := max(36, len([]rune(wpmText)))
+	hintWidth := leftWidth
+	if hasKeystrokeIntervals(data.LatencyCounts) && !splitChart {
+		histogramWidth := HistogramWidth(len(data.LatencyCounts))
+		if contentWidth >= statsTextWidth+chartGap+histogramWidth {
+			histogramX := contentX + contentWidth - histogramWidth
+			r.DrawHistogram(histogramX, contentY, "Key intervals (ms)", latencyLabels(), data.LatencyCounts, data.Theme)
+			hintWidth = histogramX - chartGap - contentX
+		}
+	}
+
 	// Draw stats (left column)
-	wpmText := fmt.Sprintf("WPM: %.1f", data.WPM)
-	r.DrawText(contentX, currentY, wpmText, data.Theme.Foreground, data.Theme.Background)
+	style := tcell.StyleDefault.Foreground(data.Theme.Foreground).Background(data.Theme.Background)
+	r.drawRunes(contentX, currentY, wpmText, style)
 	currentY++
 
 	accuracyText := fmt.Sprintf("Accuracy: %.1f%%", data.Accuracy)
-	r.DrawText(contentX, currentY, accuracyText, data.Theme.Foreground, data.Theme.Background)
-	currentY += 2
+	accuracyText += fmt.Sprintf("  %c  Corrected: %d", r.glyphs.Separator, data.CorrectedWords)
+	r.drawRunes(contentX, currentY, accuracyText, style)
+	currentY++
+
+	if len(data.CategoryAccuracy) > 0 {
+		categoryText := formatCategoryAccuracy(data.CategoryAccuracy, separator)
+		r.drawRunes(contentX, currentY, SafeRunes(categoryText, hintWidth), style)
+		currentY++
+	}
+
+	consistencyText := fmt.Sprintf("Consistency: %.0f%%", data.Consistency)
+	r.drawRunes(contentX, currentY, consistencyText, style)
+	if data.PreviousBest > 0 {
+		r.drawResultsBest(contentX+len(consistencyText), currentY, separator, data)
+	}
+	currentY++
+
+	if data.Challenge != nil {
+		r.drawResultsChallenge(contentX, currentY, hintWidth, data)
+		currentY++
+	}
+
+	r.drawResultsTime(contentX, currentY, data)
+	currentY++
+
+	if data.XP.Level > 0 {
+		r.drawResultsXP(contentX, currentY, data)
+		currentY++
+	}
+
+	if len(data.Achievements) > 0 {
+		r.drawResultsAchievements(contentX, currentY, hintWidth, data)
+		currentY++
+	}
+
+	if data.CapsLockHint {
+		hint := "Caps Lock on? Several letters in a row had the wrong case."
+		if len(hint) > hintWidth {
+			hint = "Caps Lock on?"
+		}
+		r.DrawText(contentX, currentY, hint, data.Theme.TextIncorrect, data.Theme.Background)
+	}
+	currentY++
 
 	// Draw WPM timeline graph
 	if wantChart {
@@ -521,18 +1469,48 @@ func (r *Renderer) drawResultsContent(boxX, boxY, boxWidth, boxHeight int, data
 			graphWidth := leftWidth
 			r.drawWPMGraph(contentX, currentY, graphWidth, chartHeight, data.WPMHistory, data.ErrorTimestamps, data.Theme)
 			currentY += chartHeight + 2
+
+			// Draw the rhythm chart below the timeline if there is room left
+			remaining := contentHeight - (currentY - contentY) - leaderboardMinHeight - separatorHeight - misspellMinHeight
+			if len(data.RhythmSeries) > 1 && remaining >= rhythmChartHeight+2 {
+				r.drawRhythmGraph(contentX, currentY, graphWidth, rhythmChartHeight, data.RhythmSeries, data.Theme)
+				currentY += rhythmChartHeight + 2
+			}
 		}
 	}
 
 	// Draw leaderboard table (left column)
 	currentY = r.drawLeaderboardTable(contentX-4, boxY, currentY, leftWidth+8, boxHeight, data)
 
+	// Draw misspelled words (left column)
+	if !data.HideMisspelled {
+		r.drawMisspelledWords(contentX, currentY, leftWidth, boxY, boxHeight, data)
+	}
+
+	// Draw help text
+	helpX := boxX + (boxWidth-len([]rune(data.HelpText)))/2
+	r.DrawText(helpX, boxY+boxHeight-2, data.HelpText, data.Theme.Help, data.Theme.Background)
+}
+
+// hasKeystrokeIntervals reports whether a latency histogram has any intervals.
+func hasKeystrokeIntervals(counts []int) bool {
+	for _, count := range counts {
+		if count > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// drawMisspelledWords draws a separator followed by the misspelled words, wrapped
+// to the left column width, or a note that there were no mistakes.
+func (r *Renderer) drawMisspelledWords(contentX, currentY, leftWidth, boxY, boxHeight int, data ResultsData) {
 	// Draw separator (left column)
 	borderStyle := tcell.StyleDefault.Foreground(data.Theme.Border).Background(data.Theme.Background)
 	separatorStart := contentX
 	separatorEnd := contentX + leftWidth
 	for x := separatorStart; x < separatorEnd; x++ {
-		r.screen.SetContent(x, currentY, '─', nil, borderStyle)
+		r.setContent(x, currentY, r.glyphs.BoxHorizontal, nil, borderStyle)
 	}
 	currentY += 2
 
@@ -601,11 +1579,79 @@ func (r *Renderer) drawResultsContent(boxX, boxY, boxWidth, boxHeight int, data
 			}
 		}
 	}
+}
 
-	// Draw help text
-	helpText := "Press Enter or 'r' to restart  |  Esc to quit"
-	helpX := boxX + (boxWidth-len(helpText))/2
-	r.DrawText(helpX, boxY+boxHeight-2, helpText, data.Theme.Help, data.Theme.Background)
+// drawResultsTime draws how long the test took and when it was completed.
+func (r *Renderer) drawResultsTime(x, y int, data ResultsData) {
+	timeText := "Time: " + formatDuration(data.Duration)
+	if !data.CompletedAt.IsZero() {
+		timeText += fmt.Sprintf("  %c  %s", r.glyphs.Separator, data.CompletedAt.Format("3:04 PM"))
+	}
+	style := tcell.StyleDefault.Foreground(data.Theme.Foreground).Background(data.Theme.Background)
+	r.drawRunes(x, y, timeText, style)
+}
+
+// drawResultsXP draws the XP earned by the test and the resulting level.
+func (r *Renderer) drawResultsXP(x, y int, data ResultsData) {
+	xpText := fmt.Sprintf("+%d XP  %c  Level %d", data.XP.Gained, r.glyphs.Separator, data.XP.Level)
+	style := tcell.StyleDefault.Foreground(data.Theme.Foreground).Background(data.Theme.Background)
+	r.drawRunes(x, y, xpText, style)
+	if data.XP.LeveledUp {
+		levelUpStyle := tcell.StyleDefault.Foreground(data.Theme.Title).Background(data.Theme.Background).Bold(true)
+		r.drawRunes(x+len([]rune(xpText))+2, y, "Level up!", levelUpStyle)
+	}
+}
+
+// drawResultsChallenge shows PASS or FAIL for the active challenge followed by
+// the reason, truncated to width.
+func (r *Renderer) drawResultsChallenge(x, y, width int, data ResultsData) {
+	verdict, color := "FAIL", data.Theme.TextIncorrect
+	if data.Challenge.Passed {
+		verdict, color = "PASS", data.Theme.TextCorrect
+	}
+	r.drawRunes(x, y, verdict, tcell.StyleDefault.Foreground(color).Background(data.Theme.Background).Bold(true))
+	style := tcell.StyleDefault.Foreground(data.Theme.Foreground).Background(data.Theme.Background)
+	r.drawRunes(x+len(verdict)+2, y, SafeRunes(data.Challenge.Reason, max(width-len(verdict)-2, 0)), style)
+}
+
+// formatCategoryAccuracy lists the accuracy of the typed character categories
+// in CharCategories order, e.g. "Letters 99%  ·  Digits 90%".
+func formatCategoryAccuracy(accuracy map[string]float64, separator string) string {
+	var parts []string
+	for _, category := range CharCategories {
+		if value, ok := accuracy[category]; ok {
+			parts = append(parts, fmt.Sprintf("%s %.0f%%", category, value))
+		}
+	}
+	return strings.Join(parts, separator)
+}
+
+// drawResultsBest compares the WPM with the previous best: "New best!" when it
+// was beaten, otherwise the best itself.
+func (r *Renderer) drawResultsBest(x, y int, separator string, data ResultsData) {
+	style := tcell.StyleDefault.Foreground(data.Theme.Foreground).Background(data.Theme.Background)
+	r.drawRunes(x, y, separator, style)
+	x += len([]rune(separator))
+	if data.WPM > data.PreviousBest {
+		r.drawRunes(x, y, "New best!", style.Foreground(data.Theme.TextCorrect).Bold(true))
+		return
+	}
+	r.drawRunes(x, y, fmt.Sprintf("Best: %.0f WPM", data.PreviousBest), style)
+}
+
+// drawResultsAchievements lists the achievements unlocked by the test,
+// truncated to width.
+func (r *Renderer) drawResultsAchievements(x, y, width int, data ResultsData) {
+	names := make([]string, len(data.Achievements))
+	for i, achievement := range data.Achievements {
+		names[i] = achievement.Name
+	}
+	text := []rune("Unlocked: " + strings.Join(names, ", "))
+	if len(text) > width {
+		text = text[:max(width, 0)]
+	}
+	style := tcell.StyleDefault.Foreground(data.Theme.Title).Background(data.Theme.Background).Bold(true)
+	r.drawRunes(x, y, string(text), style)
 }
 
 func (r *Renderer) drawLeaderboardTable(boxX, boxY, startY, boxWidth, boxHeight int, data ResultsData) int {
@@ -704,69 +1750,45 @@ func (r *Renderer) formatLeaderboardDivider(widths []int) string {
 	return strings.Join(parts, " ")
 }
 
-// wrapText breaks text into lines that fit within maxWidth characters.
-// Respects explicit newlines and attempts to break at word boundaries.
-func wrapText(text string, maxWidth int) []string {
-	var lines []string
-	var currentLine []rune
-
-	for _, ch := range text {
-		if ch == '\n' {
-			lines = append(lines, string(currentLine)+string(ch))
-			currentLine = []rune{}
-		} else if len(currentLine) >= maxWidth {
-			// Auto-wrap at maxWidth - try to break at last space
-			breakPoint := len(currentLine)
-			for i := len(currentLine) - 1; i >= 0; i-- {
-				if currentLine[i] == ' ' {
-					breakPoint = i + 1
-					break
-				}
-			}
-
-			lines = append(lines, string(currentLine[:breakPoint]))
-			currentLine = currentLine[breakPoint:]
-			currentLine = append(currentLine, ch)
-		} else {
-			currentLine = append(currentLine, ch)
-		}
-	}
-
-	if len(currentLine) > 0 {
-		lines = append(lines, string(currentLine))
-	}
-
-	return lines
+// textLayout returns the wrapped layout of a typing text, reusing the last
+// one while the text and width stay the same.
+func (r *Renderer) textLayout(runes []rune, maxWidth int) textLayout {
+	return r.layout.get(runes, maxWidth)
 }
 
 // CalculateCursorLine determines which wrapped line the cursor is on.
 // Returns the line index (0-based) within the wrapped lines.
 func CalculateCursorLine(text string, cursorPos int, maxWidth int) int {
-	if cursorPos < 0 {
+	return cursorLineAt(wrappedLineStarts([]rune(text), maxWidth), cursorPos)
+}
+
+// cursorLineAt returns the wrapped line the cursor is on, given where each
+// line starts.
+func cursorLineAt(starts []int, cursorPos int) int {
+	if cursorPos < 0 || len(starts) == 0 {
 		return 0
 	}
 
-	lines := wrapText(text, maxWidth)
-	charCount := 0
-
-	for lineIdx, line := range lines {
-		lineLen := len([]rune(line))
-		if charCount+lineLen > cursorPos {
-			return lineIdx
-		}
-		charCount += lineLen
-	}
+	// The cursor is on the last line starting at or before it
+	line := sort.SearchInts(starts, cursorPos+1) - 1
+	return max(line, 0)
+}
 
-	// Cursor is at or beyond the end
-	if len(lines) > 0 {
-		return len(lines) - 1
+// ReviewScrollLine returns the top visible line when reviewing the text with
+// Home (toEnd false) or End (toEnd true). The viewport stays within the text:
+// End shows the last maxVisibleLines lines, or the whole text if it fits.
+func ReviewScrollLine(toEnd bool, maxVisibleLines, totalLines int) int {
+	if !toEnd || totalLines <= maxVisibleLines {
+		return 0
 	}
-	return 0
+	return totalLines - maxVisibleLines
 }
 
 // CalculateScrollLine calculates the optimal scroll line to keep the cursor visible.
-// It keeps the cursor positioned with space above and below for context.
-func CalculateScrollLine(cursorLine, maxVisibleLines, totalLines int) int {
+// The anchor (ScrollAnchorTop, ScrollAnchorCenter or ScrollAnchorBottom) decides where
+// in the viewport the cursor line is held; unknown anchors behave like ScrollAnchorTop.
+// At least one line stays visible below the cursor.
+func CalculateScrollLine(cursorLine, maxVisibleLines, totalLines int, anchor string) int {
 	// If all text fits on screen, don't scroll
 	if totalLines <= maxVisibleLines {
 		return 0
@@ -775,8 +1797,16 @@ func CalculateScrollLine(cursorLine, maxVisibleLines, totalLines int) int {
 	// Desired buffer: keep at least 1 line visible below cursor
 	const minBufferBelow = 1
 
-	// Desired position: top third of viewport (gives more context below)
-	desiredCursorPosition := maxVisibleLines / 3
+	var desiredCursorPosition int
+	switch anchor {
+	case ScrollAnchorCenter:
+		desiredCursorPosition = maxVisibleLines / 2
+	case ScrollAnchorBottom:
+		desiredCursorPosition = maxVisibleLines
+	default:
+		// Top third of viewport (gives more context below)
+		desiredCursorPosition = maxVisibleLines / 3
+	}
 
 	// But ensure we leave room for the buffer below
 	maxCursorPosition := maxVisibleLines - minBufferBelow - 1
@@ -800,15 +1830,6 @@ func CalculateScrollLine(cursorLine, maxVisibleLines, totalLines int) int {
 	return scrollLine
 }
 
-const (
-	wpmIncrement       = 25 // Y-axis label increment
-	yAxisLabelWidth    = 4  // Width of Y-axis labels
-	yAxisPadding       = 2  // Padding between Y-axis labels and graph
-	graphHeightPadding = 3  // Space for title and X-axis
-	brailleDotsWidth   = 2  // Braille character width in dots
-	brailleDotsHeight  = 4  // Braille character height in dots
-)
-
 // drawWPMGraph renders a timeline graph of WPM changes over time.
 // The graph uses braille characters to draw a smooth line chart showing typing speed progression.
 //
@@ -819,206 +1840,74 @@ const (
 //   - errorTimestamps: timestamps when typing errors occurred
 //   - theme: color theme for rendering
 func (r *Renderer) drawWPMGraph(x, y, width, height int, history []WPMSnapshot, errorTimestamps []time.Time, theme Theme) {
-	if len(history) < 2 || width < 10 || height < 3 {
+	if len(history) < 2 {
 		return
 	}
 
-	// Calculate time range for the entire graph
-	startTime := history[0].Timestamp
-	endTime := history[len(history)-1].Timestamp
-	totalDuration := endTime.Sub(startTime).Seconds()
-
-	// Find max WPM for scaling
-	maxWPM := history[0].WPM
-	for _, snapshot := range history {
-		if snapshot.WPM > maxWPM {
-			maxWPM = snapshot.WPM
-		}
-	}
-
-	// Always start at 0 and round up to nearest wpmIncrement
-	minWPM := 0.0
-	maxWPM = float64(int(maxWPM/wpmIncrement)+1) * wpmIncrement
-	if maxWPM < wpmIncrement {
-		maxWPM = wpmIncrement
-	}
-
-	// Draw title
-	title := "WPM Timeline"
-	titleX := x + (width-len(title))/2
-	r.DrawText(titleX, y, title, theme.Title, theme.Background)
-	y++ // Move down after title
-
-	graphHeight := height - graphHeightPadding
-	graphWidth := width - (yAxisLabelWidth + yAxisPadding + 1)
-
-	// Draw Y-axis labels at every wpmIncrement
-	numLabels := int(maxWPM/wpmIncrement) + 1
-	for i := 0; i < numLabels; i++ {
-		wpmValue := float64(i) * wpmIncrement
-		label := fmt.Sprintf("%*.0f", yAxisLabelWidth, wpmValue) // Right-align
-
-		// Calculate Y position for this label (inverted)
-		normalized := (wpmValue - minWPM) / (maxWPM - minWPM)
-		labelY := y + graphHeight - 1 - int(normalized*float64(graphHeight-1))
-
-		r.DrawText(x, labelY, label, theme.Help, theme.Background)
-	}
-
-	// Starting position for graph content
-	graphX := x + yAxisLabelWidth + yAxisPadding
-	graphY := y
-
-	// Initialize graph area with spaces
-	graphStyle := tcell.StyleDefault.Foreground(theme.TextDefault).Background(theme.Background)
-	for gy := 0; gy < graphHeight; gy++ {
-		for gx := 0; gx < graphWidth; gx++ {
-			r.screen.SetContent(graphX+gx, graphY+gy, ' ', nil, graphStyle)
-		}
+	series := make([]float64, len(history))
+	for i, snapshot := range history {
+		series[i] = snapshot.WPM
 	}
 
-	// Calculate points for the graph
-	points := make([]int, graphWidth)
-	for i := range points {
-		// Map column to history index
-		historyIdx := int(float64(i) / float64(graphWidth-1) * float64(len(history)-1))
-		if historyIdx >= len(history) {
-			historyIdx = len(history) - 1
-		}
-
-		wpm := history[historyIdx].WPM
-
-		// Scale WPM to graph height (inverted because Y increases downward)
-		normalized := (wpm - minWPM) / (maxWPM - minWPM)
-		if normalized < 0 {
-			normalized = 0
-		}
-		if normalized > 1 {
-			normalized = 1
-		}
-
-		// Convert to screen coordinates (invert Y)
-		points[i] = graphHeight - 1 - int(normalized*float64(graphHeight-1))
-	}
-
-	// Draw the graph using braille characters
-	r.drawBrailleLine(graphX, graphY, graphWidth, graphHeight, points, theme.TextCorrect, theme.Background)
-
-	// Draw error markers
-	r.drawErrorMarkers(graphX, graphY, graphWidth, graphHeight, totalDuration, startTime, errorTimestamps, theme)
-
-	// Draw X-axis with time labels
-	r.drawTimeAxisLabels(graphX, graphY, graphWidth, graphHeight, totalDuration, theme)
-}
-
-// drawBrailleLine draws a smooth line through the given points using braille characters.
-func (r *Renderer) drawBrailleLine(graphX, graphY, graphWidth, graphHeight int, points []int, fg, bg tcell.Color) {
-	lineStyle := tcell.StyleDefault.Foreground(fg).Background(bg).Bold(true)
-
-	// Braille characters are 2 dots wide by 4 dots tall
-	brailleWidth := graphWidth
-	brailleHeight := graphHeight
-	brailleGrid := make([][]uint8, brailleHeight)
-	for i := range brailleGrid {
-		brailleGrid[i] = make([]uint8, brailleWidth)
-	}
-
-	// Map each point to braille grid with sub-pixel precision
-	for i := 0; i < len(points)-1; i++ {
-		// Scale to braille sub-pixel resolution
-		x1, y1 := i*brailleDotsWidth, points[i]*brailleDotsHeight
-		x2, y2 := (i+1)*brailleDotsWidth, points[i+1]*brailleDotsHeight
-
-		// Draw line segment using Bresenham's algorithm
-		drawBrailleLineSegment(brailleGrid, brailleWidth, brailleHeight, x1, y1, x2, y2)
-	}
-
-	// Render braille characters
-	brailleBase := rune(0x2800)
-	for cellY := 0; cellY < brailleHeight; cellY++ {
-		for cellX := 0; cellX < brailleWidth; cellX++ {
-			if brailleGrid[cellY][cellX] != 0 {
-				brailleChar := brailleBase + rune(brailleGrid[cellY][cellX])
-				r.screen.SetContent(graphX+cellX, graphY+cellY, brailleChar, nil, lineStyle)
-			}
-		}
-	}
+	// Calculate time range for the entire graph
+	startTime := history[0].Timestamp
+	totalDuration := history[len(history)-1].Timestamp.Sub(startTime).Seconds()
+
+	r.DrawLineChart(x, y, width, height, series, ChartOptions{
+		Title:       "WPM Timeline",
+		XLabels:     timeAxisLabels(totalDuration),
+		Markers:     errorMarkerPositions(startTime, totalDuration, errorTimestamps),
+		MarkerColor: theme.TextIncorrect,
+		Theme:       theme,
+	})
 }
 
-// drawBrailleLineSegment draws a line segment in the braille grid using Bresenham's algorithm.
-func drawBrailleLineSegment(grid [][]uint8, width, height, x1, y1, x2, y2 int) {
-	dx := abs(x2 - x1)
-	dy := abs(y2 - y1)
-	sx := 1
-	if x1 > x2 {
-		sx = -1
-	}
-	sy := 1
-	if y1 > y2 {
-		sy = -1
-	}
-	err := dx - dy
-
-	x, y := x1, y1
-	for {
-		// Convert sub-pixel coordinates to braille cell and dot position
-		cellX := x / brailleDotsWidth
-		cellY := y / brailleDotsHeight
-		dotX := x % brailleDotsWidth
-		dotY := y % brailleDotsHeight
-
-		// Set braille dot if within bounds
-		if cellX >= 0 && cellX < width && cellY >= 0 && cellY < height {
-			// Braille dot pattern: left column 0-3, right column 4-7
-			dotIndex := dotX*brailleDotsHeight + dotY
-			grid[cellY][cellX] |= (1 << dotIndex)
-		}
-
-		if x == x2 && y == y2 {
-			break
-		}
-
-		e2 := 2 * err
-		if e2 > -dy {
-			err -= dy
-			x += sx
-		}
-		if e2 < dx {
-			err += dx
-			y += sy
-		}
-	}
+// drawRhythmGraph renders the rolling variation of keystroke intervals as a
+// percentage, so an uneven rhythm shows up even at a steady WPM.
+//
+// Parameters:
+//   - x, y: top-left position of the graph
+//   - width, height: dimensions of the graph area
+//   - series: coefficients of variation from Stats.GetRhythmSeries
+//   - theme: color theme for rendering
+func (r *Renderer) drawRhythmGraph(x, y, width, height int, series []float64, theme Theme) {
+	percentages := make([]float64, len(series))
+	for i, cv := range series {
+		percentages[i] = cv * 100
+	}
+
+	r.DrawLineChart(x, y, width, height, percentages, ChartOptions{
+		Title:      "Rhythm variation (%)",
+		YIncrement: rhythmIncrement,
+		Color:      theme.TextCursor,
+		Theme:      theme,
+	})
 }
 
-// drawErrorMarkers renders error markers (×) at the bottom of the graph.
-func (r *Renderer) drawErrorMarkers(graphX, graphY, graphWidth, graphHeight int, totalDuration float64, startTime time.Time, errorTimestamps []time.Time, theme Theme) {
-	if totalDuration <= 0 || len(errorTimestamps) == 0 {
-		return
+// errorMarkerPositions converts error timestamps to positions along the time axis.
+// Errors outside the graph range are dropped.
+func errorMarkerPositions(startTime time.Time, totalDuration float64, errorTimestamps []time.Time) []float64 {
+	if totalDuration <= 0 {
+		return nil
 	}
 
-	errorStyle := tcell.StyleDefault.Foreground(theme.TextIncorrect).Background(theme.Background)
-
+	var positions []float64
 	for _, errorTime := range errorTimestamps {
 		// Calculate time offset from start
 		errorOffset := errorTime.Sub(startTime).Seconds()
-
-		// Skip errors outside the graph range
 		if errorOffset < 0 || errorOffset > totalDuration {
 			continue
 		}
-
-		// Calculate X position for this error
-		normalized := errorOffset / totalDuration
-		errorX := graphX + int(normalized*float64(graphWidth-1))
-
-		// Draw error marker at the bottom of the graph
-		r.screen.SetContent(errorX, graphY+graphHeight-1, '×', nil, errorStyle)
+		positions = append(positions, errorOffset/totalDuration)
 	}
+	return positions
 }
 
-// drawTimeAxisLabels draws time labels below the X-axis.
-func (r *Renderer) drawTimeAxisLabels(graphX, graphY, graphWidth, graphHeight int, totalDuration float64, theme Theme) {
-	xAxisY := graphY + graphHeight + 1
+// timeAxisLabels returns time labels at an interval suited to the duration.
+func timeAxisLabels(totalDuration float64) []AxisLabel {
+	if totalDuration <= 0 {
+		return nil
+	}
 
 	// Determine time interval for labels based on duration
 	var interval float64
@@ -1033,26 +1922,24 @@ func (r *Renderer) drawTimeAxisLabels(graphX, graphY, graphWidth, graphHeight in
 		interval = 30
 	}
 
-	// Draw time labels
+	var labels []AxisLabel
 	for t := 0.0; t <= totalDuration; t += interval {
-		// Calculate X position for this time
-		normalized := t / totalDuration
-		labelX := graphX + int(normalized*float64(graphWidth-1))
-
-		// Format time label
-		timeLabel := formatTimeLabel(t)
-
-		// Center the label on the tick mark
-		labelX -= len(timeLabel) / 2
-		if labelX < graphX {
-			labelX = graphX
-		}
-		if labelX+len(timeLabel) > graphX+graphWidth {
-			labelX = graphX + graphWidth - len(timeLabel)
-		}
+		labels = append(labels, AxisLabel{Position: t / totalDuration, Text: formatTimeLabel(t)})
+	}
+	return labels
+}
 
-		r.DrawText(labelX, xAxisY, timeLabel, theme.Help, theme.Background)
+// formatDuration formats a duration as m:ss, or h:mm:ss from one hour on.
+func formatDuration(d time.Duration) string {
+	total := int(d.Round(time.Second).Seconds())
+	if total < 0 {
+		total = 0
+	}
+	hours, minutes, seconds := total/3600, total/60%60, total%60
+	if hours > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", hours, minutes, seconds)
 	}
+	return fmt.Sprintf("%d:%02d", minutes, seconds)
 }
 
 // formatTimeLabel formats seconds into a readable time string.
@@ -0,0 +1,606 @@
+package internal
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// newTestRenderer creates a renderer on a simulation screen of the given size.
+func newTestRenderer(t *testing.T, width, height int) (*Renderer, tcell.SimulationScreen) {
+	t.Helper()
+
+	screen := tcell.NewSimulationScreen("UTF-8")
+	if err := screen.Init(); err != nil {
+		t.Fatalf("failed to initialize simulation screen: %v", err)
+	}
+	screen.SetSize(width, height)
+	t.Cleanup(screen.Fini)
+
+	return NewRenderer(screen), screen
+}
+
+// screenRows shows pending drawing and returns the screen content row by row.
+func screenRows(screen tcell.SimulationScreen) []string {
+	screen.Show()
+	cells, width, height := screen.GetContents()
+	rows := make([]string, height)
+	for y := range rows {
+		var runes []rune
+		for x := 0; x < width; x++ {
+			if cell := cells[y*width+x]; len(cell.Runes) > 0 {
+				runes = append(runes, cell.Runes[0])
+			} else {
+				runes = append(runes, ' ')
+			}
+		}
+		rows[y] = string(runes)
+	}
+	return rows
+}
+
+func TestDrawResultsShowGraph(t *testing.T) {
+	start := time.Now()
+	history := []WPMSnapshot{
+		{Timestamp: start, WPM: 30},
+		{Timestamp: start.Add(5 * time.Second), WPM: 45},
+		{Timestamp: start.Add(10 * time.Second), WPM: 50},
+	}
+
+	draw := func(showGraph bool) []string {
+		renderer, screen := newTestRenderer(t, 100, 60)
+		renderer.DrawResults(ResultsData{
+			WPM:        50,
+			Accuracy:   100,
+			WPMHistory: history,
+			ShowGraph:  showGraph,
+			Theme:      DefaultTheme,
+		})
+		return screenRows(screen)
+	}
+
+	// boxRows returns the number of rows between the box's top and bottom border
+	boxRows := func(rows []string) int {
+		first, last := -1, -1
+		for y, row := range rows {
+			if strings.ContainsRune(row, UnicodeGlyphs.BoxTopLeft) {
+				first = y
+			}
+			if strings.ContainsRune(row, UnicodeGlyphs.BoxBottomLeft) {
+				last = y
+			}
+		}
+		return last - first + 1
+	}
+
+	withGraph := draw(true)
+	if !strings.Contains(strings.Join(withGraph, "\n"), "WPM Timeline") {
+		t.Fatal("Expected the WPM timeline with ShowGraph enabled")
+	}
+
+	withoutGraph := draw(false)
+	if strings.Contains(strings.Join(withoutGraph, "\n"), "WPM Timeline") {
+		t.Error("Expected no WPM timeline with ShowGraph disabled")
+	}
+	if got := boxRows(withoutGraph); got >= boxRows(withGraph) || got > resultsBoxHeightNoGraph {
+		t.Errorf("Expected a shorter results box without the graph, got %d rows (with graph: %d)", got, boxRows(withGraph))
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{0, "0:00"},
+		{42 * time.Second, "0:42"},
+		{59600 * time.Millisecond, "1:00"},
+		{2*time.Minute + 5*time.Second, "2:05"},
+		{time.Hour + 2*time.Minute + 3*time.Second, "1:02:03"},
+	}
+
+	for _, tt := range tests {
+		if got := formatDuration(tt.d); got != tt.want {
+			t.Errorf("formatDuration(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
+
+func TestGetCharStyleBoldText(t *testing.T) {
+	renderer, _ := newTestRenderer(t, 80, 24)
+	sample := []rune("abc")
+	user := []rune("a")
+
+	for _, bold := range []bool{false, true} {
+		data := TypingViewData{SampleRunes: sample, UserRunes: user, CursorPos: 1, Theme: DefaultTheme, BoldText: bold}
+
+		// Position 0 is correct, position 2 is not typed yet
+		for _, pos := range []int{0, 2} {
+			style, _ := renderer.getCharStyle(pos, sample[pos], sample[pos], user, data)
+			_, _, attrs := style.Decompose()
+			if got := attrs&tcell.AttrBold != 0; got != bold {
+				t.Errorf("BoldText=%v, position %d: expected bold=%v, got %v", bold, pos, bold, got)
+			}
+		}
+	}
+}
+
+func TestGetCharStyleUnderlineWhitespace(t *testing.T) {
+	renderer, _ := newTestRenderer(t, 80, 24)
+	sample := []rune("a b")
+	user := []rune("ax")
+
+	tests := []struct {
+		underline     bool
+		wantChar      rune
+		wantUnderline bool
+	}{
+		{false, '_', false},
+		{true, ' ', true},
+	}
+
+	for _, tt := range tests {
+		data := TypingViewData{SampleRunes: sample, UserRunes: user, CursorPos: 2, Theme: DefaultTheme, UnderlineWhitespace: tt.underline}
+
+		style, displayChar := renderer.getCharStyle(1, ' ', ' ', user, data)
+		underlined := style.GetUnderlineStyle() != tcell.UnderlineStyleNone
+		if displayChar != tt.wantChar || underlined != tt.wantUnderline {
+			t.Errorf("UnderlineWhitespace=%v: expected %q underlined=%v, got %q underlined=%v",
+				tt.underline, tt.wantChar, tt.wantUnderline, displayChar, underlined)
+		}
+
+		// Mistyped letters are not underlined
+		style, _ = renderer.getCharStyle(0, 'a', 'a', []rune("x"), data)
+		if style.GetUnderlineStyle() != tcell.UnderlineStyleNone {
+			t.Errorf("UnderlineWhitespace=%v: expected a mistyped letter not to be underlined", tt.underline)
+		}
+	}
+}
+
+func TestDrawResultsHideMisspelled(t *testing.T) {
+	for _, hide := range []bool{false, true} {
+		renderer, screen := newTestRenderer(t, 100, 60)
+		renderer.DrawResults(ResultsData{
+			WPM:             50,
+			Accuracy:        90,
+			MisspelledWords: []string{"hello"},
+			WordCounts:      map[string]int{"hello": 1},
+			HideMisspelled:  hide,
+			Theme:           DefaultTheme,
+		})
+
+		shown := strings.Contains(strings.Join(screenRows(screen), "\n"), "Misspelled Words")
+		if shown == hide {
+			t.Errorf("HideMisspelled=%v: expected misspelled section shown=%v", hide, !hide)
+		}
+	}
+}
+
+func TestDrawTypingViewMistypedOverlay(t *testing.T) {
+	for _, show := range []bool{false, true} {
+		renderer, screen := newTestRenderer(t, 80, 24)
+		renderer.DrawTypingView(TypingViewData{
+			SampleText:          "abc",
+			SampleRunes:         []rune("abc"),
+			UserRunes:           []rune("aq"),
+			CursorPos:           2,
+			Theme:               DefaultTheme,
+			ShowMistypedOverlay: show,
+		})
+
+		rows := screenRows(screen)
+		textRow := -1
+		for y, row := range rows {
+			if strings.Contains(row, "abc") {
+				textRow = y
+			}
+		}
+		if textRow < 1 {
+			t.Fatalf("ShowMistypedOverlay=%v: typing text not found", show)
+		}
+		if got := strings.Contains(rows[textRow-1], "q"); got != show {
+			t.Errorf("ShowMistypedOverlay=%v: expected mistyped 'q' above the text=%v, got %v", show, show, got)
+		}
+	}
+}
+
+func TestGetCharStyleBlindMode(t *testing.T) {
+	renderer, _ := newTestRenderer(t, 80, 24)
+	sample := []rune("a b")
+	user := []rune("ax")
+	data := TypingViewData{SampleRunes: sample, UserRunes: user, CursorPos: 2, Theme: DefaultTheme, BlindMode: true}
+
+	correctStyle, _ := renderer.getCharStyle(0, 'a', 'a', user, data)
+	incorrectStyle, displayChar := renderer.getCharStyle(1, ' ', ' ', user, data)
+	if incorrectStyle != correctStyle {
+		t.Error("Expected an incorrect character to look like a correct one in blind mode")
+	}
+	if displayChar != ' ' {
+		t.Errorf("Expected the sample character without error marker, got %q", displayChar)
+	}
+
+	data.BlindMode = false
+	if style, _ := renderer.getCharStyle(1, ' ', ' ', user, data); style == correctStyle {
+		t.Error("Expected mistakes to be highlighted outside blind mode")
+	}
+}
+
+func TestReviewScrollLine(t *testing.T) {
+	tests := []struct {
+		toEnd      bool
+		totalLines int
+		want       int
+	}{
+		{false, 100, 0}, // Home shows the first line
+		{true, 100, 91}, // End shows the last 9 lines, not past the end
+		{true, 9, 0},    // the text fits exactly
+		{true, 3, 0},    // the text is shorter than the viewport
+		{false, 0, 0},
+		{true, 0, 0},
+	}
+
+	for _, tt := range tests {
+		if got := ReviewScrollLine(tt.toEnd, 9, tt.totalLines); got != tt.want {
+			t.Errorf("toEnd %v, %d lines: expected scroll line %d, got %d", tt.toEnd, tt.totalLines, tt.want, got)
+		}
+	}
+}
+
+func TestCalculateScrollLineAnchors(t *testing.T) {
+	tests := []struct {
+		anchor     string
+		cursorLine int
+		want       int
+	}{
+		{ScrollAnchorTop, 20, 17},    // cursor 3 lines below the top (9/3)
+		{ScrollAnchorCenter, 20, 16}, // cursor 4 lines below the top (9/2)
+		{ScrollAnchorBottom, 20, 13}, // cursor one line above the last visible line
+		{ScrollAnchorTop, 1, 0},      // no scrolling before the anchor is reached
+		{ScrollAnchorCenter, 99, 91}, // no scrolling past the end
+		{ScrollAnchorBottom, 99, 91},
+		{"unknown", 20, 17},
+	}
+
+	for _, tt := range tests {
+		if got := CalculateScrollLine(tt.cursorLine, 9, 100, tt.anchor); got != tt.want {
+			t.Errorf("anchor %q, cursor line %d: expected scroll line %d, got %d", tt.anchor, tt.cursorLine, tt.want, got)
+		}
+	}
+
+	if got := CalculateScrollLine(5, 9, 8, ScrollAnchorBottom); got != 0 {
+		t.Errorf("expected no scrolling when the text fits, got %d", got)
+	}
+}
+
+func TestLineNumberGutterLayout(t *testing.T) {
+	for lastNumber, want := range map[int]int{0: 3, 9: 3, 10: 4, 120: 5} {
+		if got := lineNumberGutterWidth(lastNumber); got != want {
+			t.Errorf("lineNumberGutterWidth(%d): expected %d, got %d", lastNumber, want, got)
+		}
+	}
+
+	if got := typingTextStartX(80, 40, 0); got != 20 {
+		t.Errorf("expected text at column 20 without a gutter, got %d", got)
+	}
+	// Text and gutter are centered together: the gutter starts at 18, the text 4 columns later
+	if got := typingTextStartX(80, 40, 4); got != 22 {
+		t.Errorf("expected text at column 22 with a 4 column gutter, got %d", got)
+	}
+}
+
+func TestTypingMargins(t *testing.T) {
+	tests := []struct {
+		height, top, bottom int
+		wantAvailable       int
+		wantStartY          int // For 3 visible lines
+	}{
+		{40, 0, 0, 30, 17},
+		{40, 6, 0, 24, 20}, // Centered between the margins, so shifted by half
+		{40, 0, 6, 24, 14},
+		{40, 4, 4, 22, 17},
+		{12, 0, 0, 2, 4},
+		{12, 10, 0, 2, 4}, // Margins that leave no room for a line are dropped
+	}
+
+	for _, tt := range tests {
+		if got := TypingAvailableHeight(tt.height, tt.top, tt.bottom); got != tt.wantAvailable {
+			t.Errorf("TypingAvailableHeight(%d, %d, %d) = %d, want %d", tt.height, tt.top, tt.bottom, got, tt.wantAvailable)
+		}
+		if got := typingViewStartY(tt.height, 3, tt.top, tt.bottom); got != tt.wantStartY {
+			t.Errorf("typingViewStartY(%d, 3, %d, %d) = %d, want %d", tt.height, tt.top, tt.bottom, got, tt.wantStartY)
+		}
+	}
+
+	// A large top margin keeps the text below it even when it fills the screen
+	if got := typingViewStartY(40, 14, 8, 0); got != 12 {
+		t.Errorf("expected the text to start below the margin at row 12, got %d", got)
+	}
+}
+
+func TestNoticeKeepsProgressVisible(t *testing.T) {
+	renderer, screen := newTestRenderer(t, 60, 12)
+	renderer.DrawProgress("0:42", DefaultTheme)
+	renderer.DrawNotice("Not exact yet", DefaultTheme)
+
+	text := strings.Join(screenRows(screen), "\n")
+	if !strings.Contains(text, "0:42") || !strings.Contains(text, "Not exact yet") {
+		t.Errorf("Expected the notice and the progress on separate rows, got:\n%s", text)
+	}
+}
+
+func TestFocusStartX(t *testing.T) {
+	lines := wrapRunesWindow([]rune("abcd efgh\nij"), 5, 0, 3) // "abcd ", "efgh\n", "ij"
+
+	tests := []struct {
+		cursorPos int
+		want      int
+	}{
+		{0, 40},  // First character at the focus column
+		{3, 37},  // Shifted left by the cursor column
+		{5, 40},  // End of a wrapped line: the cursor is at the start of the next
+		{8, 37},  // Within the second line
+		{9, 36},  // On the newline
+		{12, 38}, // After the last character
+		{20, 10}, // Not on a visible line
+	}
+	for _, tt := range tests {
+		if got := focusStartX(40, lines, tt.cursorPos, 10); got != tt.want {
+			t.Errorf("focusStartX(cursor %d) = %d, want %d", tt.cursorPos, got, tt.want)
+		}
+	}
+
+	// Combining marks share the column of the character before them
+	marks := wrapRunesWindow([]rune("ne\u0301e"), 10, 0, 1)
+	if got := focusStartX(40, marks, 3, 0); got != 38 {
+		t.Errorf("expected combining marks to take no column, got %d", got)
+	}
+}
+
+func TestDrawTypingViewHorizontalFocus(t *testing.T) {
+	renderer, screen := newTestRenderer(t, 80, 24)
+	renderer.DrawTypingView(TypingViewData{
+		SampleText:      "hello world",
+		SampleRunes:     []rune("hello world"),
+		UserRunes:       []rune("hello"),
+		CursorPos:       5,
+		Theme:           DefaultTheme,
+		HorizontalFocus: true,
+	})
+
+	for _, row := range screenRows(screen) {
+		if x := strings.Index(row, "hello world"); x >= 0 {
+			if x+5 != 40 {
+				t.Errorf("expected the cursor at column 40, text starts at %d", x)
+			}
+			return
+		}
+	}
+	t.Error("expected the text to be drawn")
+}
+
+func TestDrawTypingViewGenerationIndicator(t *testing.T) {
+	for _, generating := range []bool{false, true} {
+		renderer, screen := newTestRenderer(t, 80, 24)
+		renderer.DrawTypingView(TypingViewData{
+			SampleText:  "hello world",
+			SampleRunes: []rune("hello world"),
+			Theme:       DefaultTheme,
+			WordMode:    true,
+			Generating:  generating,
+		})
+
+		text := strings.Join(screenRows(screen), "\n")
+		if strings.Contains(text, "hello world …") != generating {
+			t.Errorf("Expected the indicator to be shown only while generating (generating: %v), got:\n%s", generating, text)
+		}
+	}
+}
+
+func TestDrawResultsPersonalBest(t *testing.T) {
+	tests := []struct {
+		wpm, previousBest float64
+		want, notWant     string
+	}{
+		{wpm: 96, previousBest: 95, want: "New best!", notWant: "Best: 95 WPM"},
+		{wpm: 80, previousBest: 95, want: "Best: 95 WPM", notWant: "New best!"},
+		{wpm: 80, previousBest: 0, notWant: "Best: "}, // First run of the text, shown silently
+	}
+	for _, tt := range tests {
+		renderer, screen := newTestRenderer(t, 100, 30)
+		renderer.DrawResults(ResultsData{
+			WPM:          tt.wpm,
+			Accuracy:     100,
+			PreviousBest: tt.previousBest,
+			Theme:        DefaultTheme,
+		})
+
+		text := strings.Join(screenRows(screen), "\n")
+		if !strings.Contains(text, tt.want) || strings.Contains(text, tt.notWant) || tt.previousBest == 0 && strings.Contains(text, "New best!") {
+			t.Errorf("WPM %.0f, best %.0f: expected %q and no %q, got:\n%s", tt.wpm, tt.previousBest, tt.want, tt.notWant, text)
+		}
+	}
+}
+
+func TestDrawResultsCategoryAccuracy(t *testing.T) {
+	renderer, screen := newTestRenderer(t, 100, 30)
+	renderer.DrawResults(ResultsData{
+		WPM:              50,
+		Accuracy:         95,
+		CategoryAccuracy: map[string]float64{CategoryPunct: 85, CategoryLetters: 99.4},
+		Theme:            DefaultTheme,
+	})
+
+	text := strings.Join(screenRows(screen), "\n")
+	if !strings.Contains(text, "Letters 99%  ·  Punct 85%") {
+		t.Errorf("Expected the typed categories in display order, got:\n%s", text)
+	}
+}
+
+func TestDrawPausedBanner(t *testing.T) {
+	renderer, screen := newTestRenderer(t, 80, 24)
+	renderer.DrawPausedBanner(DefaultTheme)
+
+	text := strings.Join(screenRows(screen), "\n")
+	if !strings.Contains(text, "PAUSED") || !strings.Contains(text, "Ctrl+Space: resume") {
+		t.Errorf("Expected the paused banner with the resume key, got:\n%s", text)
+	}
+}
+
+func TestVisibleLineNumbers(t *testing.T) {
+	layout := newTextLayout([]rune("aaaa bbbb\ncc\n"), 5) // "aaaa ", "bbbb\n", "cc\n"
+
+	tests := []struct {
+		numbering string
+		firstLine int
+		want      []int
+		wantLast  int
+	}{
+		{LineNumbersLogical, 0, []int{1, 0, 2}, 2},
+		{LineNumbersLogical, 1, []int{0, 2}, 2},
+		{LineNumbersWrapped, 0, []int{1, 2, 3}, 3},
+		{LineNumbersWrapped, 1, []int{2, 3}, 3},
+	}
+
+	for _, tt := range tests {
+		got := visibleLineNumbers(layout, tt.firstLine, len(layout.starts)-tt.firstLine, tt.numbering)
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%s from line %d: expected %v, got %v", tt.numbering, tt.firstLine, tt.want, got)
+		}
+		if last := lastLineNumber(layout, tt.numbering); last != tt.wantLast {
+			t.Errorf("%s: expected last line number %d, got %d", tt.numbering, tt.wantLast, last)
+		}
+	}
+}
+
+func TestDrawTypingViewLineNumbers(t *testing.T) {
+	renderer, screen := newTestRenderer(t, 80, 24)
+	renderer.DrawTypingView(TypingViewData{
+		SampleText:      "ab\ncd",
+		SampleRunes:     []rune("ab\ncd"),
+		Theme:           DefaultTheme,
+		ShowLineNumbers: true,
+		LineNumbering:   LineNumbersLogical,
+	})
+
+	text := strings.Join(screenRows(screen), "\n")
+	if !strings.Contains(text, "1  ab") || !strings.Contains(text, "2  cd") {
+		t.Errorf("expected numbered lines, got:\n%s", text)
+	}
+}
+
+func TestDrawStatsFocusFade(t *testing.T) {
+	for _, active := range []bool{false, true} {
+		wantFg := DefaultTheme.Help
+		if active {
+			wantFg = DefaultTheme.MenuDimText
+		}
+		if fg, _, _ := chromeStyle(DefaultTheme.Help, DefaultTheme, active).Decompose(); fg != wantFg {
+			t.Errorf("typingActive=%v: expected chrome color %v, got %v", active, wantFg, fg)
+		}
+
+		renderer, screen := newTestRenderer(t, 80, 24)
+		renderer.DrawStats(50, 98, 88, 3, 7, DefaultTheme, active)
+
+		row := screenRows(screen)[24-3]
+		x := strings.Index(row, "WPM")
+		if x < 0 {
+			t.Fatalf("typingActive=%v: stats not found in %q", active, row)
+		}
+		if !strings.Contains(row, "Word acc: 88%") {
+			t.Errorf("expected the word accuracy in %q", row)
+		}
+		_, style, _ := screen.Get(x, 24-3)
+		if _, _, attrs := style.Decompose(); (attrs&tcell.AttrDim != 0) != active {
+			t.Errorf("typingActive=%v: expected stats dimmed=%v, got attrs %v", active, active, attrs)
+		}
+	}
+}
+
+func TestFormatResultSpeed(t *testing.T) {
+	tests := []struct {
+		unit string
+		want string
+	}{
+		{SpeedUnitWPM, "WPM: 82.0 (raw 91.0)"},
+		{SpeedUnitKPM, "KPM: 410 (raw 455)"},
+		{SpeedUnitBoth, "WPM: 82.0 (raw 91.0) | KPM: 410 (raw 455)"},
+	}
+	for _, tt := range tests {
+		if got := formatResultSpeed(82, 91, tt.unit, " | "); got != tt.want {
+			t.Errorf("formatResultSpeed(%s) = %q, want %q", tt.unit, got, tt.want)
+		}
+	}
+}
+
+func TestFormatSpeed(t *testing.T) {
+	tests := []struct {
+		unit string
+		want string
+	}{
+		{SpeedUnitWPM, "WPM: 82.4"},
+		{SpeedUnitKPM, "KPM: 412"},
+		{SpeedUnitBoth, "WPM: 82.4 | KPM: 412"},
+		{"unknown", "WPM: 82.4"},
+	}
+	for _, tt := range tests {
+		if got := FormatSpeed(82.4, tt.unit, 1, " | "); got != tt.want {
+			t.Errorf("FormatSpeed(%s) = %q, want %q", tt.unit, got, tt.want)
+		}
+	}
+}
+
+func TestDimStyle(t *testing.T) {
+	theme := DefaultTheme
+	style := dimStyle(tcell.StyleDefault.Foreground(tcell.ColorRed).Background(tcell.ColorBlue).Bold(true), theme)
+	fg, bg, attrs := style.Decompose()
+	if fg != theme.MenuDimText || bg != tcell.ColorBlue {
+		t.Errorf("colors = %v on %v, want %v on %v", fg, bg, theme.MenuDimText, tcell.ColorBlue)
+	}
+	if attrs&tcell.AttrBold != 0 || attrs&tcell.AttrDim == 0 {
+		t.Errorf("attrs = %v, want dim and not bold", attrs)
+	}
+
+	// Themes without a dimmed menu color keep the foreground and only dim it
+	theme.MenuDimText = tcell.ColorDefault
+	fg, _, attrs = dimStyle(tcell.StyleDefault.Foreground(tcell.ColorRed), theme).Decompose()
+	if fg != tcell.ColorRed || attrs&tcell.AttrDim == 0 {
+		t.Errorf("got %v with attrs %v, want a dimmed red", fg, attrs)
+	}
+}
+
+func TestDimBackground(t *testing.T) {
+	renderer, screen := newTestRenderer(t, 10, 2)
+	screen.SetContent(3, 1, 'x', nil, tcell.StyleDefault.Bold(true))
+
+	renderer.DimBackground(DefaultTheme)
+
+	mainc, _, style, _ := screen.GetContent(3, 1)
+	if _, _, attrs := style.Decompose(); mainc != 'x' || attrs&tcell.AttrDim == 0 || attrs&tcell.AttrBold != 0 {
+		t.Errorf("cell = %q with attrs %v, want a dimmed 'x'", mainc, attrs)
+	}
+}
+
+func TestDrawCurrentWordBlindMode(t *testing.T) {
+	for _, blind := range []bool{false, true} {
+		renderer, screen := newTestRenderer(t, 80, 24)
+		renderer.DrawCurrentWord("cat", "cx", DefaultTheme, blind)
+
+		y := typingViewStartY(24, wordModeVisibleLines, 0, 0) - 3
+		x := (80 - 5) / 2
+		_, _, correct, _ := screen.GetContent(x, y)
+		_, _, wrong, _ := screen.GetContent(x+2, y)
+		correctFg, _, _ := correct.Decompose()
+		wrongFg, _, _ := wrong.Decompose()
+
+		want := [2]tcell.Color{DefaultTheme.TextCorrect, DefaultTheme.TextIncorrect}
+		if blind {
+			want = [2]tcell.Color{DefaultTheme.Foreground, DefaultTheme.Foreground}
+		}
+		if correctFg != want[0] || wrongFg != want[1] {
+			t.Errorf("blind %v: got colors %v and %v, want %v", blind, correctFg, wrongFg, want)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package internal
+
+import (
+	"strconv"
+	"strings"
+)
+
+// maxTextRepeats caps how often a text can be repeated for endurance practice.
+const maxTextRepeats = 20
+
+// RepeatText returns text repeated n times for endurance practice. Texts with
+// several lines are joined by a newline so every repetition starts on its own
+// line; single lines are joined by a space. n below 1 counts as 1.
+func RepeatText(text string, n int) string {
+	separator := " "
+	if strings.Contains(text, "\n") {
+		separator = "\n"
+	}
+	repeats := make([]string, max(n, 1))
+	for i := range repeats {
+		repeats[i] = text
+	}
+	return strings.Join(repeats, separator)
+}
+
+// ParseRepeatCount parses a repetition count like "3" or "3x". It fails for
+// counts outside 1 to maxTextRepeats.
+func ParseRepeatCount(arg string) (int, bool) {
+	arg = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(arg)), "x")
+	n, err := strconv.Atoi(strings.TrimSpace(arg))
+	if err != nil || n < 1 || n > maxTextRepeats {
+		return 0, false
+	}
+	return n, true
+}
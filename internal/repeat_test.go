@@ -0,0 +1,42 @@
+package internal
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRepeatText(t *testing.T) {
+	tests := []struct {
+		text string
+		n    int
+		want string
+	}{
+		{"the quick fox", 3, "the quick fox the quick fox the quick fox"},
+		{"roses are red\nviolets are blue", 2, "roses are red\nviolets are blue\nroses are red\nviolets are blue"},
+		{"once", 1, "once"},
+		{"once", 0, "once"},
+	}
+
+	for _, tt := range tests {
+		if got := RepeatText(tt.text, tt.n); got != tt.want {
+			t.Errorf("RepeatText(%q, %d) = %q, want %q", tt.text, tt.n, got, tt.want)
+		}
+	}
+
+	if got := strings.Count(RepeatText("abc", 5), "abc"); got != 5 {
+		t.Errorf("Expected 5 repetitions, got %d", got)
+	}
+}
+
+func TestParseRepeatCount(t *testing.T) {
+	for arg, want := range map[string]int{"3": 3, "3x": 3, "4X": 4, " 2 x": 2} {
+		if got, ok := ParseRepeatCount(arg); !ok || got != want {
+			t.Errorf("ParseRepeatCount(%q) = %d, %v, want %d", arg, got, ok, want)
+		}
+	}
+	for _, arg := range []string{"", "x", "0", "-2x", "21", "three"} {
+		if _, ok := ParseRepeatCount(arg); ok {
+			t.Errorf("Expected ParseRepeatCount(%q) to fail", arg)
+		}
+	}
+}
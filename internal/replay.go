@@ -0,0 +1,94 @@
+package internal
+
+import "time"
+
+const (
+	// minReplaySpeed and maxReplaySpeed bound the replay speed factor.
+	minReplaySpeed = 0.25
+	maxReplaySpeed = 8.0
+)
+
+// Replay schedules recorded input events for playback with their original timing.
+// It keeps its own playback clock that is advanced by the caller (the App ticker),
+// which makes pausing and speed changes independent of wall-clock time.
+type Replay struct {
+	events  []InputEvent  // Recorded events in chronological order
+	speed   float64       // Playback speed factor (2 = twice as fast)
+	index   int           // Index of the next event to play
+	elapsed time.Duration // Playback clock in original (unscaled) time
+	paused  bool          // Whether playback is paused
+}
+
+// NewReplay creates a replay of the given events at the given speed factor.
+// The first event plays immediately.
+func NewReplay(events []InputEvent, speed float64) *Replay {
+	return &Replay{
+		events: events,
+		speed:  clampReplaySpeed(speed),
+	}
+}
+
+// clampReplaySpeed keeps a speed factor within the supported range.
+func clampReplaySpeed(speed float64) float64 {
+	return min(max(speed, minReplaySpeed), maxReplaySpeed)
+}
+
+// offset returns the recorded time of event i relative to the first event.
+func (rp *Replay) offset(i int) time.Duration {
+	return rp.events[i].Timestamp.Sub(rp.events[0].Timestamp)
+}
+
+// NextEventDelay returns how long (in real time at the current speed) until the
+// next event is due. Returns 0 if an event is already due or the replay is done.
+func (rp *Replay) NextEventDelay() time.Duration {
+	if rp.IsDone() {
+		return 0
+	}
+	remaining := rp.offset(rp.index) - rp.elapsed
+	if remaining <= 0 {
+		return 0
+	}
+	return time.Duration(float64(remaining) / rp.speed)
+}
+
+// Advance moves the playback clock forward by dt of real time and returns the
+// events that became due, in order. Returns nil while paused.
+func (rp *Replay) Advance(dt time.Duration) []InputEvent {
+	if rp.paused || rp.IsDone() {
+		return nil
+	}
+
+	rp.elapsed += time.Duration(float64(dt) * rp.speed)
+
+	start := rp.index
+	for rp.index < len(rp.events) && rp.offset(rp.index) <= rp.elapsed {
+		rp.index++
+	}
+	return rp.events[start:rp.index]
+}
+
+// TogglePause pauses or resumes playback.
+func (rp *Replay) TogglePause() {
+	rp.paused = !rp.paused
+}
+
+// IsPaused returns whether playback is paused.
+func (rp *Replay) IsPaused() bool {
+	return rp.paused
+}
+
+// IsDone returns whether all events have been played.
+func (rp *Replay) IsDone() bool {
+	return rp.index >= len(rp.events)
+}
+
+// Speed returns the current playback speed factor.
+func (rp *Replay) Speed() float64 {
+	return rp.speed
+}
+
+// SetSpeed changes the playback speed factor, clamped to the supported range.
+// Playback continues from the current position.
+func (rp *Replay) SetSpeed(speed float64) {
+	rp.speed = clampReplaySpeed(speed)
+}
@@ -0,0 +1,91 @@
+package internal
+
+import (
+	"testing"
+	"time"
+)
+
+func replayEvents(offsetsMS ...int) []InputEvent {
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	events := make([]InputEvent, len(offsetsMS))
+	for i, ms := range offsetsMS {
+		events[i] = InputEvent{Timestamp: base.Add(time.Duration(ms) * time.Millisecond), Char: 'a'}
+	}
+	return events
+}
+
+func TestReplayNextEventDelay(t *testing.T) {
+	replay := NewReplay(replayEvents(0, 100, 300), 1)
+
+	if delay := replay.NextEventDelay(); delay != 0 {
+		t.Errorf("Expected first event due immediately, got %v", delay)
+	}
+	if events := replay.Advance(0); len(events) != 1 {
+		t.Fatalf("Expected 1 event at start, got %d", len(events))
+	}
+
+	if delay := replay.NextEventDelay(); delay != 100*time.Millisecond {
+		t.Errorf("Expected 100ms until second event, got %v", delay)
+	}
+
+	replay.Advance(40 * time.Millisecond)
+	if delay := replay.NextEventDelay(); delay != 60*time.Millisecond {
+		t.Errorf("Expected 60ms remaining, got %v", delay)
+	}
+
+	if events := replay.Advance(60 * time.Millisecond); len(events) != 1 {
+		t.Errorf("Expected second event to be due, got %d events", len(events))
+	}
+	if delay := replay.NextEventDelay(); delay != 200*time.Millisecond {
+		t.Errorf("Expected 200ms until third event, got %v", delay)
+	}
+}
+
+func TestReplaySpeedScalesDelay(t *testing.T) {
+	replay := NewReplay(replayEvents(0, 400), 2)
+	replay.Advance(0)
+
+	if delay := replay.NextEventDelay(); delay != 200*time.Millisecond {
+		t.Errorf("Expected 200ms at 2x speed, got %v", delay)
+	}
+
+	replay.SetSpeed(100) // Clamped to maxReplaySpeed
+	if replay.Speed() != maxReplaySpeed {
+		t.Errorf("Expected speed clamped to %v, got %v", maxReplaySpeed, replay.Speed())
+	}
+}
+
+func TestReplayPause(t *testing.T) {
+	replay := NewReplay(replayEvents(0, 100), 1)
+	replay.Advance(0)
+
+	replay.TogglePause()
+	if events := replay.Advance(time.Second); len(events) != 0 {
+		t.Errorf("Expected no events while paused, got %d", len(events))
+	}
+
+	replay.TogglePause()
+	if events := replay.Advance(100 * time.Millisecond); len(events) != 1 {
+		t.Errorf("Expected event after resuming, got %d", len(events))
+	}
+	if !replay.IsDone() {
+		t.Error("Expected replay to be done")
+	}
+}
+
+func TestTypingTestInputLog(t *testing.T) {
+	test := NewTypingTest("ab")
+	test.SetRetainKeystrokeLog(true)
+
+	test.TypeCharacter('x')
+	test.Backspace()
+	test.TypeCharacter('a')
+
+	log := test.GetInputLog()
+	if len(log) != 3 {
+		t.Fatalf("Expected 3 input events, got %d", len(log))
+	}
+	if log[0].Char != 'x' || !log[1].Backspace || log[2].Char != 'a' {
+		t.Errorf("Unexpected input log: %+v", log)
+	}
+}
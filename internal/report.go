@@ -0,0 +1,74 @@
+package internal
+
+import (
+	"sort"
+	"time"
+)
+
+// ReportPeriod is the time span results are grouped by in the progress report.
+type ReportPeriod string
+
+const (
+	ReportByDay  ReportPeriod = "day"
+	ReportByWeek ReportPeriod = "week" // Weeks start on Monday
+)
+
+// ReportBucket summarizes all results within one period.
+type ReportBucket struct {
+	Start      time.Time // Start of the period, in the results' time zone
+	Tests      int
+	AverageWPM float64
+}
+
+// BucketResults groups results by day or week and averages their WPM.
+// Periods without results are omitted. Buckets are sorted oldest first.
+func BucketResults(results []TestResult, period ReportPeriod) []ReportBucket {
+	totals := make(map[time.Time]*ReportBucket)
+	for _, result := range results {
+		start := periodStart(result.Timestamp, period)
+		bucket, ok := totals[start]
+		if !ok {
+			bucket = &ReportBucket{Start: start}
+			totals[start] = bucket
+		}
+		bucket.Tests++
+		bucket.AverageWPM += result.WPM
+	}
+
+	buckets := make([]ReportBucket, 0, len(totals))
+	for _, bucket := range totals {
+		bucket.AverageWPM /= float64(bucket.Tests)
+		buckets = append(buckets, *bucket)
+	}
+	sort.Slice(buckets, func(i, j int) bool {
+		return buckets[i].Start.Before(buckets[j].Start)
+	})
+	return buckets
+}
+
+// periodStart returns midnight of t's day, or of the Monday of t's week.
+func periodStart(t time.Time, period ReportPeriod) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	if period != ReportByWeek {
+		return day
+	}
+	// time.Weekday starts on Sunday; shift so Monday is 0
+	offset := (int(day.Weekday()) + 6) % 7
+	return day.AddDate(0, 0, -offset)
+}
+
+// DayStreak returns how many consecutive days, ending with now's day, have at
+// least one result. Days are taken in now's time zone. Returns 0 if there is
+// no result today.
+func DayStreak(results []TestResult, now time.Time) int {
+	days := make(map[time.Time]bool)
+	for _, result := range results {
+		days[periodStart(result.Timestamp.In(now.Location()), ReportByDay)] = true
+	}
+
+	streak := 0
+	for day := periodStart(now, ReportByDay); days[day]; day = day.AddDate(0, 0, -1) {
+		streak++
+	}
+	return streak
+}
@@ -0,0 +1,94 @@
+package internal
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBucketResultsByDay(t *testing.T) {
+	day := func(d, hour int) time.Time {
+		return time.Date(2024, time.March, d, hour, 0, 0, 0, time.UTC)
+	}
+	results := []TestResult{
+		{Timestamp: day(5, 23), WPM: 60},
+		{Timestamp: day(4, 9), WPM: 40},
+		{Timestamp: day(4, 18), WPM: 50},
+		{Timestamp: day(7, 0), WPM: 70},
+	}
+
+	buckets := BucketResults(results, ReportByDay)
+
+	want := []ReportBucket{
+		{Start: day(4, 0), Tests: 2, AverageWPM: 45},
+		{Start: day(5, 0), Tests: 1, AverageWPM: 60},
+		{Start: day(7, 0), Tests: 1, AverageWPM: 70},
+	}
+	if len(buckets) != len(want) {
+		t.Fatalf("Expected %d buckets, got %v", len(want), buckets)
+	}
+	for i := range want {
+		if !buckets[i].Start.Equal(want[i].Start) || buckets[i].Tests != want[i].Tests || buckets[i].AverageWPM != want[i].AverageWPM {
+			t.Errorf("Bucket %d: expected %+v, got %+v", i, want[i], buckets[i])
+		}
+	}
+}
+
+func TestBucketResultsByWeek(t *testing.T) {
+	date := func(month time.Month, d int) time.Time {
+		return time.Date(2024, month, d, 12, 0, 0, 0, time.UTC)
+	}
+	results := []TestResult{
+		{Timestamp: date(time.March, 4), WPM: 40},  // Monday
+		{Timestamp: date(time.March, 10), WPM: 60}, // Sunday, same week
+		{Timestamp: date(time.March, 11), WPM: 80}, // Next Monday
+		{Timestamp: date(time.February, 29), WPM: 30},
+	}
+
+	buckets := BucketResults(results, ReportByWeek)
+
+	if len(buckets) != 3 {
+		t.Fatalf("Expected 3 weeks, got %v", buckets)
+	}
+	wantStarts := []time.Time{
+		time.Date(2024, time.February, 26, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, time.March, 4, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, time.March, 11, 0, 0, 0, 0, time.UTC),
+	}
+	for i, start := range wantStarts {
+		if !buckets[i].Start.Equal(start) {
+			t.Errorf("Bucket %d: expected start %v, got %v", i, start, buckets[i].Start)
+		}
+	}
+	if buckets[1].Tests != 2 || buckets[1].AverageWPM != 50 {
+		t.Errorf("Expected Monday and Sunday in one week averaging 50, got %+v", buckets[1])
+	}
+}
+
+func TestBucketResultsEmpty(t *testing.T) {
+	if buckets := BucketResults(nil, ReportByDay); len(buckets) != 0 {
+		t.Errorf("Expected no buckets, got %v", buckets)
+	}
+}
+
+func TestDayStreak(t *testing.T) {
+	day := func(d, hour int) time.Time {
+		return time.Date(2024, time.March, d, hour, 0, 0, 0, time.UTC)
+	}
+	results := []TestResult{
+		{Timestamp: day(1, 10)},
+		{Timestamp: day(3, 23)},
+		{Timestamp: day(4, 8)},
+		{Timestamp: day(4, 9)},
+		{Timestamp: day(5, 0)},
+	}
+
+	if got := DayStreak(results, day(5, 12)); got != 3 {
+		t.Errorf("Expected a streak of 3 days, got %d", got)
+	}
+	if got := DayStreak(results, day(6, 12)); got != 0 {
+		t.Errorf("Expected no streak without a result today, got %d", got)
+	}
+	if got := DayStreak(nil, day(5, 12)); got != 0 {
+		t.Errorf("Expected no streak without results, got %d", got)
+	}
+}
@@ -14,8 +14,9 @@ import (
 // you finish a test, restart, or select a new text.
 type Session struct {
 	// Text information
-	TextName    string `json:"text_name"`    // Name of the text being typed
-	TextContent string `json:"text_content"` // Full text content
+	Mode        string `json:"mode"`         // "text", "words" or "quote"; empty in sessions saved before word mode support
+	TextName    string `json:"text_name"`    // Name of the text being typed, or of the word set in word mode
+	TextContent string `json:"text_content"` // Full text content, including the generated words in word mode
 	TextPath    string `json:"text_path"`    // Path to text file (if from file)
 
 	// Progress information
@@ -104,6 +105,13 @@ func (sm *SessionManager) LoadSession() (*Session, error) {
 	return &session, nil
 }
 
+// ShouldPromptResume reports whether to ask before resuming a saved session on
+// startup: only if the startup prompt is enabled, a session is saved and the
+// --restore-session flag was not given explicitly to force the choice.
+func ShouldPromptResume(startupPrompt, hasSession, restoreForced bool) bool {
+	return startupPrompt && hasSession && !restoreForced
+}
+
 // HasSession checks if a saved session exists.
 func (sm *SessionManager) HasSession() bool {
 	_, err := os.Stat(sm.sessionPath)
@@ -129,17 +137,30 @@ func (sm *SessionManager) GetSessionPath() string {
 }
 
 // CreateSessionFromApp creates a Session from the current app state.
+// In word mode the generated words are saved as they are, so the same
+// sequence can be continued after a restart.
 // Note: Theme is not included as it's stored separately in settings.
 func CreateSessionFromApp(app *App) Session {
-	currentText := app.textLibrary.GetCurrentText()
-
-	return Session{
-		TextName:    currentText.Name,
-		TextContent: app.typingTest.GetSampleText(),
-		TextPath:    currentText.Path,
-		UserInput:   app.typingTest.GetUserInput(),
-		CursorPos:   app.typingTest.GetCursorPos(),
+	session := Session{
+		Mode:              app.mode,
+		TextContent:       app.typingTest.GetSampleText(),
+		UserInput:         app.typingTest.GetUserInput(),
+		CursorPos:         app.typingTest.GetCursorPos(),
+		StartTime:         app.typingTest.GetStatsStartTime(),
+		TotalKeystrokes:   app.typingTest.GetTotalKeystrokes(),
+		CorrectKeystrokes: app.typingTest.GetCorrectKeystrokes(),
+		MisspelledWords:   app.typingTest.GetMisspelledWordsMap(),
+		MisspelledOrder:   app.typingTest.GetStats().GetMisspelledWords(),
+		WordHadError:      app.typingTest.GetWordErrorsMap(),
+	}
+	if app.mode == "words" {
+		session.TextName = app.wordLibrary.GetCurrentWordSet().Name
+	} else {
+		currentText := app.textLibrary.GetCurrentText()
+		session.TextName = currentText.Name
+		session.TextPath = currentText.Path
 	}
+	return session
 }
 
 // SaveLeaderboard writes the leaderboard map to disk atomically.
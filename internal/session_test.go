@@ -0,0 +1,167 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestShouldPromptResume(t *testing.T) {
+	tests := []struct {
+		startupPrompt bool
+		hasSession    bool
+		restoreForced bool
+		want          bool
+	}{
+		{true, true, false, true},
+		{true, false, false, false}, // Nothing to resume
+		{true, true, true, false},   // --restore-session decides
+		{false, true, false, false}, // Resume silently as before
+	}
+
+	for _, tt := range tests {
+		got := ShouldPromptResume(tt.startupPrompt, tt.hasSession, tt.restoreForced)
+		if got != tt.want {
+			t.Errorf("ShouldPromptResume(%v, %v, %v) = %v, want %v",
+				tt.startupPrompt, tt.hasSession, tt.restoreForced, got, tt.want)
+		}
+	}
+}
+
+// newSessionTestApp saves a session with the startup prompt enabled and
+// starts an app that restores it.
+func newSessionTestApp(t *testing.T, restoreForced bool) *App {
+	t.Helper()
+
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	settingsManager, err := NewSettingsManager()
+	if err != nil {
+		t.Fatal(err)
+	}
+	settings := DefaultSettings()
+	settings.StartupPrompt = true
+	settings.Mode = "text"
+	if err := settingsManager.SaveSettings(settings); err != nil {
+		t.Fatal(err)
+	}
+	sessionManager, err := NewSessionManager()
+	if err != nil {
+		t.Fatal(err)
+	}
+	session := Session{TextName: "saved", TextContent: "hello world", UserInput: "hel", CursorPos: 3}
+	if err := sessionManager.SaveSession(session); err != nil {
+		t.Fatal(err)
+	}
+
+	screen := tcell.NewSimulationScreen("")
+	if err := screen.Init(); err != nil {
+		t.Fatalf("failed to initialize simulation screen: %v", err)
+	}
+	screen.SetSize(100, 40)
+	t.Cleanup(screen.Fini)
+
+	app, err := newApp(screen, "", []string{t.TempDir()}, true, restoreForced)
+	if err != nil {
+		t.Fatalf("failed to create app: %v", err)
+	}
+	return app
+}
+
+func TestResumePrompt(t *testing.T) {
+	app := newSessionTestApp(t, false)
+	if app.getCurrentMode() != ModeResumePrompt {
+		t.Fatalf("Expected the resume prompt on startup, got mode %v", app.getCurrentMode())
+	}
+
+	// Typing doesn't answer the prompt
+	app.handleKey(tcell.NewEventKey(tcell.KeyRune, 'x', tcell.ModNone))
+	if app.getCurrentMode() != ModeResumePrompt {
+		t.Fatal("Expected other keys to leave the prompt open")
+	}
+
+	app.handleKey(tcell.NewEventKey(tcell.KeyRune, 'y', tcell.ModNone))
+	if app.getCurrentMode() != ModeTyping || app.typingTest.GetUserInput() != "hel" {
+		t.Errorf("Expected to resume the session, got input %q", app.typingTest.GetUserInput())
+	}
+
+	app = newSessionTestApp(t, false)
+	app.handleKey(tcell.NewEventKey(tcell.KeyRune, 'n', tcell.ModNone))
+	if app.resumePrompt || app.typingTest.GetUserInput() != "" || app.sessionManager.HasSession() {
+		t.Error("Expected declining to discard the session and start fresh")
+	}
+	if app.textLibrary.SelectByName("saved") {
+		t.Error("Expected declining to remove the session's text from the library")
+	}
+
+	app = newSessionTestApp(t, true)
+	if app.resumePrompt || app.typingTest.GetUserInput() != "hel" {
+		t.Error("Expected --restore-session to resume without asking")
+	}
+}
+
+func TestWordModeSessionRoundTrip(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	wordsDir, err := GetDefaultWordsDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(wordsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for name, words := range map[string]string{"fruit": "apple banana cherry", "trees": "oak elm ash"} {
+		if err := os.WriteFile(filepath.Join(wordsDir, name+".txt"), []byte(words), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	settingsManager, err := NewSettingsManager()
+	if err != nil {
+		t.Fatal(err)
+	}
+	settings := DefaultSettings()
+	settings.Mode = "words"
+	settings.LastWordSet = "trees"
+	if err := settingsManager.SaveSettings(settings); err != nil {
+		t.Fatal(err)
+	}
+
+	newSimulatedApp := func(restoreSession bool) *App {
+		screen := tcell.NewSimulationScreen("")
+		if err := screen.Init(); err != nil {
+			t.Fatalf("failed to initialize simulation screen: %v", err)
+		}
+		screen.SetSize(100, 40)
+		t.Cleanup(screen.Fini)
+		app, err := newApp(screen, "", []string{t.TempDir()}, restoreSession, true)
+		if err != nil {
+			t.Fatalf("failed to create app: %v", err)
+		}
+		return app
+	}
+
+	app := newSimulatedApp(false)
+	app.selectWordSet("fruit")
+	words := app.typingTest.GetSampleText()
+	typeString(app.typingTest, words[:4]+"x")
+	if err := app.sessionManager.SaveSession(CreateSessionFromApp(app)); err != nil {
+		t.Fatal(err)
+	}
+
+	restored := newSimulatedApp(true)
+	if restored.mode != "words" || restored.wordLibrary.GetCurrentWordSet().Name != "fruit" {
+		t.Errorf("Expected word mode with the fruit word set, got mode %q and set %q",
+			restored.mode, restored.wordLibrary.GetCurrentWordSet().Name)
+	}
+	if restored.typingTest.GetSampleText() != words {
+		t.Errorf("Expected the generated words to be restored exactly, got %q, want %q",
+			restored.typingTest.GetSampleText(), words)
+	}
+	if restored.typingTest.GetUserInput() != words[:4]+"x" || restored.typingTest.GetCursorPos() != 5 {
+		t.Errorf("Expected the typed input to be restored, got %q at %d",
+			restored.typingTest.GetUserInput(), restored.typingTest.GetCursorPos())
+	}
+	if restored.textLibrary.HasText("fruit") {
+		t.Error("Expected the generated words not to be added to the text library")
+	}
+}
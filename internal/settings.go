@@ -5,26 +5,236 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
+	"time"
+)
+
+// Timer start modes for Settings.TimerStart.
+const (
+	TimerStartFirstKey = "first-key" // Timer starts with the first keystroke
+	TimerStartOnLoad   = "on-load"   // Timer starts when the typing view is shown
+)
+
+// Tab key roles for Settings.TabKey.
+const (
+	TabKeyAuto    = "auto"    // Type a tab when the text expects one, restart otherwise
+	TabKeyRestart = "restart" // Always restart the test
+	TabKeyType    = "type"    // Always type a tab character
+)
+
+// Enter key actions for Settings.ResultsEnterAction on the results screen.
+const (
+	ResultsEnterRestart = "restart" // Restart the test like 'r'
+	ResultsEnterNext    = "next"    // Move on to the next text, word set or quote
+)
+
+// Scroll anchors for Settings.ScrollAnchor in text mode.
+const (
+	ScrollAnchorSmooth = "smooth" // Scroll only when the cursor nears the bottom edge
+	ScrollAnchorTop    = "top"    // Keep the cursor in the top third of the viewport
+	ScrollAnchorCenter = "center" // Keep the cursor in the middle of the viewport
+	ScrollAnchorBottom = "bottom" // Keep the cursor just above the last visible line
+)
+
+// Line numbering modes for Settings.LineNumbering.
+const (
+	LineNumbersLogical = "logical" // Number the lines of the source text
+	LineNumbersWrapped = "wrapped" // Number every wrapped line on screen
+)
+
+// Speed units for Settings.SpeedUnit.
+const (
+	SpeedUnitWPM  = "wpm"  // Words per minute
+	SpeedUnitKPM  = "kpm"  // Correct keystrokes per minute
+	SpeedUnitBoth = "both" // WPM and KPM side by side
 )
 
 // Settings represents persistent user preferences that survive across sessions.
 // These settings are preserved even when clearing session data.
 type Settings struct {
-	ThemeName string `json:"theme_name"` // Current theme preference
+	ThemeName      string    `json:"theme_name"`               // Current theme preference
+	FavoriteThemes []string  `json:"favorite_themes"`          // Themes cycled through with Ctrl+T (empty = all)
+	ManualThemeAt  time.Time `json:"manual_theme_at,omitzero"` // When the user last picked a theme, which the schedule keeps until its next switch
 
 	// Mode settings
-	Mode string `json:"mode"` // "text" or "words"
+	Mode string `json:"mode"` // "text", "words" or "quote"
 
 	// Word mode settings
-	LimitType   string `json:"limit_type"`    // "time" or "words"
-	TimeLimit   int    `json:"time_limit"`    // Time limit in seconds (default: 60)
-	WordLimit   int    `json:"word_limit"`    // Word count limit (default: 50)
-	LastWordSet string `json:"last_word_set"` // Last selected word set name
+	LimitType      string `json:"limit_type"`      // "time" or "words"
+	TimeLimit      int    `json:"time_limit"`      // Time limit in seconds (default: 60)
+	WordLimit      int    `json:"word_limit"`      // Word count limit (default: 50)
+	LastWordSet    string `json:"last_word_set"`   // Last selected word set name
+	WordCase       string `json:"word_case"`       // "as-is", "capitalized" or "random-capitals"
+	MinWordLength  int    `json:"min_word_length"` // Only generate words with at least this many letters (0 = any)
+	Punctuation    bool   `json:"punctuation"`     // Add sentence punctuation to generated words
+	Capitalization bool   `json:"capitalization"`  // Capitalize some generated words and sentence starts
+	IncludeNumbers bool   `json:"include_numbers"` // Replace some generated words with numbers
+	HardWordsOnly  bool   `json:"hard_words_only"` // Only use the heavier half of the words of weighted word sets
+
+	// Text playlist
+	Playlist      []string `json:"playlist"`       // Text names practiced in order
+	PlaylistIndex int      `json:"playlist_index"` // Current playlist entry (-1 before the first)
+	PlaylistMode  bool     `json:"playlist_mode"`  // Move on to the next playlist text after each finished test
+
+	// Startup
+	StartupPrompt bool `json:"startup_prompt"` // Ask whether to resume a saved session instead of resuming it silently
+
+	// Command palette
+	CommandUsage map[string]int `json:"command_usage"` // How often each command was executed
+
+	// Typing behavior
+	SkipToNextWordOnSpace bool   `json:"skip_to_next_word_on_space"` // Space typed mid-word jumps to the next word
+	TypingSemantics       string `json:"typing_semantics"`           // "character" or "word"
+	TimerStart            string `json:"timer_start"`                // "first-key" or "on-load"
+	WPMLeadInMS           int    `json:"wpm_lead_in_ms"`             // Initial milliseconds ignored for WPM (0 = off)
+	TrackErrors           bool   `json:"track_errors"`               // Track misspelled words and list them on the results screen
+	RequireExactToFinish  bool   `json:"require_exact_to_finish"`    // Errors must be corrected before the test can finish
+	StrictFinish          bool   `json:"strict_finish"`              // Trailing whitespace of the text must be typed to finish
+	TabKey                string `json:"tab_key"`                    // "auto", "restart" or "type"
+	BlindMode             bool   `json:"blind_mode"`                 // Hide correctness feedback until the results
+	ErrorFeedback         string `json:"error_feedback"`             // Bell and flash on mistakes: "none", "subtle" or "strong"
+	Proofread             bool   `json:"proofread"`                  // Show texts with typos to be typed corrected
+
+	// Text loading
+	CleanGutenberg *bool    `json:"clean_gutenberg,omitempty"` // Older setting, moved into Preprocess as the gutenberg stage on load
+	Preprocess     []string `json:"preprocess"`                // Preprocessors applied to texts in order (see ApplyPipeline)
+	ChunkWords     int      `json:"chunk_words"`               // Split long texts into parts of about this many words (0 = off)
+
+	// Key bindings and results screen
+	Keymap             map[string]string `json:"keymap"`               // Key overrides per action (see Keymap)
+	ResultsFooter      string            `json:"results_footer"`       // Custom results help text (empty = generated from the keymap)
+	ResultsEnterAction string            `json:"results_enter_action"` // What Enter does: "restart" or "next"
+
+	// Appearance settings
+	ASCIIMode             string            `json:"ascii_mode"`             // "auto", "on" or "off" (ASCII-only decorations)
+	TransparentBackground bool              `json:"transparent_background"` // Use the terminal background instead of the theme's
+	AutoTheme             AutoThemeSchedule `json:"auto_theme"`             // Automatic day/night theme switching
+	ShowGraph             bool              `json:"show_graph"`             // Show the WPM timeline on the results screen
+	ShowSparkline         bool              `json:"show_sparkline"`         // Show a line of recent WPM below the text while typing
+	ShowLiveStats         bool              `json:"show_live_stats"`        // Show the live stats and the word mode progress while typing
+	DimStopwords          bool              `json:"dim_stopwords"`          // Dim common function words (the, a, of...) until typed
+	CelebrateMode         bool              `json:"celebrate"`              // Play a confetti animation after a perfect run
+	BigWord               bool              `json:"big_word"`               // Show the current word enlarged above the text in word mode
+	BoldText              bool              `json:"bold_text"`              // Draw the typing text bold
+	UnderlineWhitespace   bool              `json:"underline_whitespace"`   // Underline mistyped spaces and newlines instead of showing '_'
+	ShowMistypedOverlay   bool              `json:"show_mistyped_overlay"`  // Show mistyped characters above the text
+	ScrollAnchor          string            `json:"scroll_anchor"`          // "smooth", "top", "center" or "bottom"
+	TopMargin             int               `json:"top_margin"`             // Extra empty rows above the typing text
+	BottomMargin          int               `json:"bottom_margin"`          // Extra empty rows below the typing text
+	ShowLineNumbers       bool              `json:"show_line_numbers"`      // Show line numbers left of the text in text mode
+	HorizontalFocus       bool              `json:"horizontal_focus"`       // Keep the cursor at a fixed column and scroll the text under it
+	GenerationIndicator   bool              `json:"generation_indicator"`   // Mark the end of the text while word mode generates more words
+	FocusFade             bool              `json:"focus_fade"`             // Dim the title, help and stats while typing
+	DimBehindOverlays     bool              `json:"dim_behind_overlays"`    // Dim the screen behind the command palette and other overlays
+	LineNumbering         string            `json:"line_numbering"`         // "logical" or "wrapped"
+	SpeedUnit             string            `json:"speed_unit"`             // "wpm", "kpm" or "both"
+}
+
+// AutoThemeSchedule describes automatic switching between a day and a night theme
+// based on the local time of day.
+type AutoThemeSchedule struct {
+	Enabled        bool   `json:"enabled"`
+	DayTheme       string `json:"day_theme"`        // Theme used during the day
+	NightTheme     string `json:"night_theme"`      // Theme used during the night
+	DayStartHour   int    `json:"day_start_hour"`   // Hour (0-23) when the day theme starts
+	NightStartHour int    `json:"night_start_hour"` // Hour (0-23) when the night theme starts
+}
+
+// isDay reports whether the given time falls into the day period.
+// Supports schedules where the night period wraps around midnight (the usual case)
+// as well as inverted schedules where the day period does.
+func (s AutoThemeSchedule) isDay(now time.Time) bool {
+	hour := now.Hour()
+	if s.DayStartHour <= s.NightStartHour {
+		return hour >= s.DayStartHour && hour < s.NightStartHour
+	}
+	return hour >= s.DayStartHour || hour < s.NightStartHour
+}
+
+// ThemeFor returns the name of the theme scheduled for the given time.
+func (s AutoThemeSchedule) ThemeFor(now time.Time) string {
+	if s.isDay(now) {
+		return s.DayTheme
+	}
+	return s.NightTheme
+}
+
+// LastBoundary returns the most recent switch time (day or night start) at or before now.
+func (s AutoThemeSchedule) LastBoundary(now time.Time) time.Time {
+	var latest time.Time
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	for _, day := range []time.Time{today.AddDate(0, 0, -1), today} {
+		for _, hour := range []int{s.DayStartHour, s.NightStartHour} {
+			boundary := day.Add(time.Duration(hour) * time.Hour)
+			if !boundary.After(now) && boundary.After(latest) {
+				latest = boundary
+			}
+		}
+	}
+	return latest
+}
+
+// ScheduledTheme decides which theme should be active at the given time.
+// A manual theme change made after the most recent boundary wins until the next
+// boundary, so the schedule never overrides an explicit user choice.
+//
+// Parameters:
+//   - now: the current local time
+//   - manualChange: when the user last picked a theme manually (zero if never)
+//
+// Returns the scheduled theme name and whether it should be applied.
+func (s AutoThemeSchedule) ScheduledTheme(now, manualChange time.Time) (string, bool) {
+	if !s.Enabled {
+		return "", false
+	}
+	if !manualChange.IsZero() && manualChange.After(s.LastBoundary(now)) {
+		return "", false
+	}
+	name := s.ThemeFor(now)
+	return name, name != ""
+}
+
+// DefaultSettings returns the settings used when no settings file exists.
+// Fields missing from an existing settings file also fall back to these values.
+func DefaultSettings() Settings {
+	return Settings{
+		ThemeName:           "default",
+		Mode:                "text",
+		LimitType:           "time",
+		TimeLimit:           60,
+		WordLimit:           50,
+		LastWordSet:         "",
+		WordCase:            string(WordCaseAsIs),
+		PlaylistIndex:       -1,
+		TypingSemantics:     string(SemanticsCharacter),
+		TimerStart:          TimerStartFirstKey,
+		TrackErrors:         true,
+		StrictFinish:        true,
+		Preprocess:          []string{PreprocessGutenberg},
+		ErrorFeedback:       ErrorFeedbackNone,
+		TabKey:              TabKeyAuto,
+		ResultsEnterAction:  ResultsEnterRestart,
+		ASCIIMode:           ASCIIModeAuto,
+		ShowGraph:           true,
+		ShowLiveStats:       true,
+		ShowMistypedOverlay: true,
+		ScrollAnchor:        ScrollAnchorSmooth,
+		LineNumbering:       LineNumbersLogical,
+		SpeedUnit:           SpeedUnitWPM,
+		AutoTheme: AutoThemeSchedule{
+			Enabled:        false,
+			DayTheme:       "gruvbox-light",
+			NightTheme:     "gruvbox",
+			DayStartHour:   7,
+			NightStartHour: 19,
+		},
+	}
 }
 
 // SettingsManager handles saving and loading user settings.
 type SettingsManager struct {
 	settingsPath string
+	warnings     []string // Problems with the settings file found by LoadSettings
 }
 
 // NewSettingsManager creates a new settings manager.
@@ -64,14 +274,8 @@ func (sm *SettingsManager) LoadSettings() (*Settings, error) {
 	// Check if settings file exists
 	if _, err := os.Stat(sm.settingsPath); os.IsNotExist(err) {
 		// Return default settings
-		return &Settings{
-			ThemeName:   "default",
-			Mode:        "text",
-			LimitType:   "time",
-			TimeLimit:   60,
-			WordLimit:   50,
-			LastWordSet: "",
-		}, nil
+		settings := DefaultSettings()
+		return &settings, nil
 	}
 
 	// Read file
@@ -80,8 +284,8 @@ func (sm *SettingsManager) LoadSettings() (*Settings, error) {
 		return nil, fmt.Errorf("failed to read settings file: %w", err)
 	}
 
-	// Unmarshal JSON
-	var settings Settings
+	// Unmarshal JSON on top of defaults so new fields get sensible values
+	settings := DefaultSettings()
 	if err := json.Unmarshal(data, &settings); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal settings: %w", err)
 	}
@@ -100,9 +304,69 @@ func (sm *SettingsManager) LoadSettings() (*Settings, error) {
 		settings.WordLimit = 50
 	}
 
+	// Schedule hours edited by hand must be hours of the day
+	defaults := DefaultSettings()
+	if settings.AutoTheme.DayStartHour < 0 || settings.AutoTheme.DayStartHour > 23 {
+		settings.AutoTheme.DayStartHour = defaults.AutoTheme.DayStartHour
+	}
+	if settings.AutoTheme.NightStartHour < 0 || settings.AutoTheme.NightStartHour > 23 {
+		settings.AutoTheme.NightStartHour = defaults.AutoTheme.NightStartHour
+	}
+
+	// Key bindings edited by hand fall back to the defaults if invalid
+	sm.warnings = dropInvalidBindings(settings.Keymap)
+
+	// Margins edited by hand are kept within what the settings accept
+	settings.TopMargin = min(max(settings.TopMargin, 0), maxTypingMargin)
+	settings.BottomMargin = min(max(settings.BottomMargin, 0), maxTypingMargin)
+
+	// Settings files written before the preprocessing pipeline turn Gutenberg
+	// cleanup on or off with a separate flag
+	if settings.CleanGutenberg != nil {
+		settings.Preprocess = WithGutenberg(settings.Preprocess, *settings.CleanGutenberg)
+		settings.CleanGutenberg = nil
+	}
+
 	return &settings, nil
 }
 
+// MergeChangedSettings returns saved with every field that differs between
+// loaded and current set to its current value. loaded holds the settings as
+// the app started with them, i.e. saved with the rc file and the environment
+// applied, so values that only come from those layers are not written back.
+func MergeChangedSettings(saved, loaded, current Settings) Settings {
+	merged := CloneSettings(saved)
+	mergedValue := reflect.ValueOf(&merged).Elem()
+	loadedValue := reflect.ValueOf(loaded)
+	currentValue := reflect.ValueOf(current)
+	for i := range mergedValue.NumField() {
+		if !reflect.DeepEqual(loadedValue.Field(i).Interface(), currentValue.Field(i).Interface()) {
+			mergedValue.Field(i).Set(currentValue.Field(i))
+		}
+	}
+	return CloneSettings(merged)
+}
+
+// CloneSettings returns a deep copy of settings, so maps and slices of the copy
+// can be changed without affecting the original.
+func CloneSettings(settings Settings) Settings {
+	clone := settings
+	data, err := json.Marshal(settings)
+	if err == nil {
+		clone = Settings{}
+		if json.Unmarshal(data, &clone) != nil {
+			clone = settings
+		}
+	}
+	return clone
+}
+
+// Warnings returns the problems with the settings file the last LoadSettings
+// worked around, such as invalid key bindings replaced by the defaults.
+func (sm *SettingsManager) Warnings() []string {
+	return sm.warnings
+}
+
 // GetSettingsPath returns the path to the settings file.
 func (sm *SettingsManager) GetSettingsPath() string {
 	return sm.settingsPath
@@ -0,0 +1,172 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestAutoThemeScheduledTheme(t *testing.T) {
+	schedule := AutoThemeSchedule{
+		Enabled:        true,
+		DayTheme:       "gruvbox-light",
+		NightTheme:     "gruvbox",
+		DayStartHour:   7,
+		NightStartHour: 19,
+	}
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2024, 3, day, hour, minute, 0, 0, time.Local)
+	}
+
+	tests := []struct {
+		name         string
+		schedule     AutoThemeSchedule
+		now          time.Time
+		manualChange time.Time
+		expected     string
+		apply        bool
+	}{
+		{name: "morning is day", schedule: schedule, now: at(10, 9, 0), expected: "gruvbox-light", apply: true},
+		{name: "exactly at day start", schedule: schedule, now: at(10, 7, 0), expected: "gruvbox-light", apply: true},
+		{name: "evening is night", schedule: schedule, now: at(10, 20, 30), expected: "gruvbox", apply: true},
+		{name: "after midnight is night", schedule: schedule, now: at(10, 2, 0), expected: "gruvbox", apply: true},
+		{
+			name:         "manual change after boundary wins",
+			schedule:     schedule,
+			now:          at(10, 21, 0),
+			manualChange: at(10, 19, 30),
+			expected:     "",
+			apply:        false,
+		},
+		{
+			name:         "manual change before boundary is overridden",
+			schedule:     schedule,
+			now:          at(10, 19, 5),
+			manualChange: at(10, 18, 0),
+			expected:     "gruvbox",
+			apply:        true,
+		},
+		{
+			name:         "manual change yesterday evening, now after midnight",
+			schedule:     schedule,
+			now:          at(11, 1, 0),
+			manualChange: at(10, 22, 0),
+			expected:     "",
+			apply:        false,
+		},
+		{
+			name:     "disabled schedule never applies",
+			schedule: AutoThemeSchedule{DayTheme: "a", NightTheme: "b", DayStartHour: 7, NightStartHour: 19},
+			now:      at(10, 9, 0),
+			expected: "",
+			apply:    false,
+		},
+		{
+			name:     "inverted schedule",
+			schedule: AutoThemeSchedule{Enabled: true, DayTheme: "day", NightTheme: "night", DayStartHour: 20, NightStartHour: 4},
+			now:      at(10, 23, 0),
+			expected: "day",
+			apply:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name, apply := tt.schedule.ScheduledTheme(tt.now, tt.manualChange)
+			if name != tt.expected || apply != tt.apply {
+				t.Errorf("ScheduledTheme() = (%q, %v), want (%q, %v)", name, apply, tt.expected, tt.apply)
+			}
+		})
+	}
+}
+
+func TestAutoThemeLastBoundary(t *testing.T) {
+	schedule := AutoThemeSchedule{DayStartHour: 7, NightStartHour: 19}
+
+	now := time.Date(2024, 3, 10, 3, 0, 0, 0, time.Local)
+	expected := time.Date(2024, 3, 9, 19, 0, 0, 0, time.Local)
+	if got := schedule.LastBoundary(now); !got.Equal(expected) {
+		t.Errorf("LastBoundary(%v) = %v, want %v", now, got, expected)
+	}
+}
+
+func TestLoadSettingsClampsMargins(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	settingsManager, err := NewSettingsManager()
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeSettingsFile(t, settingsManager, `{"top_margin": -3, "bottom_margin": 99}`)
+
+	settings, err := settingsManager.LoadSettings()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if settings.TopMargin != 0 || settings.BottomMargin != maxTypingMargin {
+		t.Errorf("Expected margins 0 and %d, got %d and %d", maxTypingMargin, settings.TopMargin, settings.BottomMargin)
+	}
+}
+
+func TestLoadSettingsResetsInvalidScheduleHours(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	settingsManager, err := NewSettingsManager()
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeSettingsFile(t, settingsManager, `{"auto_theme": {"day_start_hour": 24, "night_start_hour": -1}}`)
+
+	settings, err := settingsManager.LoadSettings()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defaults := DefaultSettings().AutoTheme
+	if settings.AutoTheme.DayStartHour != defaults.DayStartHour || settings.AutoTheme.NightStartHour != defaults.NightStartHour {
+		t.Errorf("Expected the default hours, got %d and %d", settings.AutoTheme.DayStartHour, settings.AutoTheme.NightStartHour)
+	}
+}
+
+func TestLoadSettingsMovesCleanGutenbergIntoPipeline(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		want []string
+	}{
+		{"enabled", `{"clean_gutenberg": true, "preprocess": ["lowercase"]}`, []string{PreprocessGutenberg, PreprocessLowercase}},
+		{"disabled", `{"clean_gutenberg": false}`, []string{}},
+		{"pipeline only", `{"preprocess": ["lowercase"]}`, []string{PreprocessLowercase}},
+		{"neither", `{}`, []string{PreprocessGutenberg}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+			settingsManager, err := NewSettingsManager()
+			if err != nil {
+				t.Fatal(err)
+			}
+			writeSettingsFile(t, settingsManager, tt.json)
+
+			settings, err := settingsManager.LoadSettings()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(settings.Preprocess, tt.want) || settings.CleanGutenberg != nil {
+				t.Errorf("Expected pipeline %v without the old flag, got %v and %v", tt.want, settings.Preprocess, settings.CleanGutenberg)
+			}
+		})
+	}
+}
+
+// writeSettingsFile writes content as the settings file of settingsManager.
+func writeSettingsFile(t *testing.T, settingsManager *SettingsManager, content string) {
+	t.Helper()
+
+	if err := os.MkdirAll(filepath.Dir(settingsManager.GetSettingsPath()), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(settingsManager.GetSettingsPath(), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
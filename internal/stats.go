@@ -1,7 +1,9 @@
 package internal
 
 import (
+	"math"
 	"os/user"
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -14,6 +16,12 @@ const (
 	CharsPerWord = 5.0
 )
 
+const (
+	// maxKeystrokeLogEntries caps the full keystroke log so that pathological
+	// sessions (e.g. a stuck key) cannot grow memory without bound.
+	maxKeystrokeLogEntries = 100000
+)
+
 const (
 	// MaxLeaderboardEntries defines the maximum number of entries kept per leaderboard.
 	MaxLeaderboardEntries = 10
@@ -48,6 +56,13 @@ type keystrokeEvent struct {
 	correct   bool
 }
 
+// KeystrokeRecord is a single entry of the full keystroke log used for
+// end-of-test analytics.
+type KeystrokeRecord struct {
+	Timestamp time.Time // When the key was pressed
+	Correct   bool      // Whether the typed character matched the expected one
+}
+
 // Stats tracks typing test statistics including timing, accuracy, and error tracking.
 // It maintains detailed information about keystrokes, misspelled words, and test progress.
 //
@@ -59,14 +74,30 @@ type Stats struct {
 	startTime time.Time
 	endTime   time.Time
 
+	// Pausing: while paused the clock stands still (see Pause and Resume)
+	pausedAt    time.Time     // When the running pause began (zero if not paused)
+	pausedTotal time.Duration // Total length of the finished pauses
+
 	// Keystroke tracking
 	totalKeystrokes   int
 	correctKeystrokes int
+	combo             int // Correct keystrokes in a row since the last error
+	bestCombo         int // Longest combo of the test
+
+	// First time each sample position was reached (for per-segment timing)
+	positionReached []time.Time
+
+	// Reaction time discounted from the elapsed time used for final WPM
+	leadInDiscount time.Duration
 
 	// Instantaneous WPM tracking
 	keystrokeEvents  []keystrokeEvent // Recent keystrokes with timestamps
 	instantWindowSec float64          // Time window for instantaneous WPM (e.g., 3 seconds)
 
+	// Full keystroke log for end-of-test analytics (only filled when enabled)
+	retainKeystrokeLog bool
+	keystrokeLog       []KeystrokeRecord
+
 	// WPM timeline tracking
 	wpmHistory          []WPMSnapshot // Historical WPM measurements
 	lastSnapshotTime    time.Time     // Last time we took a snapshot
@@ -76,10 +107,24 @@ type Stats struct {
 	errorTimestamps []time.Time    // Timestamps of when errors occurred
 	misspelledWords map[string]int // Maps word to count of times misspelled
 	misspelledOrder []string       // Maintains insertion order of misspelled words
+	mistakes        []Mistake      // Every incorrectly typed character, in order
+
+	// Keystrokes and mistakes per CharCategory of the expected character (see
+	// GetCategoryAccuracy); unlike mistakes, the counts are not capped
+	categoryKeystrokes map[string]int
+	categoryMistakes   map[string]int
 
 	// Current word tracking for real-time error detection
+	trackErrors      bool         // Record misspelled words and word error flags
 	currentWordStart int          // Index where current word starts
 	wordHadError     map[int]bool // Maps word start position to error flag
+	completedWords   map[int]bool // Start positions of the words typed to their end
+	correctedWords   int          // Words that had an error but ended up typed correctly
+	uncorrectedWords int          // Words that had an error and were left wrong
+
+	// Per-word accuracy (word-based typing semantics)
+	wordAccuracy bool         // Compute accuracy from finished words instead of keystrokes
+	wordResults  map[int]bool // Maps word start position to whether it was typed correctly
 
 	// Test state
 	testComplete bool
@@ -91,6 +136,11 @@ func NewStats() *Stats {
 	return &Stats{
 		misspelledWords:     make(map[string]int),
 		wordHadError:        make(map[int]bool),
+		completedWords:      make(map[int]bool),
+		trackErrors:         true,
+		wordResults:         make(map[int]bool),
+		categoryKeystrokes:  make(map[string]int),
+		categoryMistakes:    make(map[string]int),
 		currentWordStart:    0,
 		testComplete:        false,
 		wpmHistory:          make([]WPMSnapshot, 0, 60),      // Pre-allocate for ~60 seconds
@@ -117,13 +167,83 @@ func (s *Stats) Finish() {
 	s.testComplete = true
 }
 
+// Pause stops the clock of a running test. It does nothing before the test
+// started, after it finished or while it is already paused.
+func (s *Stats) Pause() {
+	if s.startTime.IsZero() || s.testComplete || !s.pausedAt.IsZero() {
+		return
+	}
+	s.pausedAt = time.Now()
+}
+
+// Resume restarts the clock after Pause and returns how long the pause lasted
+// (0 if the test wasn't paused). The pause is added to the paused duration and
+// left out of the elapsed time: the start and all recorded timestamps are moved
+// forward by it, so WPM, the timeline and the keystroke intervals continue as if
+// there had been no break.
+func (s *Stats) Resume() time.Duration {
+	if s.pausedAt.IsZero() {
+		return 0
+	}
+	pause := time.Since(s.pausedAt)
+	s.pausedAt = time.Time{}
+	s.pausedTotal += pause
+
+	s.startTime = s.startTime.Add(pause)
+	s.lastSnapshotTime = shiftTime(s.lastSnapshotTime, pause)
+	for i := range s.positionReached {
+		s.positionReached[i] = shiftTime(s.positionReached[i], pause)
+	}
+	for i := range s.errorTimestamps {
+		s.errorTimestamps[i] = s.errorTimestamps[i].Add(pause)
+	}
+	for i := range s.keystrokeEvents {
+		s.keystrokeEvents[i].timestamp = s.keystrokeEvents[i].timestamp.Add(pause)
+	}
+	for i := range s.keystrokeLog {
+		s.keystrokeLog[i].Timestamp = s.keystrokeLog[i].Timestamp.Add(pause)
+	}
+	for i := range s.wpmHistory {
+		s.wpmHistory[i].Timestamp = s.wpmHistory[i].Timestamp.Add(pause)
+	}
+	for i := range s.mistakes {
+		s.mistakes[i].Timestamp = s.mistakes[i].Timestamp.Add(pause)
+	}
+	return pause
+}
+
+// shiftTime moves t forward by d, keeping the zero time (not reached yet) as is.
+func shiftTime(t time.Time, d time.Duration) time.Time {
+	if t.IsZero() {
+		return t
+	}
+	return t.Add(d)
+}
+
+// IsPaused returns whether the clock is stopped by Pause.
+func (s *Stats) IsPaused() bool {
+	return !s.pausedAt.IsZero()
+}
+
+// GetPausedAt returns when the running pause began (zero if not paused).
+func (s *Stats) GetPausedAt() time.Time {
+	return s.pausedAt
+}
+
+// GetPausedDuration returns the total length of the finished pauses, which is
+// not counted in the elapsed time.
+func (s *Stats) GetPausedDuration() time.Duration {
+	return s.pausedTotal
+}
+
 // IsComplete returns whether the typing test has finished.
 func (s *Stats) IsComplete() bool {
 	return s.testComplete
 }
 
 // RecordKeystroke records a single keystroke and tracks whether it was correct.
-// This method updates both total keystroke count and correct keystroke count.
+// This method updates the total and correct keystroke counts and the combo,
+// which an incorrect keystroke resets.
 // It also updates the WPM timeline at regular intervals.
 //
 // Parameters:
@@ -132,7 +252,10 @@ func (s *Stats) RecordKeystroke(correct bool) {
 	s.totalKeystrokes++
 	if correct {
 		s.correctKeystrokes++
+		s.combo++
+		s.bestCombo = max(s.bestCombo, s.combo)
 	} else {
+		s.combo = 0
 		// Record timestamp of error
 		if !s.startTime.IsZero() {
 			s.errorTimestamps = append(s.errorTimestamps, time.Now())
@@ -141,16 +264,182 @@ func (s *Stats) RecordKeystroke(correct bool) {
 
 	// Record keystroke event with timestamp for instantaneous WPM
 	if !s.startTime.IsZero() {
+		now := time.Now()
 		s.keystrokeEvents = append(s.keystrokeEvents, keystrokeEvent{
-			timestamp: time.Now(),
+			timestamp: now,
 			correct:   correct,
 		})
+
+		// Keep the complete history when analytics are enabled
+		if s.retainKeystrokeLog && len(s.keystrokeLog) < maxKeystrokeLogEntries {
+			s.keystrokeLog = append(s.keystrokeLog, KeystrokeRecord{
+				Timestamp: now,
+				Correct:   correct,
+			})
+		}
 	}
 
 	// Update WPM timeline
 	s.updateWPMTimeline()
 }
 
+// SetRetainKeystrokeLog enables or disables retention of the full keystroke log.
+// The rolling window used for instantaneous WPM is trimmed to the last few seconds,
+// so analytics that need the whole test (bursts, per-word timing, replay) must
+// enable this before typing starts. The log is capped at maxKeystrokeLogEntries.
+func (s *Stats) SetRetainKeystrokeLog(enabled bool) {
+	s.retainKeystrokeLog = enabled
+	if !enabled {
+		s.keystrokeLog = nil
+	}
+}
+
+// GetFullKeystrokeLog returns a copy of the complete keystroke log.
+// Returns an empty slice if log retention is disabled.
+func (s *Stats) GetFullKeystrokeLog() []KeystrokeRecord {
+	// Return a copy to prevent external modification
+	result := make([]KeystrokeRecord, len(s.keystrokeLog))
+	copy(result, s.keystrokeLog)
+	return result
+}
+
+// GetLatencyHistogram counts the intervals between consecutive keystrokes of the
+// full keystroke log per bucket. Buckets are ascending upper bounds in
+// milliseconds: an interval lands in the first bucket it doesn't exceed, so a
+// value on a boundary belongs to the lower bucket. The result has one more entry
+// than buckets, counting the intervals above the last bound.
+// All counts are 0 if log retention is disabled.
+//
+// Parameters:
+//   - buckets: ascending upper bounds of the buckets in milliseconds
+func (s *Stats) GetLatencyHistogram(buckets []float64) []int {
+	counts := make([]int, len(buckets)+1)
+	for i := 1; i < len(s.keystrokeLog); i++ {
+		interval := s.keystrokeLog[i].Timestamp.Sub(s.keystrokeLog[i-1].Timestamp)
+		ms := float64(interval) / float64(time.Millisecond)
+		counts[sort.SearchFloat64s(buckets, ms)]++
+	}
+	return counts
+}
+
+// GetRhythmSeries returns the rolling coefficient of variation (standard
+// deviation divided by mean) of the intervals between consecutive keystrokes of
+// the full keystroke log, one value per window of windowSize intervals. Low
+// values mean an even typing rhythm, independent of speed. The series is empty
+// if log retention is disabled or there are fewer than windowSize intervals.
+//
+// Parameters:
+//   - windowSize: number of consecutive intervals per value
+func (s *Stats) GetRhythmSeries(windowSize int) []float64 {
+	if windowSize < 1 || len(s.keystrokeLog)-1 < windowSize {
+		return nil
+	}
+
+	intervals := make([]float64, len(s.keystrokeLog)-1)
+	for i := range intervals {
+		interval := s.keystrokeLog[i+1].Timestamp.Sub(s.keystrokeLog[i].Timestamp)
+		intervals[i] = float64(interval) / float64(time.Millisecond)
+	}
+	return rollingCV(intervals, windowSize)
+}
+
+// rollingCV returns the coefficient of variation of every run of windowSize
+// consecutive values. Windows with a mean of zero yield 0.
+func rollingCV(values []float64, windowSize int) []float64 {
+	series := make([]float64, 0, len(values)-windowSize+1)
+	for end := windowSize; end <= len(values); end++ {
+		series = append(series, coefficientOfVariation(values[end-windowSize:end]))
+	}
+	return series
+}
+
+// coefficientOfVariation returns the standard deviation of values relative to
+// their mean. Values with a mean of zero yield 0.
+func coefficientOfVariation(values []float64) float64 {
+	mean := 0.0
+	for _, v := range values {
+		mean += v
+	}
+	mean /= float64(len(values))
+	if mean == 0 {
+		return 0
+	}
+
+	variance := 0.0
+	for _, v := range values {
+		variance += (v - mean) * (v - mean)
+	}
+	variance /= float64(len(values))
+	return math.Sqrt(variance) / mean
+}
+
+// GetConsistency returns how steady the typing speed was: 100% minus the
+// coefficient of variation of the WPM snapshots in percent, clamped to
+// [0, 100]. Returns 100.0 with fewer than two snapshots, so short tests
+// don't show noise, and 0 if every snapshot is 0 WPM.
+func (s *Stats) GetConsistency() float64 {
+	if len(s.wpmHistory) < 2 {
+		return 100.0
+	}
+	values := make([]float64, len(s.wpmHistory))
+	for i, snapshot := range s.wpmHistory {
+		values[i] = snapshot.WPM
+	}
+	if !slices.ContainsFunc(values, func(wpm float64) bool { return wpm != 0 }) {
+		return 0
+	}
+	return max(0, min(100, 100-coefficientOfVariation(values)*100))
+}
+
+// RecordMistake records an incorrectly typed character.
+// Recording stops after maxMistakes entries, but every mistake still counts
+// towards GetCategoryAccuracy.
+//
+// Parameters:
+//   - position: index of the expected character in the sample text
+//   - expected: the character in the sample text (0 if there is none)
+//   - typed: the character the user typed
+func (s *Stats) RecordMistake(position int, expected, typed rune) {
+	s.categoryMistakes[CharCategory(expected)]++
+	if len(s.mistakes) >= maxMistakes {
+		return
+	}
+	s.mistakes = append(s.mistakes, Mistake{
+		Timestamp: time.Now(),
+		Position:  position,
+		Expected:  expected,
+		Typed:     typed,
+	})
+}
+
+// RecordExpectedChar counts a keystroke towards the category of the character
+// that was expected (see CharCategory). Characters without a category, like
+// whitespace, are not counted.
+func (s *Stats) RecordExpectedChar(expected rune) {
+	if category := CharCategory(expected); category != "" {
+		s.categoryKeystrokes[category]++
+	}
+}
+
+// GetCategoryAccuracy returns the accuracy in percent for each character
+// category that was typed, from the keystrokes counted by RecordExpectedChar
+// and the mistakes of that category counted by RecordMistake.
+func (s *Stats) GetCategoryAccuracy() map[string]float64 {
+	accuracy := make(map[string]float64, len(s.categoryKeystrokes))
+	for category, keystrokes := range s.categoryKeystrokes {
+		correct := max(keystrokes-s.categoryMistakes[category], 0)
+		accuracy[category] = float64(correct) / float64(keystrokes) * 100
+	}
+	return accuracy
+}
+
+// GetMistakes returns a copy of the recorded mistakes in the order they were made.
+func (s *Stats) GetMistakes() []Mistake {
+	result := make([]Mistake, len(s.mistakes))
+	copy(result, s.mistakes)
+	return result
+}
+
 // MarkCurrentWordAsError marks that the word starting at the given position has an error.
 // This flag persists even if the user backspaces and corrects the error, ensuring that
 // corrections don't hide mistakes in the final statistics.
@@ -158,6 +447,9 @@ func (s *Stats) RecordKeystroke(correct bool) {
 // Parameters:
 //   - wordStart: the character index where the word begins in the sample text
 func (s *Stats) MarkCurrentWordAsError(wordStart int) {
+	if !s.trackErrors {
+		return
+	}
 	s.wordHadError[wordStart] = true
 }
 
@@ -170,6 +462,53 @@ func (s *Stats) WordHadError(wordStart int) bool {
 	return s.wordHadError[wordStart]
 }
 
+// RecordCompletedWord records that the word starting at the given position was
+// typed to its end. Completing a word again after backspacing into it counts once.
+func (s *Stats) RecordCompletedWord(wordStart int) {
+	s.completedWords[wordStart] = true
+}
+
+// GetCompletedWordCount returns how many words have been typed to their end.
+func (s *Stats) GetCompletedWordCount() int {
+	return len(s.completedWords)
+}
+
+// GetWordAccuracy returns the percentage of completed words typed without any
+// error. Unlike GetAccuracy, a word with several mistakes counts once, and
+// corrected mistakes still count. Returns 100.0 before the first word is completed.
+func (s *Stats) GetWordAccuracy() float64 {
+	if len(s.completedWords) == 0 {
+		return 100.0
+	}
+	clean := 0
+	for wordStart := range s.completedWords {
+		if !s.wordHadError[wordStart] {
+			clean++
+		}
+	}
+	return (float64(clean) / float64(len(s.completedWords))) * 100.0
+}
+
+// RecordErrorWordOutcome records how a word that had an error ended up when the
+// test finished: fixed (typed correctly in the end) or left wrong.
+func (s *Stats) RecordErrorWordOutcome(corrected bool) {
+	if corrected {
+		s.correctedWords++
+	} else {
+		s.uncorrectedWords++
+	}
+}
+
+// GetCorrectedWordCount returns how many words had an error that was fixed before finishing.
+func (s *Stats) GetCorrectedWordCount() int {
+	return s.correctedWords
+}
+
+// GetUncorrectedWordCount returns how many words had an error and were left wrong.
+func (s *Stats) GetUncorrectedWordCount() int {
+	return s.uncorrectedWords
+}
+
 // RecordMisspelledWord records a word that was misspelled during the test.
 // If the word was already misspelled, increments its count. Empty strings are ignored.
 // The first occurrence of each misspelled word is tracked for maintaining display order.
@@ -177,6 +516,10 @@ func (s *Stats) WordHadError(wordStart int) bool {
 // Parameters:
 //   - word: the word from the sample text that was typed incorrectly
 func (s *Stats) RecordMisspelledWord(word string) {
+	if !s.trackErrors {
+		return
+	}
+
 	// Trim all whitespace (spaces, tabs, newlines) from the word
 	word = strings.TrimSpace(word)
 
@@ -217,6 +560,102 @@ func (s *Stats) RecordMisspelledWord(word string) {
 	s.misspelledWords[word]++
 }
 
+// RecordPosition records the first time the cursor reached the given sample
+// position. Later visits (e.g. after backspacing) keep the original time.
+// Ignored before the test has started.
+func (s *Stats) RecordPosition(pos int) {
+	if s.startTime.IsZero() || pos < 0 {
+		return
+	}
+	for len(s.positionReached) <= pos {
+		s.positionReached = append(s.positionReached, time.Time{})
+	}
+	if s.positionReached[pos].IsZero() {
+		s.positionReached[pos] = time.Now()
+	}
+}
+
+// timeReached returns when the cursor first reached pos, or the first recorded
+// position after it if pos itself was skipped over. Position 0 is the start time.
+// Returns the zero time if pos was never reached.
+func (s *Stats) timeReached(pos int) time.Time {
+	if pos <= 0 {
+		return s.startTime
+	}
+	for p := pos; p < len(s.positionReached); p++ {
+		if !s.positionReached[p].IsZero() {
+			return s.positionReached[p]
+		}
+	}
+	return time.Time{}
+}
+
+// GetSegmentWPMs returns the typing speed for each segment between consecutive
+// boundaries (sample positions, ascending), e.g. the start of every line.
+// Segments that weren't completed or took no measurable time have a WPM of 0.
+//
+// Parameters:
+//   - boundaries: sample positions delimiting the segments; n boundaries give n-1 segments
+func (s *Stats) GetSegmentWPMs(boundaries []int) []float64 {
+	if len(boundaries) < 2 {
+		return nil
+	}
+
+	wpms := make([]float64, len(boundaries)-1)
+	for i := range wpms {
+		start := s.timeReached(boundaries[i])
+		end := s.timeReached(boundaries[i+1])
+		chars := boundaries[i+1] - boundaries[i]
+		if start.IsZero() || end.IsZero() || chars <= 0 {
+			continue
+		}
+
+		minutes := end.Sub(start).Minutes()
+		if minutes <= 0 {
+			continue
+		}
+		wpms[i] = float64(chars) / CharsPerWord / minutes
+	}
+	return wpms
+}
+
+// SetLeadInDiscount sets a lead-in that is subtracted from the elapsed time in GetWPM,
+// so the reaction time before the first keystroke doesn't drag down the result.
+// Negative values are treated as zero.
+func (s *Stats) SetLeadInDiscount(d time.Duration) {
+	s.leadInDiscount = max(d, 0)
+}
+
+// SetTrackErrors enables or disables misspelled word tracking. When disabled,
+// words are never marked as having errors or recorded as misspelled; accuracy
+// is still computed from keystrokes. Tracking is enabled by default.
+func (s *Stats) SetTrackErrors(enabled bool) {
+	s.trackErrors = enabled
+}
+
+// SetWordAccuracy switches accuracy between keystroke-based and per-word calculation.
+func (s *Stats) SetWordAccuracy(enabled bool) {
+	s.wordAccuracy = enabled
+}
+
+// RecordWordResult records whether the word starting at the given position was typed
+// correctly. Recording the same word again (after backspacing into it) replaces the
+// previous result.
+//
+// Parameters:
+//   - wordStart: the character index where the word begins in the sample text
+//   - correct: true if the typed word matched the sample word exactly
+func (s *Stats) RecordWordResult(wordStart int, correct bool) {
+	s.wordResults[wordStart] = correct
+}
+
+// GetWordResult returns whether the word starting at the given position was typed
+// correctly, and false for ok if no result was recorded for it.
+func (s *Stats) GetWordResult(wordStart int) (correct, ok bool) {
+	correct, ok = s.wordResults[wordStart]
+	return correct, ok
+}
+
 // SetCurrentWordStart updates the index where the current word begins.
 // This is used for tracking word boundaries as the user types.
 //
@@ -290,22 +729,31 @@ func (s *Stats) CheckCurrentWordForErrors(sampleText, userInput string, wordStar
 //   - Less than 1 second has elapsed
 //   - No time has passed (edge case)
 func (s *Stats) GetWPM() float64 {
+	return s.wordsPerMinute(s.correctKeystrokes)
+}
+
+// GetRawWPM calculates the typing speed in words per minute from all
+// keystrokes, correct or not, showing how fast the keys were hit before
+// corrections. Timing and edge cases are the same as for GetWPM.
+func (s *Stats) GetRawWPM() float64 {
+	return s.wordsPerMinute(s.totalKeystrokes)
+}
+
+// wordsPerMinute converts a number of keystrokes to words per minute over the
+// elapsed time of the test, as described for GetWPM.
+func (s *Stats) wordsPerMinute(keystrokes int) float64 {
 	if s.startTime.IsZero() {
 		return 0
 	}
 
-	var duration time.Duration
-	if s.testComplete {
-		duration = s.endTime.Sub(s.startTime)
-	} else {
-		duration = time.Since(s.startTime)
-	}
+	// Ignore the initial hesitation before typing got going
+	duration := s.GetDuration() - s.leadInDiscount
 
 	if duration.Seconds() < 1 {
 		return 0
 	}
 
-	words := float64(s.correctKeystrokes) / CharsPerWord
+	words := float64(keystrokes) / CharsPerWord
 	minutes := duration.Minutes()
 
 	if minutes == 0 {
@@ -316,10 +764,24 @@ func (s *Stats) GetWPM() float64 {
 }
 
 // GetAccuracy calculates typing accuracy as a percentage.
-// Accuracy is the ratio of correct keystrokes to total keystrokes.
+// Accuracy is the ratio of correct keystrokes to total keystrokes, or the ratio
+// of correctly typed words to finished words when per-word accuracy is enabled.
 //
-// Returns 100.0 if no keystrokes have been recorded yet.
+// Returns 100.0 if nothing has been recorded yet.
 func (s *Stats) GetAccuracy() float64 {
+	if s.wordAccuracy {
+		if len(s.wordResults) == 0 {
+			return 100.0
+		}
+		correct := 0
+		for _, ok := range s.wordResults {
+			if ok {
+				correct++
+			}
+		}
+		return (float64(correct) / float64(len(s.wordResults))) * 100.0
+	}
+
 	if s.totalKeystrokes == 0 {
 		return 100.0
 	}
@@ -338,17 +800,56 @@ func (s *Stats) GetMisspelledWordCount(word string) int {
 	return s.misspelledWords[word]
 }
 
+// GetDuration returns how long the test took, or how long it has been running
+// if it isn't complete yet. Returns 0 if the test hasn't started.
+func (s *Stats) GetDuration() time.Duration {
+	if s.startTime.IsZero() {
+		return 0
+	}
+	if s.testComplete {
+		return s.endTime.Sub(s.startTime)
+	}
+	if s.IsPaused() {
+		return s.pausedAt.Sub(s.startTime)
+	}
+	return time.Since(s.startTime)
+}
+
+// GetEndTime returns when the test was completed (zero if not complete).
+func (s *Stats) GetEndTime() time.Time {
+	return s.endTime
+}
+
 // GetStartTime returns the time when the test started.
 // Returns zero time if test hasn't started yet.
 func (s *Stats) GetStartTime() time.Time {
 	return s.startTime
 }
 
+// GetLastKeystrokeTime returns when the most recent keystroke was recorded.
+// Returns zero time if no keystroke was recorded since the test started.
+func (s *Stats) GetLastKeystrokeTime() time.Time {
+	if len(s.keystrokeEvents) == 0 {
+		return time.Time{}
+	}
+	return s.keystrokeEvents[len(s.keystrokeEvents)-1].timestamp
+}
+
 // GetTotalKeystrokes returns the total number of keystrokes recorded.
 func (s *Stats) GetTotalKeystrokes() int {
 	return s.totalKeystrokes
 }
 
+// GetCombo returns the number of correct keystrokes in a row since the last error.
+func (s *Stats) GetCombo() int {
+	return s.combo
+}
+
+// GetBestCombo returns the longest run of correct keystrokes in the test.
+func (s *Stats) GetBestCombo() int {
+	return s.bestCombo
+}
+
 // GetCorrectKeystrokes returns the number of correct keystrokes recorded.
 func (s *Stats) GetCorrectKeystrokes() int {
 	return s.correctKeystrokes
@@ -1,6 +1,8 @@
 package internal
 
 import (
+	"math"
+	"slices"
 	"testing"
 	"time"
 )
@@ -88,3 +90,445 @@ func TestWPMHistoryImmutable(t *testing.T) {
 		t.Error("Returned history is not a copy, internal state was modified")
 	}
 }
+
+// ageKeystrokes shifts all recorded keystroke timestamps into the past and forces
+// the next keystroke to take a snapshot, which triggers rolling-window cleanup.
+func ageKeystrokes(stats *Stats, by time.Duration) {
+	for i := range stats.keystrokeEvents {
+		stats.keystrokeEvents[i].timestamp = stats.keystrokeEvents[i].timestamp.Add(-by)
+	}
+	for i := range stats.keystrokeLog {
+		stats.keystrokeLog[i].Timestamp = stats.keystrokeLog[i].Timestamp.Add(-by)
+	}
+	stats.lastSnapshotTime = time.Now().Add(-by)
+}
+
+func TestFullKeystrokeLogRetention(t *testing.T) {
+	tests := []struct {
+		name        string
+		retain      bool
+		expectedLog int
+	}{
+		{name: "retained when enabled", retain: true, expectedLog: 21},
+		{name: "empty when disabled", retain: false, expectedLog: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stats := NewStats()
+			stats.SetRetainKeystrokeLog(tt.retain)
+			stats.Start()
+
+			for i := 0; i < 20; i++ {
+				stats.RecordKeystroke(i%5 != 0)
+			}
+
+			// Push everything outside the 10 second cleanup window
+			ageKeystrokes(stats, 20*time.Second)
+			stats.RecordKeystroke(true)
+
+			// The rolling window is always trimmed
+			if len(stats.keystrokeEvents) != 1 {
+				t.Errorf("Expected rolling window trimmed to 1 event, got %d", len(stats.keystrokeEvents))
+			}
+
+			log := stats.GetFullKeystrokeLog()
+			if len(log) != tt.expectedLog {
+				t.Fatalf("Expected %d log entries, got %d", tt.expectedLog, len(log))
+			}
+			if tt.retain && log[0].Correct {
+				t.Error("Expected first logged keystroke to be incorrect")
+			}
+		})
+	}
+}
+
+func TestFullKeystrokeLogCapped(t *testing.T) {
+	stats := NewStats()
+	stats.SetRetainKeystrokeLog(true)
+	stats.Start()
+
+	for i := 0; i < maxKeystrokeLogEntries+10; i++ {
+		stats.RecordKeystroke(true)
+	}
+
+	if got := len(stats.GetFullKeystrokeLog()); got != maxKeystrokeLogEntries {
+		t.Errorf("Expected log capped at %d entries, got %d", maxKeystrokeLogEntries, got)
+	}
+}
+
+func TestLatencyHistogram(t *testing.T) {
+	stats := NewStats()
+	stats.SetRetainKeystrokeLog(true)
+
+	// Intervals: 30, 50 (on a boundary), 51, 100 (on a boundary), 250 and 1000 ms
+	start := time.Now()
+	offsets := []int{0, 30, 80, 131, 231, 481, 1481}
+	for _, ms := range offsets {
+		stats.keystrokeLog = append(stats.keystrokeLog, KeystrokeRecord{
+			Timestamp: start.Add(time.Duration(ms) * time.Millisecond),
+			Correct:   true,
+		})
+	}
+
+	got := stats.GetLatencyHistogram([]float64{50, 100, 200})
+	want := []int{2, 2, 0, 2}
+	if !slices.Equal(got, want) {
+		t.Errorf("Expected bucket counts %v, got %v", want, got)
+	}
+
+	if got := NewStats().GetLatencyHistogram([]float64{50, 100}); !slices.Equal(got, []int{0, 0, 0}) {
+		t.Errorf("Expected empty buckets without a keystroke log, got %v", got)
+	}
+}
+
+func TestWPMWithStartBeforeFirstKeystroke(t *testing.T) {
+	stats := NewStats()
+	// Timer started on load, 30 seconds before any keystroke
+	stats.RestoreFromSession(time.Now().Add(-30*time.Second), 0, 0, map[string]int{}, nil, map[int]bool{})
+	stats.Start() // First keystroke must not move the start time
+
+	for i := 0; i < 25; i++ {
+		stats.RecordKeystroke(true)
+	}
+
+	// 25 chars = 5 words in 0.5 minutes
+	if wpm := stats.GetWPM(); wpm < 9.9 || wpm > 10.1 {
+		t.Errorf("Expected ~10 WPM measured from load, got %.2f", wpm)
+	}
+}
+
+func TestWPMZeroWithinFirstSecondOfLoad(t *testing.T) {
+	stats := NewStats()
+	stats.Start()
+
+	if wpm := stats.GetWPM(); wpm != 0 {
+		t.Errorf("Expected 0 WPM before one second has passed, got %.2f", wpm)
+	}
+}
+
+func TestWPMLeadInDiscount(t *testing.T) {
+	tests := []struct {
+		name     string
+		leadIn   time.Duration
+		wantWPM  float64
+		tolerate float64
+	}{
+		{"no discount", 0, 10, 0.1},                    // 5 words in 30s
+		{"half discounted", 15 * time.Second, 20, 0.2}, // 5 words in 15s
+		{"negative treated as zero", -time.Second, 10, 0.1},
+		{"discount exceeds duration", time.Minute, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stats := NewStats()
+			stats.SetLeadInDiscount(tt.leadIn)
+			stats.RestoreFromSession(time.Now().Add(-30*time.Second), 0, 0, map[string]int{}, nil, map[int]bool{})
+			for i := 0; i < 25; i++ {
+				stats.RecordKeystroke(true)
+			}
+
+			wpm := stats.GetWPM()
+			if wpm < tt.wantWPM-tt.tolerate || wpm > tt.wantWPM+tt.tolerate {
+				t.Errorf("Expected ~%.1f WPM, got %.2f", tt.wantWPM, wpm)
+			}
+		})
+	}
+}
+
+func TestGetSegmentWPMs(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	stats := NewStats()
+	stats.startTime = start
+	// 100 characters; position 50 reached after 30s, 100 after 90s
+	stats.positionReached = make([]time.Time, 101)
+	stats.positionReached[50] = start.Add(30 * time.Second)
+	stats.positionReached[100] = start.Add(90 * time.Second)
+
+	wpms := stats.GetSegmentWPMs([]int{0, 50, 100})
+	if len(wpms) != 2 {
+		t.Fatalf("Expected 2 segments, got %d", len(wpms))
+	}
+	// 50 chars = 10 words in 0.5 min = 20 WPM; then 10 words in 1 min = 10 WPM
+	if wpms[0] < 19.9 || wpms[0] > 20.1 {
+		t.Errorf("Expected ~20 WPM for first segment, got %.2f", wpms[0])
+	}
+	if wpms[1] < 9.9 || wpms[1] > 10.1 {
+		t.Errorf("Expected ~10 WPM for second segment, got %.2f", wpms[1])
+	}
+}
+
+func TestGetSegmentWPMsSkippedAndUnfinished(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	stats := NewStats()
+	stats.startTime = start
+	// Position 50 was skipped over (word skip); 52 is the next recorded position
+	stats.positionReached = make([]time.Time, 53)
+	stats.positionReached[52] = start.Add(30 * time.Second)
+
+	wpms := stats.GetSegmentWPMs([]int{0, 50, 100})
+	if wpms[0] < 19.9 || wpms[0] > 20.1 {
+		t.Errorf("Expected skipped boundary to use the next recorded time, got %.2f", wpms[0])
+	}
+	if wpms[1] != 0 {
+		t.Errorf("Expected 0 WPM for an unfinished segment, got %.2f", wpms[1])
+	}
+
+	if got := stats.GetSegmentWPMs([]int{0}); got != nil {
+		t.Errorf("Expected no segments for a single boundary, got %v", got)
+	}
+}
+
+func TestRecordPositionKeepsFirstVisit(t *testing.T) {
+	stats := NewStats()
+	stats.RecordPosition(1) // Ignored before start
+	if len(stats.positionReached) != 0 {
+		t.Fatal("Expected positions to be ignored before the test starts")
+	}
+
+	stats.Start()
+	stats.RecordPosition(3)
+	first := stats.positionReached[3]
+	time.Sleep(time.Millisecond)
+	stats.RecordPosition(3)
+
+	if !stats.positionReached[3].Equal(first) {
+		t.Error("Expected revisiting a position to keep the first time")
+	}
+}
+
+func TestGetDuration(t *testing.T) {
+	stats := NewStats()
+	if d := stats.GetDuration(); d != 0 {
+		t.Errorf("Expected 0 before the test starts, got %v", d)
+	}
+
+	start := time.Date(2024, time.March, 4, 12, 0, 0, 0, time.UTC)
+	stats.startTime = start
+	if d := stats.GetDuration(); d < time.Since(start)-time.Second {
+		t.Errorf("Expected running duration measured from start, got %v", d)
+	}
+
+	stats.endTime = start.Add(42 * time.Second)
+	stats.testComplete = true
+	if d := stats.GetDuration(); d != 42*time.Second {
+		t.Errorf("Expected 42s for a finished test, got %v", d)
+	}
+}
+
+func TestPauseExcludedFromDuration(t *testing.T) {
+	stats := NewStats()
+	stats.Pause()
+	if stats.IsPaused() {
+		t.Error("Expected no pause before the test starts")
+	}
+
+	// Typed for 30s, then paused for 30s
+	now := time.Now()
+	stats.startTime = now.Add(-time.Minute)
+	stats.positionReached = []time.Time{now.Add(-time.Minute), {}}
+	stats.Pause()
+	stats.pausedAt = now.Add(-30 * time.Second)
+	if d := stats.GetDuration(); d < 29*time.Second || d > 31*time.Second {
+		t.Errorf("Expected the clock to stop at 30s while paused, got %v", d)
+	}
+
+	pause := stats.Resume()
+	if stats.IsPaused() || pause < 30*time.Second || stats.GetPausedDuration() != pause {
+		t.Errorf("Expected a 30s pause to be recorded, got %v (total %v)", pause, stats.GetPausedDuration())
+	}
+	if d := stats.GetDuration(); d < 29*time.Second || d > 31*time.Second {
+		t.Errorf("Expected the pause left out of the elapsed time, got %v", d)
+	}
+	if !stats.positionReached[1].IsZero() || !stats.positionReached[0].Equal(stats.startTime) {
+		t.Error("Expected reached positions moved with the start and unreached ones kept at zero")
+	}
+
+	// 150 correct keystrokes in 30s of typing: 60 WPM
+	for range 150 {
+		stats.RecordKeystroke(true)
+	}
+	stats.endTime = stats.startTime.Add(30 * time.Second)
+	stats.testComplete = true
+	if got := stats.GetWPM(); math.Abs(got-60) > 0.01 {
+		t.Errorf("Expected 60 WPM without the pause, got %.2f", got)
+	}
+	if stats.Resume() != 0 {
+		t.Error("Expected Resume without a pause to do nothing")
+	}
+}
+
+func TestGetRawWPM(t *testing.T) {
+	stats := NewStats()
+	if stats.GetRawWPM() != 0 {
+		t.Errorf("Expected 0 raw WPM before the test starts, got %.1f", stats.GetRawWPM())
+	}
+
+	// 60 keystrokes in a minute, 10 of them wrong: 12 raw WPM, 10 net WPM
+	start := time.Date(2024, time.March, 4, 12, 0, 0, 0, time.UTC)
+	stats.startTime = start
+	for i := 0; i < 60; i++ {
+		stats.RecordKeystroke(i%6 != 0)
+	}
+	stats.endTime = start.Add(time.Minute)
+	stats.testComplete = true
+	if got := stats.GetRawWPM(); math.Abs(got-12) > 0.01 {
+		t.Errorf("Expected 12 raw WPM, got %.2f", got)
+	}
+	if got := stats.GetWPM(); math.Abs(got-10) > 0.01 {
+		t.Errorf("Expected 10 net WPM, got %.2f", got)
+	}
+
+	// Both ignore tests shorter than a second
+	stats.endTime = start.Add(500 * time.Millisecond)
+	if stats.GetRawWPM() != 0 || stats.GetWPM() != 0 {
+		t.Errorf("Expected 0 WPM under a second, got raw %.1f and net %.1f", stats.GetRawWPM(), stats.GetWPM())
+	}
+}
+
+func TestGetConsistency(t *testing.T) {
+	stats := NewStats()
+	if got := stats.GetConsistency(); got != 100 {
+		t.Errorf("Expected 100%% without snapshots, got %.1f", got)
+	}
+
+	stats.wpmHistory = []WPMSnapshot{{WPM: 60}}
+	if got := stats.GetConsistency(); got != 100 {
+		t.Errorf("Expected 100%% with a single snapshot, got %.1f", got)
+	}
+
+	stats.wpmHistory = []WPMSnapshot{{WPM: 60}, {WPM: 60}, {WPM: 60}}
+	if got := stats.GetConsistency(); got != 100 {
+		t.Errorf("Expected 100%% for a steady speed, got %.1f", got)
+	}
+
+	// Mean 60, standard deviation 20: a third of the mean
+	stats.wpmHistory = []WPMSnapshot{{WPM: 40}, {WPM: 80}}
+	if got := stats.GetConsistency(); math.Abs(got-66.67) > 0.01 {
+		t.Errorf("Expected 66.67%%, got %.2f", got)
+	}
+
+	// Wild swings clamp at 0
+	stats.wpmHistory = []WPMSnapshot{{WPM: 0}, {WPM: 0}, {WPM: 0}, {WPM: 120}}
+	if got := stats.GetConsistency(); got != 0 {
+		t.Errorf("Expected 0%% for a standard deviation above the mean, got %.1f", got)
+	}
+
+	// Nothing typed at all isn't a steady speed
+	stats.wpmHistory = []WPMSnapshot{{WPM: 0}, {WPM: 0}, {WPM: 0}}
+	if got := stats.GetConsistency(); got != 0 {
+		t.Errorf("Expected 0%% when every snapshot is 0 WPM, got %.1f", got)
+	}
+}
+
+func TestTrackErrorsDisabled(t *testing.T) {
+	test := NewTypingTest("hello world")
+	test.SetTrackErrors(false)
+
+	typeString(test, "hxllo wrrld")
+
+	if !test.IsFinished() {
+		t.Fatal("Expected test to finish")
+	}
+	stats := test.GetStats()
+	if words := stats.GetMisspelledWords(); len(words) != 0 {
+		t.Errorf("Expected no misspelled words with tracking off, got %v", words)
+	}
+	if len(stats.GetWordErrorsMap()) != 0 {
+		t.Errorf("Expected no word error flags, got %v", stats.GetWordErrorsMap())
+	}
+	if acc := stats.GetAccuracy(); acc < 81 || acc > 82 {
+		t.Errorf("Expected keystroke accuracy (9/11) with tracking off, got %.1f%%", acc)
+	}
+
+	// The option survives a reset
+	test.Reset()
+	typeString(test, "hxllo world")
+	if words := test.GetStats().GetMisspelledWords(); len(words) != 0 {
+		t.Errorf("Expected tracking to stay off after reset, got %v", words)
+	}
+}
+
+func TestCombo(t *testing.T) {
+	stats := NewStats()
+	stats.Start()
+
+	for range 5 {
+		stats.RecordKeystroke(true)
+	}
+	if stats.GetCombo() != 5 || stats.GetBestCombo() != 5 {
+		t.Fatalf("Expected combo 5 and best 5, got %d and %d", stats.GetCombo(), stats.GetBestCombo())
+	}
+
+	stats.RecordKeystroke(false)
+	if stats.GetCombo() != 0 {
+		t.Errorf("Expected an error to reset the combo, got %d", stats.GetCombo())
+	}
+
+	for range 3 {
+		stats.RecordKeystroke(true)
+	}
+	if stats.GetCombo() != 3 || stats.GetBestCombo() != 5 {
+		t.Errorf("Expected combo 3 with the best of 5 kept, got %d and %d", stats.GetCombo(), stats.GetBestCombo())
+	}
+}
+
+func TestRollingCV(t *testing.T) {
+	// A steady rhythm has no variation
+	steady := rollingCV([]float64{100, 100, 100, 100}, 2)
+	if !slices.Equal(steady, []float64{0, 0, 0}) {
+		t.Errorf("Expected no variation for equal intervals, got %v", steady)
+	}
+
+	// Window {50, 150}: mean 100, standard deviation 50
+	// Window {150, 150}: no variation
+	// Window {150, 0}: mean 75, standard deviation 75
+	got := rollingCV([]float64{50, 150, 150, 0}, 2)
+	want := []float64{0.5, 0, 1}
+	if len(got) != len(want) {
+		t.Fatalf("Expected %d values, got %v", len(want), got)
+	}
+	for i := range want {
+		if math.Abs(got[i]-want[i]) > 1e-9 {
+			t.Errorf("Window %d: expected %.2f, got %.4f", i, want[i], got[i])
+		}
+	}
+
+	if got := rollingCV([]float64{0, 0}, 2); !slices.Equal(got, []float64{0}) {
+		t.Errorf("Expected 0 for a zero mean, got %v", got)
+	}
+}
+
+func TestRhythmSeries(t *testing.T) {
+	stats := NewStats()
+	stats.SetRetainKeystrokeLog(true)
+
+	// Intervals alternate between 100 and 300 ms: mean 200, deviation 100
+	start := time.Now()
+	offset := 0
+	for i := 0; i < 7; i++ {
+		stats.keystrokeLog = append(stats.keystrokeLog, KeystrokeRecord{
+			Timestamp: start.Add(time.Duration(offset) * time.Millisecond),
+			Correct:   true,
+		})
+		offset += 100 + 200*(i%2)
+	}
+
+	got := stats.GetRhythmSeries(2)
+	if len(got) != 5 {
+		t.Fatalf("Expected one value per window of the 6 intervals, got %v", got)
+	}
+	for i, cv := range got {
+		if math.Abs(cv-0.5) > 1e-9 {
+			t.Errorf("Window %d: expected 0.5, got %.4f", i, cv)
+		}
+	}
+
+	if got := stats.GetRhythmSeries(7); got != nil {
+		t.Errorf("Expected no series with fewer intervals than the window, got %v", got)
+	}
+	if got := NewStats().GetRhythmSeries(2); got != nil {
+		t.Errorf("Expected no series without a keystroke log, got %v", got)
+	}
+}
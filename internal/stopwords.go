@@ -0,0 +1,70 @@
+package internal
+
+import (
+	"strings"
+	"unicode"
+)
+
+// stopwords are common English function words. They carry little meaning, so
+// dimming them trains the eyes to jump to the content words.
+var stopwords = map[string]bool{
+	"a": true, "an": true, "the": true,
+	"and": true, "but": true, "or": true, "nor": true, "so": true, "yet": true,
+	"if": true, "as": true, "than": true, "that": true, "then": true,
+	"of": true, "in": true, "on": true, "at": true, "to": true, "by": true,
+	"for": true, "from": true, "with": true, "into": true, "onto": true,
+	"up": true, "out": true, "off": true, "over": true, "about": true,
+	"i": true, "me": true, "my": true, "we": true, "us": true, "our": true,
+	"you": true, "your": true, "he": true, "him": true, "his": true,
+	"she": true, "her": true, "it": true, "its": true, "they": true,
+	"them": true, "their": true, "this": true, "these": true, "those": true,
+	"is": true, "am": true, "are": true, "was": true, "were": true,
+	"be": true, "been": true, "being": true, "do": true, "does": true,
+	"did": true, "have": true, "has": true, "had": true, "will": true,
+	"would": true, "shall": true, "should": true, "can": true, "could": true,
+	"may": true, "might": true, "must": true, "not": true, "no": true,
+	"there": true, "which": true, "who": true, "whom": true, "what": true,
+	"when": true, "where": true, "how": true, "all": true, "any": true,
+	"some": true, "such": true, "very": true, "too": true, "also": true,
+}
+
+// IsStopword reports whether word is a common function word. Case and
+// surrounding punctuation are ignored, so "The" and "it," count.
+func IsStopword(word string) bool {
+	word = strings.TrimFunc(word, func(r rune) bool { return !unicode.IsLetter(r) })
+	return stopwords[strings.ToLower(word)]
+}
+
+// StopwordMask marks the characters of text that belong to a stopword, so the
+// renderer can look up each position directly. Separators are never marked.
+func StopwordMask(text []rune) []bool {
+	mask := make([]bool, len(text))
+	start := 0
+	for i := 0; i <= len(text); i++ {
+		if i < len(text) && !isWordSeparator(text[i]) {
+			continue
+		}
+		if i > start && IsStopword(string(text[start:i])) {
+			for j := start; j < i; j++ {
+				mask[j] = true
+			}
+		}
+		start = i + 1
+	}
+	return mask
+}
+
+// stopwordCache keeps the StopwordMask of the last text, so it is only built
+// again when the text changes. Texts are told apart like in layoutCache.
+type stopwordCache struct {
+	runes []rune
+	mask  []bool
+}
+
+// get returns the StopwordMask of runes.
+func (c *stopwordCache) get(runes []rune) []bool {
+	if c.mask == nil || !sameRunes(c.runes, runes) {
+		c.runes, c.mask = runes, StopwordMask(runes)
+	}
+	return c.mask
+}
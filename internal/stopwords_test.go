@@ -0,0 +1,46 @@
+package internal
+
+import "testing"
+
+func TestStopwordMask(t *testing.T) {
+	sample := []rune("The cat sat on a mat,\nand it's OF note.")
+	// One mark per character: x for characters of a stopword
+	want := "xxx         xx x      xxx      xx"
+
+	mask := StopwordMask(sample)
+	if len(mask) != len(sample) {
+		t.Fatalf("Expected one entry per character, got %d for %d", len(mask), len(sample))
+	}
+	for i, ch := range sample {
+		expected := i < len(want) && want[i] == 'x'
+		if mask[i] != expected {
+			t.Errorf("Character %d (%q): expected stopword %v, got %v", i, ch, expected, mask[i])
+		}
+	}
+}
+
+func TestStopwordCache(t *testing.T) {
+	runes := []rune("the cat")
+	var cache stopwordCache
+
+	mask := cache.get(runes)
+	if again := cache.get(runes); &again[0] != &mask[0] {
+		t.Error("Expected the cached mask for the same text")
+	}
+	if other := cache.get([]rune("the cat")); &other[0] == &mask[0] {
+		t.Error("Expected a new mask for another text")
+	}
+}
+
+func TestIsStopword(t *testing.T) {
+	for _, word := range []string{"the", "The", "of,", "(and", "it."} {
+		if !IsStopword(word) {
+			t.Errorf("Expected %q to be a stopword", word)
+		}
+	}
+	for _, word := range []string{"cat", "theory", "it's", "", "..."} {
+		if IsStopword(word) {
+			t.Errorf("Expected %q not to be a stopword", word)
+		}
+	}
+}
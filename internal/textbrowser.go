@@ -0,0 +1,142 @@
+package internal
+
+import "strings"
+
+const (
+	// textBrowserPreviewLines is the number of wrapped lines shown in the preview pane.
+	textBrowserPreviewLines = 8
+)
+
+// TextBrowser manages the text browser overlay, a two-pane picker that lists
+// available texts on the left and previews the highlighted text on the right.
+type TextBrowser struct {
+	visible      bool         // Whether the browser is currently displayed
+	selected     int          // Index of the highlighted text
+	scrollOffset int          // Scroll offset for long text lists
+	visibleRows  int          // Entries of the list that fit on screen (see SetVisibleRows)
+	texts        []TextSource // Texts available for browsing
+}
+
+// NewTextBrowser creates a new hidden TextBrowser with no texts.
+func NewTextBrowser() *TextBrowser {
+	return &TextBrowser{
+		visible:      false,
+		selected:     0,
+		scrollOffset: 0,
+		visibleRows:  defaultMaxVisibleCommands,
+		texts:        []TextSource{},
+	}
+}
+
+// Show displays the browser with the given texts and highlights the text at
+// the given index (typically the currently selected text).
+func (tb *TextBrowser) Show(texts []TextSource, current int) {
+	tb.visible = true
+	tb.texts = texts
+	tb.selected = 0
+	tb.scrollOffset = 0
+	if current >= 0 && current < len(texts) {
+		tb.selected = current
+	}
+	tb.ensureVisible()
+}
+
+// Hide closes the browser.
+func (tb *TextBrowser) Hide() {
+	tb.visible = false
+}
+
+// IsVisible returns whether the browser is currently displayed.
+func (tb *TextBrowser) IsVisible() bool {
+	return tb.visible
+}
+
+// MoveUp moves the highlight up by one entry. Does nothing at the top.
+func (tb *TextBrowser) MoveUp() {
+	if tb.selected > 0 {
+		tb.selected--
+		tb.ensureVisible()
+	}
+}
+
+// MoveDown moves the highlight down by one entry. Does nothing at the bottom.
+func (tb *TextBrowser) MoveDown() {
+	if tb.selected < len(tb.texts)-1 {
+		tb.selected++
+		tb.ensureVisible()
+	}
+}
+
+// SetVisibleRows sets how many entries of the list fit on screen (see
+// TextBrowserRows) and scrolls so the highlighted entry is among them.
+func (tb *TextBrowser) SetVisibleRows(rows int) {
+	tb.visibleRows = max(rows, 1)
+	tb.ensureVisible()
+}
+
+// ensureVisible adjusts the scroll offset so the highlighted entry stays on screen.
+func (tb *TextBrowser) ensureVisible() {
+	if tb.selected < tb.scrollOffset {
+		tb.scrollOffset = tb.selected
+	}
+	if tb.selected >= tb.scrollOffset+tb.visibleRows {
+		tb.scrollOffset = tb.selected - tb.visibleRows + 1
+	}
+}
+
+// GetSelected returns the index of the highlighted text.
+func (tb *TextBrowser) GetSelected() int {
+	return tb.selected
+}
+
+// GetScrollOffset returns the current scroll offset for rendering.
+func (tb *TextBrowser) GetScrollOffset() int {
+	return tb.scrollOffset
+}
+
+// GetTexts returns the texts being browsed.
+func (tb *TextBrowser) GetTexts() []TextSource {
+	return tb.texts
+}
+
+// GetSelectedText returns the highlighted text.
+// Returns false if the browser has no texts.
+func (tb *TextBrowser) GetSelectedText() (TextSource, bool) {
+	if tb.selected >= 0 && tb.selected < len(tb.texts) {
+		return tb.texts[tb.selected], true
+	}
+	return TextSource{}, false
+}
+
+// PreviewLines returns the first lines of content wrapped to maxWidth.
+// If the content has more than maxLines lines, the last returned line
+// is marked with "..." to indicate truncation.
+//
+// Parameters:
+//   - content: the text to preview
+//   - maxLines: maximum number of lines to return
+//   - maxWidth: maximum width of each line in runes
+func PreviewLines(content string, maxLines, maxWidth int) []string {
+	if maxLines <= 0 || maxWidth <= 0 {
+		return nil
+	}
+
+	wrapped := wrapText(content, maxWidth)
+	lines := make([]string, 0, min(len(wrapped), maxLines))
+	for i, line := range wrapped {
+		if i >= maxLines {
+			break
+		}
+		lines = append(lines, strings.TrimRight(line, "\n"))
+	}
+
+	if len(wrapped) > maxLines {
+		last := []rune(lines[len(lines)-1])
+		if len(last) > maxWidth-3 {
+			last = last[:max(maxWidth-3, 0)]
+		}
+		lines[len(lines)-1] = strings.TrimRight(string(last), " ") + "..."
+	}
+
+	return lines
+}
@@ -0,0 +1,139 @@
+package internal
+
+import (
+	"fmt"
+	"testing"
+)
+
+func newBrowserTexts(n int) []TextSource {
+	texts := make([]TextSource, n)
+	for i := range texts {
+		texts[i] = TextSource{Name: fmt.Sprintf("text-%d", i), Content: "content"}
+	}
+	return texts
+}
+
+func TestTextBrowserNavigation(t *testing.T) {
+	browser := NewTextBrowser()
+	browser.Show(newBrowserTexts(3), 1)
+
+	if got := browser.GetSelected(); got != 1 {
+		t.Fatalf("Expected initial selection 1, got %d", got)
+	}
+
+	browser.MoveUp()
+	browser.MoveUp() // Already at top, should stay
+	if got := browser.GetSelected(); got != 0 {
+		t.Errorf("Expected selection clamped at 0, got %d", got)
+	}
+
+	browser.MoveDown()
+	browser.MoveDown()
+	browser.MoveDown() // Already at bottom, should stay
+	if got := browser.GetSelected(); got != 2 {
+		t.Errorf("Expected selection clamped at 2, got %d", got)
+	}
+
+	text, ok := browser.GetSelectedText()
+	if !ok || text.Name != "text-2" {
+		t.Errorf("Expected text-2 to be selected, got %q (ok=%v)", text.Name, ok)
+	}
+}
+
+func TestTextBrowserScrollFollowsSelection(t *testing.T) {
+	browser := NewTextBrowser()
+	browser.Show(newBrowserTexts(25), 0)
+
+	for i := 0; i < 15; i++ {
+		browser.MoveDown()
+	}
+	if got := browser.GetScrollOffset(); got != 15-defaultMaxVisibleCommands+1 {
+		t.Errorf("Expected scroll offset %d, got %d", 15-defaultMaxVisibleCommands+1, got)
+	}
+
+	browser.Show(newBrowserTexts(25), 20)
+	if browser.GetScrollOffset() > 20 || browser.GetScrollOffset()+defaultMaxVisibleCommands <= 20 {
+		t.Errorf("Expected initial selection 20 to be visible, offset %d", browser.GetScrollOffset())
+	}
+}
+
+func TestTextBrowserScrollsByVisibleRows(t *testing.T) {
+	browser := NewTextBrowser()
+	browser.Show(newBrowserTexts(25), 0)
+	browser.SetVisibleRows(TextBrowserRows(12)) // 4 rows on a small screen
+
+	for i := 0; i < 6; i++ {
+		browser.MoveDown()
+	}
+	if got := browser.GetScrollOffset(); got != 3 {
+		t.Errorf("Expected selection 6 to scroll 4 visible rows to offset 3, got %d", got)
+	}
+
+	browser.SetVisibleRows(2)
+	if got := browser.GetScrollOffset(); got != 5 {
+		t.Errorf("Expected fewer rows to scroll the selection back into view (offset 5), got %d", got)
+	}
+}
+
+func TestTextBrowserEmpty(t *testing.T) {
+	browser := NewTextBrowser()
+	browser.Show(nil, 0)
+	browser.MoveDown()
+
+	if _, ok := browser.GetSelectedText(); ok {
+		t.Error("Expected no selected text in empty browser")
+	}
+}
+
+func TestPreviewLines(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		maxLines int
+		maxWidth int
+		expected []string
+	}{
+		{
+			name:     "short content unchanged",
+			content:  "one\ntwo",
+			maxLines: 3,
+			maxWidth: 20,
+			expected: []string{"one", "two"},
+		},
+		{
+			name:     "truncated to max lines",
+			content:  "one\ntwo\nthree\nfour",
+			maxLines: 2,
+			maxWidth: 20,
+			expected: []string{"one", "two..."},
+		},
+		{
+			name:     "long line wrapped then truncated",
+			content:  "alpha beta gamma delta",
+			maxLines: 1,
+			maxWidth: 11,
+			expected: []string{"alpha be..."},
+		},
+		{
+			name:     "zero lines",
+			content:  "one",
+			maxLines: 0,
+			maxWidth: 20,
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := PreviewLines(tt.content, tt.maxLines, tt.maxWidth)
+			if len(result) != len(tt.expected) {
+				t.Fatalf("PreviewLines() = %q, want %q", result, tt.expected)
+			}
+			for i := range result {
+				if result[i] != tt.expected[i] {
+					t.Errorf("PreviewLines()[%d] = %q, want %q", i, result[i], tt.expected[i])
+				}
+			}
+		})
+	}
+}
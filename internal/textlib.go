@@ -1,10 +1,13 @@
 package internal
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"math/rand"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 	"unicode"
@@ -12,9 +15,82 @@ import (
 
 // TextSource represents a typing test text with its metadata.
 type TextSource struct {
-	Name    string // Display name (filename without extension)
-	Content string // The actual text content
-	Path    string // Full file path
+	Name      string            // Display name (filename without extension)
+	Content   string            // The actual text content
+	Path      string            // Full file path
+	Overrides map[string]string // Settings from the front matter (rc file keys), applied while the text is practiced
+	Part      int               // Number of the part of a file split by chunking (0 for a whole file)
+
+	FrontMatterErr error // Why the front matter was ignored (nil if it was valid or absent)
+}
+
+// IsFile reports whether the text was loaded from a file. The default text and
+// texts from stdin have no file.
+func (t TextSource) IsFile() bool {
+	return t.Path != ""
+}
+
+// frontMatterDelimiter opens and closes the optional settings block at the top of a text file.
+const frontMatterDelimiter = "---"
+
+// textOverrideKeys are the rc file keys a text's front matter may override.
+// They are the settings that only affect a single test. The word limits are
+// left out since they only apply to generated words: a text ends after its
+// last character. There is no case sensitivity setting to override.
+var textOverrideKeys = map[string]bool{
+	"typing_semantics":           true,
+	"skip_to_next_word_on_space": true,
+	"wpm_lead_in_ms":             true,
+	"track_errors":               true,
+	"require_exact_to_finish":    true,
+	"strict_finish":              true,
+	"tab_key":                    true,
+}
+
+// ParseFrontMatter splits a text file into its optional front matter and the
+// text itself. Front matter is a block of key=value lines (the rocketype.conf
+// syntax) between two "---" lines at the very top of the file:
+//
+//	---
+//	typing_semantics = word
+//	require_exact_to_finish = true
+//	---
+//	func main() {
+//
+// Content without front matter is returned unchanged with nil overrides.
+// Returns an error for an unterminated block, keys that can't be set per text,
+// or invalid values.
+func ParseFrontMatter(content string) (overrides map[string]string, text string, err error) {
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	rest, ok := strings.CutPrefix(content, frontMatterDelimiter+"\n")
+	if !ok {
+		return nil, content, nil
+	}
+
+	block, text, found := strings.Cut(rest, "\n"+frontMatterDelimiter+"\n")
+	if !found {
+		block, found = strings.CutSuffix(rest, "\n"+frontMatterDelimiter)
+		text = ""
+	}
+	if !found {
+		return nil, content, fmt.Errorf("front matter is not closed with %q", frontMatterDelimiter)
+	}
+
+	overrides, err = ParseRCFile([]byte(block))
+	if err != nil {
+		return nil, content, fmt.Errorf("front matter: %w", err)
+	}
+	for key := range overrides {
+		if !textOverrideKeys[key] {
+			return nil, content, fmt.Errorf("front matter: %q can't be set per text", key)
+		}
+	}
+	// Validate the values on a scratch copy
+	scratch := DefaultSettings()
+	if err := ApplyRCValues(&scratch, overrides); err != nil {
+		return nil, content, fmt.Errorf("front matter: %w", err)
+	}
+	return overrides, text, nil
 }
 
 // NormalizeWhitespace converts all whitespace characters to regular spaces,
@@ -70,56 +146,131 @@ func NormalizeWhitespace(text string) string {
 // TextLibrary manages the collection of available typing test texts.
 type TextLibrary struct {
 	texts       []TextSource
-	currentIdx  int    // Index of currently selected text
-	textsDir    string // Directory where text files are stored
+	currentIdx  int             // Index of currently selected text
+	textsDirs   []string        // Directories where text files are stored
+	added       []TextSource    // Texts added with AddText, kept when reloading
+	copies      map[string]bool // Paths written by Duplicate, loaded even while identical to their original
+	pipeline    []string        // Preprocessors applied to loaded files, in order (see ApplyPipeline)
+	chunkWords  int             // Split loaded files into parts of about this many words (0 = off)
 	defaultText TextSource
 	rand        *rand.Rand
 }
 
+// TextLoadOptions control how text files are turned into texts.
+type TextLoadOptions struct {
+	Pipeline   []string // Preprocessors applied to loaded files, in order (see ApplyPipeline)
+	ChunkWords int      // Split loaded files into parts of about this many words (0 = off)
+}
+
+// DefaultTextLoadOptions returns the options NewTextLibrary loads texts with.
+func DefaultTextLoadOptions() TextLoadOptions {
+	return TextLoadOptions{Pipeline: []string{PreprocessGutenberg}}
+}
+
 // NewTextLibrary creates a new TextLibrary instance.
-// It loads all .txt files from the specified directory, or uses the default
-// embedded text if the directory doesn't exist or contains no files.
+// It loads all .txt files from the specified directories, or uses the default
+// embedded text if none of them exists or contains files.
 //
 // Parameters:
-//   - textsDir: directory path to search for .txt files
+//   - textsDirs: directory paths to search for .txt files, in order
+//
+// Texts with the same file name in several directories are told apart by
+// prefixing their names with the directory name, e.g. "personal/notes".
 //
 // Returns a TextLibrary with at least one text (the default if no files found).
-func NewTextLibrary(textsDir string) *TextLibrary {
+func NewTextLibrary(textsDirs []string) *TextLibrary {
+	return newTextLibrary(textsDirs, defaultSampleText, DefaultTextLoadOptions())
+}
+
+// NewTextLibraryWithOptions is NewTextLibrary loading the texts with the given
+// options, so they are read only once instead of again for each setter.
+func NewTextLibraryWithOptions(textsDirs []string, opts TextLoadOptions) *TextLibrary {
+	return newTextLibrary(textsDirs, defaultSampleText, opts)
+}
+
+// newTextLibrary is NewTextLibraryWithOptions with the given default text. An
+// empty default (e.g. stripped from a package) is not added, leaving the
+// library without texts if the directory has none.
+func newTextLibrary(textsDirs []string, defaultContent string, opts TextLoadOptions) *TextLibrary {
 	tl := &TextLibrary{
-		textsDir: textsDir,
+		textsDirs: textsDirs,
 		defaultText: TextSource{
 			Name:    "Default (Tolkien)",
-			Content: NormalizeWhitespace(defaultSampleText),
+			Content: NormalizeWhitespace(defaultContent),
 			Path:    "",
 		},
 		texts:      make([]TextSource, 0),
 		currentIdx: 0,
+		pipeline:   slices.Clone(opts.Pipeline),
+		chunkWords: opts.ChunkWords,
 		rand:       rand.New(rand.NewSource(time.Now().UnixNano())),
 	}
 
-	// Try to load texts from directory
-	if err := tl.loadTexts(); err != nil {
-		// If loading fails, use default text
-		tl.texts = []TextSource{tl.defaultText}
+	tl.load()
+	return tl
+}
+
+// load reads the texts directories into texts, replacing what was loaded before.
+func (tl *TextLibrary) load() {
+	tl.texts = make([]TextSource, 0)
+
+	// Try to load texts from each directory; missing or unreadable directories
+	// are skipped. Files with the same text as one loaded before are skipped
+	// too, so copies in several directories show up once.
+	seen := make(map[string]bool)
+	for _, dir := range tl.textsDirs {
+		_ = tl.loadTexts(dir, seen)
 	}
+	tl.disambiguateNames()
+	tl.texts = append(tl.texts, tl.added...)
 
 	// If no texts were loaded, add default
-	if len(tl.texts) == 0 {
+	if len(tl.texts) == 0 && strings.TrimSpace(tl.defaultText.Content) != "" {
 		tl.texts = []TextSource{tl.defaultText}
 	}
+}
 
-	return tl
+// SetPipeline sets the preprocessors applied to text files, in order, and
+// reloads the texts if they changed.
+func (tl *TextLibrary) SetPipeline(pipeline []string) {
+	if !slices.Equal(tl.pipeline, pipeline) {
+		tl.pipeline = slices.Clone(pipeline)
+		tl.Reload()
+	}
+}
+
+// Preprocess applies the preprocessing pipeline to the content of a text.
+func (tl *TextLibrary) Preprocess(text string) string {
+	return ApplyPipeline(text, tl.pipeline)
 }
 
-// loadTexts reads all .txt files from the texts directory.
-func (tl *TextLibrary) loadTexts() error {
+// SetChunkWords sets the size in words of the parts long text files are split
+// into (0 keeps them whole) and reloads the texts if it changed.
+func (tl *TextLibrary) SetChunkWords(words int) {
+	if tl.chunkWords != words {
+		tl.chunkWords = words
+		tl.Reload()
+	}
+}
+
+// Reload reads the texts directories again, keeping texts added with AddText.
+// The selection stays at the same index, or the last text if the list got
+// shorter, so after deleting a file the text that followed it is selected.
+func (tl *TextLibrary) Reload() {
+	tl.load()
+	tl.currentIdx = min(tl.currentIdx, len(tl.texts)-1)
+}
+
+// loadTexts reads all .txt files from a texts directory. Files whose TextHash
+// is in seen are skipped; the hashes of loaded files are added to it.
+func (tl *TextLibrary) loadTexts(textsDir string, seen map[string]bool) error {
 	// Check if directory exists
-	if _, err := os.Stat(tl.textsDir); os.IsNotExist(err) {
-		return fmt.Errorf("texts directory not found: %s", tl.textsDir)
+	if _, err := os.Stat(textsDir); os.IsNotExist(err) {
+		return fmt.Errorf("texts directory not found: %s", textsDir)
 	}
 
 	// Read all files in directory
-	entries, err := os.ReadDir(tl.textsDir)
+	entries, err := os.ReadDir(textsDir)
 	if err != nil {
 		return fmt.Errorf("failed to read texts directory: %w", err)
 	}
@@ -135,15 +286,23 @@ func (tl *TextLibrary) loadTexts() error {
 			continue
 		}
 
-		path := filepath.Join(tl.textsDir, entry.Name())
+		path := filepath.Join(textsDir, entry.Name())
 		content, err := os.ReadFile(path)
 		if err != nil {
 			// Skip files that can't be read
 			continue
 		}
 
+		// Texts with broken front matter are loaded as they are, without
+		// overrides; the error is shown when the text is selected
+		overrides, body, frontMatterErr := ParseFrontMatter(string(content))
+		if frontMatterErr != nil {
+			body = string(content)
+		}
+		body = tl.Preprocess(body)
+
 		// Skip empty files
-		text := strings.TrimSpace(string(content))
+		text := strings.TrimSpace(body)
 		if text == "" {
 			continue
 		}
@@ -151,18 +310,69 @@ func (tl *TextLibrary) loadTexts() error {
 		// Normalize whitespace to ensure all whitespace is typeable
 		text = NormalizeWhitespace(text)
 
+		// Keep only the first of identical texts, except fresh copies that
+		// are about to be edited
+		hash := TextHash(text)
+		if seen[hash] && !tl.copies[path] {
+			continue
+		}
+		seen[hash] = true
+
 		// Create text source
 		name := strings.TrimSuffix(entry.Name(), ".txt")
-		tl.texts = append(tl.texts, TextSource{
-			Name:    name,
-			Content: text,
-			Path:    path,
-		})
+		tl.texts = append(tl.texts, chunkTexts(TextSource{
+			Name:           name,
+			Content:        text,
+			Path:           path,
+			Overrides:      overrides,
+			FrontMatterErr: frontMatterErr,
+		}, tl.chunkWords)...)
 	}
 
 	return nil
 }
 
+// TextHash returns a hash of a text's content, identifying identical texts
+// loaded from different files.
+func TextHash(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+// disambiguateNames prefixes the names of texts that share a name with another
+// text by the name of their directory, adding parent directories until the
+// names differ, e.g. "a/notes/poem" and "b/notes/poem".
+func (tl *TextLibrary) disambiguateNames() {
+	shared := make(map[string][]int)
+	for i, text := range tl.texts {
+		shared[text.Name] = append(shared[text.Name], i)
+	}
+	for name, indexes := range shared {
+		if len(indexes) < 2 {
+			continue
+		}
+		for depth := 1; ; depth++ {
+			names := make([]string, len(indexes))
+			seen := make(map[string]bool)
+			unique, complete := true, true
+			for k, i := range indexes {
+				dirs := strings.FieldsFunc(filepath.ToSlash(filepath.Dir(tl.texts[i].Path)), func(r rune) bool { return r == '/' })
+				complete = complete && depth >= len(dirs)
+				names[k] = strings.Join(append(dirs[max(len(dirs)-depth, 0):], name), "/")
+				unique = unique && !seen[names[k]]
+				seen[names[k]] = true
+			}
+			// Paths that differ in no directory keep the longest names
+			if unique || complete {
+				for k, i := range indexes {
+					tl.texts[i].Name = names[k]
+				}
+				break
+			}
+		}
+	}
+}
+
 // GetCurrentText returns the currently selected text.
 func (tl *TextLibrary) GetCurrentText() TextSource {
 	if tl.currentIdx >= 0 && tl.currentIdx < len(tl.texts) {
@@ -180,6 +390,25 @@ func (tl *TextLibrary) SelectRandom() TextSource {
 	return tl.GetCurrentText()
 }
 
+// SelectRandomQuote selects a random text like SelectRandom and returns one of
+// its sentences (see SplitSentences) for a short test. The quote keeps the
+// text's name, path and front matter overrides. A text without sentence breaks
+// is returned whole.
+func (tl *TextLibrary) SelectRandomQuote() TextSource {
+	tl.SelectRandom()
+	return tl.RandomQuote()
+}
+
+// RandomQuote returns a random sentence of the current text, like
+// SelectRandomQuote but keeping the selection.
+func (tl *TextLibrary) RandomQuote() TextSource {
+	quote := tl.GetCurrentText()
+	if sentences := SplitSentences(quote.Content); len(sentences) > 0 {
+		quote.Content = sentences[tl.rand.Intn(len(sentences))]
+	}
+	return quote
+}
+
 // SelectByIndex selects a text by its index in the library.
 // Returns false if the index is out of bounds.
 func (tl *TextLibrary) SelectByIndex(index int) bool {
@@ -190,6 +419,29 @@ func (tl *TextLibrary) SelectByIndex(index int) bool {
 	return false
 }
 
+// SelectNext selects the text after the current one, wrapping around to the
+// first, and returns it.
+func (tl *TextLibrary) SelectNext() TextSource {
+	return tl.selectOffset(1)
+}
+
+// SelectPrevious selects the text before the current one, wrapping around to
+// the last, and returns it.
+func (tl *TextLibrary) SelectPrevious() TextSource {
+	return tl.selectOffset(-1)
+}
+
+// selectOffset selects the text offset positions from the current one with
+// wraparound and returns it.
+func (tl *TextLibrary) selectOffset(offset int) TextSource {
+	if len(tl.texts) == 0 {
+		return tl.defaultText
+	}
+	n := len(tl.texts)
+	tl.SelectByIndex(((tl.currentIdx+offset)%n + n) % n)
+	return tl.GetCurrentText()
+}
+
 // SelectByName selects a text by its name.
 // Returns false if no text with that name is found.
 func (tl *TextLibrary) SelectByName(name string) bool {
@@ -202,11 +454,31 @@ func (tl *TextLibrary) SelectByName(name string) bool {
 	return false
 }
 
+// HasText reports whether a text with the given name exists.
+func (tl *TextLibrary) HasText(name string) bool {
+	for _, text := range tl.texts {
+		if text.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
 // GetAllTexts returns a slice of all available texts.
 func (tl *TextLibrary) GetAllTexts() []TextSource {
 	return tl.texts
 }
 
+// HasTexts returns true if the library has at least one text.
+func (tl *TextLibrary) HasTexts() bool {
+	return len(tl.texts) > 0
+}
+
+// GetTextsDirs returns the directories the texts were loaded from.
+func (tl *TextLibrary) GetTextsDirs() []string {
+	return tl.textsDirs
+}
+
 // Count returns the number of available texts.
 func (tl *TextLibrary) Count() int {
 	return len(tl.texts)
@@ -217,10 +489,103 @@ func (tl *TextLibrary) GetCurrentIndex() int {
 	return tl.currentIdx
 }
 
+// UserTextsDir returns the directory new text files are written to: the last
+// texts directory, which holds the personal texts when several are given.
+func (tl *TextLibrary) UserTextsDir() string {
+	if len(tl.textsDirs) == 0 {
+		return ""
+	}
+	return tl.textsDirs[len(tl.textsDirs)-1]
+}
+
+// Duplicate writes an editable copy of a text into UserTextsDir (see
+// DuplicateText) and returns its path. The copy is loaded by the next Reload
+// although it is identical to the original until it is edited.
+func (tl *TextLibrary) Duplicate(text TextSource) (string, error) {
+	path, err := DuplicateText(text, tl.UserTextsDir())
+	if err != nil {
+		return "", err
+	}
+	if tl.copies == nil {
+		tl.copies = make(map[string]bool)
+	}
+	tl.copies[path] = true
+	return path, nil
+}
+
+// DuplicateText writes an editable copy of a text into dir and returns the
+// path of the copy. Texts from files are copied as they are, front matter and
+// all parts included; other texts are copied with their content.
+func DuplicateText(text TextSource, dir string) (string, error) {
+	base := text.Name
+	content := []byte(text.Content)
+	if text.IsFile() {
+		base = strings.TrimSuffix(filepath.Base(text.Path), filepath.Ext(text.Path))
+		data, err := os.ReadFile(text.Path)
+		if err != nil {
+			return "", fmt.Errorf("failed to read %s: %w", text.Path, err)
+		}
+		content = data
+	}
+
+	if err := EnsureTextsDir(dir); err != nil {
+		return "", fmt.Errorf("failed to create texts directory: %w", err)
+	}
+	file, err := CreateCopyFile(dir, base)
+	if err != nil {
+		return "", fmt.Errorf("failed to create a copy of %s: %w", base, err)
+	}
+	_, err = file.Write(content)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		// Don't leave an empty or partial copy behind
+		_ = os.Remove(file.Name())
+		return "", fmt.Errorf("failed to write %s: %w", file.Name(), err)
+	}
+	return file.Name(), nil
+}
+
+// CreateCopyFile creates a new empty file in dir for a copy of the text named
+// name: <name>-copy.txt, or <name>-copy-2.txt, <name>-copy-3.txt and so on if
+// that exists. The file is created exclusively, so an existing file is never
+// overwritten, even one created at the same time. Path separators in the name
+// are replaced.
+func CreateCopyFile(dir, name string) (*os.File, error) {
+	name = strings.NewReplacer("/", "-", string(filepath.Separator), "-").Replace(name)
+	path := filepath.Join(dir, name+"-copy.txt")
+	for i := 2; ; i++ {
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+		if !os.IsExist(err) {
+			return file, err
+		}
+		path = filepath.Join(dir, fmt.Sprintf("%s-copy-%d.txt", name, i))
+	}
+}
+
 // AddText adds a new text to the library.
 // This is useful for dynamically adding texts like stdin input.
 func (tl *TextLibrary) AddText(text TextSource) {
 	// Normalize whitespace in the content
 	text.Content = NormalizeWhitespace(text.Content)
 	tl.texts = append(tl.texts, text)
+	tl.added = append(tl.added, text)
+}
+
+// RemoveText removes a text added with AddText. The selection stays at the
+// same index, or the last text if it was the last one. Returns false if no
+// added text has the name.
+func (tl *TextLibrary) RemoveText(name string) bool {
+	isText := func(text TextSource) bool { return text.Name == name }
+	i := slices.IndexFunc(tl.added, isText)
+	if i < 0 {
+		return false
+	}
+	tl.added = slices.Delete(tl.added, i, i+1)
+	if j := slices.IndexFunc(tl.texts, isText); j >= 0 {
+		tl.texts = slices.Delete(tl.texts, j, j+1)
+	}
+	tl.currentIdx = max(min(tl.currentIdx, len(tl.texts)-1), 0)
+	return true
 }
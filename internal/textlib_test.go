@@ -1,6 +1,10 @@
 package internal
 
 import (
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
 	"testing"
 )
 
@@ -102,3 +106,302 @@ func TestNormalizeWhitespace(t *testing.T) {
 		})
 	}
 }
+
+func TestParseFrontMatter(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		overrides map[string]string
+		text      string
+		wantErr   bool
+	}{
+		{
+			name:    "no front matter",
+			content: "hello\nworld",
+			text:    "hello\nworld",
+		},
+		{
+			name:      "overrides",
+			content:   "---\ntyping_semantics = word\n# code\nrequire_exact_to_finish=true\n---\nfunc main() {}",
+			overrides: map[string]string{"typing_semantics": "word", "require_exact_to_finish": "true"},
+			text:      "func main() {}",
+		},
+		{
+			name:      "CRLF line endings",
+			content:   "---\r\ntab_key = type\r\n---\r\nx",
+			overrides: map[string]string{"tab_key": "type"},
+			text:      "x",
+		},
+		{
+			name:    "unterminated",
+			content: "---\ntyping_semantics = word\nhello",
+			text:    "---\ntyping_semantics = word\nhello",
+			wantErr: true,
+		},
+		{
+			name:    "key that can't be set per text",
+			content: "---\ntheme = gruvbox\n---\nhello",
+			text:    "---\ntheme = gruvbox\n---\nhello",
+			wantErr: true,
+		},
+		{
+			name:    "invalid value",
+			content: "---\ntyping_semantics = sloppy\n---\nhello",
+			text:    "---\ntyping_semantics = sloppy\n---\nhello",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			overrides, text, err := ParseFrontMatter(tt.content)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error=%v, got %v", tt.wantErr, err)
+			}
+			if !maps.Equal(overrides, tt.overrides) {
+				t.Errorf("expected overrides %v, got %v", tt.overrides, overrides)
+			}
+			if text != tt.text {
+				t.Errorf("expected text %q, got %q", tt.text, text)
+			}
+		})
+	}
+}
+
+func TestLoadTextWithFrontMatter(t *testing.T) {
+	dir := t.TempDir()
+	content := "---\ntyping_semantics = word\n---\nhello world\n"
+	if err := os.WriteFile(filepath.Join(dir, "code.txt"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	texts := NewTextLibrary([]string{dir}).GetAllTexts()
+	if len(texts) != 1 {
+		t.Fatalf("expected 1 text, got %d", len(texts))
+	}
+	if texts[0].Content != "hello world" {
+		t.Errorf("expected the front matter to be stripped, got %q", texts[0].Content)
+	}
+	if texts[0].Overrides["typing_semantics"] != "word" {
+		t.Errorf("expected typing_semantics override, got %v", texts[0].Overrides)
+	}
+}
+
+func TestLoadTextsFromSeveralDirs(t *testing.T) {
+	root := t.TempDir()
+	system := filepath.Join(root, "system")
+	personal := filepath.Join(root, "personal")
+	files := map[string]string{
+		filepath.Join(system, "poem.txt"):     "roses are red",
+		filepath.Join(system, "notes.txt"):    "system notes",
+		filepath.Join(personal, "notes.txt"):  "my notes",
+		filepath.Join(personal, "letter.txt"): "dear reader",
+	}
+	for path, content := range files {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	library := NewTextLibrary([]string{system, personal, filepath.Join(root, "missing")})
+
+	contents := make(map[string]string)
+	for _, text := range library.GetAllTexts() {
+		contents[text.Name] = text.Content
+	}
+	want := map[string]string{
+		"poem":           "roses are red",
+		"system/notes":   "system notes",
+		"personal/notes": "my notes",
+		"letter":         "dear reader",
+	}
+	if !maps.Equal(contents, want) {
+		t.Errorf("expected %v, got %v", want, contents)
+	}
+	if !library.SelectByName("personal/notes") || library.GetCurrentText().Content != "my notes" {
+		t.Errorf("expected to select the personal notes by their prefixed name")
+	}
+}
+
+func TestDisambiguateNamesWithSameDirectoryName(t *testing.T) {
+	root := t.TempDir()
+	first := filepath.Join(root, "a", "notes")
+	second := filepath.Join(root, "b", "notes")
+	for dir, content := range map[string]string{first: "first poem", second: "second poem"} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "poem.txt"), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	library := NewTextLibrary([]string{first, second})
+
+	var names []string
+	for _, text := range library.GetAllTexts() {
+		names = append(names, text.Name)
+	}
+	if want := []string{"a/notes/poem", "b/notes/poem"}; !slices.Equal(names, want) {
+		t.Errorf("expected %v, got %v", want, names)
+	}
+}
+
+func TestLoadTextsSkipsDuplicates(t *testing.T) {
+	root := t.TempDir()
+	first := filepath.Join(root, "first")
+	second := filepath.Join(root, "second")
+	files := map[string]string{
+		filepath.Join(first, "poem.txt"):   "roses are red",
+		filepath.Join(second, "poem.txt"):  "roses are red",
+		filepath.Join(second, "verse.txt"): "roses are red\r\n", // Same after trimming and normalization
+		filepath.Join(second, "other.txt"): "violets are blue",
+	}
+	for path, content := range files {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	library := NewTextLibrary([]string{first, second})
+
+	var names []string
+	for _, text := range library.GetAllTexts() {
+		names = append(names, text.Name)
+	}
+	if want := []string{"poem", "other"}; !slices.Equal(names, want) {
+		t.Errorf("expected %v, got %v", want, names)
+	}
+	if text := library.GetAllTexts()[0]; text.Path != filepath.Join(first, "poem.txt") {
+		t.Errorf("expected the first loaded copy to be kept, got %s", text.Path)
+	}
+	if TextHash("a") == TextHash("b") || TextHash("a") != TextHash("a") {
+		t.Error("expected hashes to identify the content")
+	}
+}
+
+func TestSelectNextPrevious(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"a", "b", "c"} {
+		if err := os.WriteFile(filepath.Join(dir, name+".txt"), []byte("text "+name), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	library := NewTextLibrary([]string{dir})
+	library.SelectByName("b")
+
+	if got := library.SelectNext().Name; got != "c" {
+		t.Errorf("expected c after b, got %s", got)
+	}
+	if got := library.SelectNext().Name; got != "a" {
+		t.Errorf("expected to wrap around to a after the last text, got %s", got)
+	}
+	if got := library.SelectPrevious().Name; got != "c" {
+		t.Errorf("expected to wrap around to c before the first text, got %s", got)
+	}
+	if got := library.SelectPrevious().Name; got != "b" {
+		t.Errorf("expected b before c, got %s", got)
+	}
+	if library.GetCurrentText().Name != "b" {
+		t.Errorf("expected the returned text to be selected, got %s", library.GetCurrentText().Name)
+	}
+}
+
+func TestCreateCopyFile(t *testing.T) {
+	dir := t.TempDir()
+	create := func(name string) string {
+		t.Helper()
+		file, err := CreateCopyFile(dir, name)
+		if err != nil {
+			t.Fatalf("CreateCopyFile() failed: %v", err)
+		}
+		file.Close()
+		return file.Name()
+	}
+
+	if got, want := create("poem"), filepath.Join(dir, "poem-copy.txt"); got != want {
+		t.Errorf("CreateCopyFile() = %q, want %q", got, want)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "poem-copy-2.txt"), []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := create("poem"), filepath.Join(dir, "poem-copy-3.txt"); got != want {
+		t.Errorf("Expected a counter on collisions, got %q, want %q", got, want)
+	}
+	if data, err := os.ReadFile(filepath.Join(dir, "poem-copy-2.txt")); err != nil || string(data) != "x" {
+		t.Errorf("Expected existing copies to stay untouched, got %q (%v)", data, err)
+	}
+
+	if got, want := create("system/notes"), filepath.Join(dir, "system-notes-copy.txt"); got != want {
+		t.Errorf("Expected disambiguated names to stay in dir, got %q, want %q", got, want)
+	}
+}
+
+func TestDuplicateText(t *testing.T) {
+	system := t.TempDir()
+	personal := t.TempDir()
+	original := "---\ntyping_semantics = word\n---\nroses are red"
+	if err := os.WriteFile(filepath.Join(system, "poem.txt"), []byte(original), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	library := NewTextLibrary([]string{system, personal})
+	library.SelectByName("poem")
+	path, err := library.Duplicate(library.GetCurrentText())
+	if err != nil {
+		t.Fatalf("DuplicateText() failed: %v", err)
+	}
+	if want := filepath.Join(personal, "poem-copy.txt"); path != want {
+		t.Errorf("Expected the copy at %q, got %q", want, path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil || string(data) != original {
+		t.Errorf("Expected the file to be copied with its front matter, got %q (%v)", data, err)
+	}
+
+	// The copy is identical to the original but loads to be edited
+	library.Reload()
+	if !library.SelectByName("poem-copy") || library.GetCurrentText().Content != "roses are red" {
+		t.Error("Expected the copy to load after a reload")
+	}
+	if library.Count() != 2 {
+		t.Errorf("Expected the original and the copy, got %d texts", library.Count())
+	}
+
+	// Texts without a file are copied with their content
+	path, err = DuplicateText(TextSource{Name: "stdin", Content: "piped text"}, personal)
+	if err != nil {
+		t.Fatalf("DuplicateText() failed: %v", err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "piped text" {
+		t.Errorf("Expected the content to be copied, got %q", data)
+	}
+}
+
+func TestRemoveText(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "poem.txt"), []byte("roses are red"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	library := NewTextLibrary([]string{dir})
+	library.AddText(TextSource{Name: "stdin", Content: "piped text"})
+	library.SelectByName("stdin")
+
+	if library.RemoveText("poem") {
+		t.Error("Expected texts loaded from files not to be removed")
+	}
+	if !library.RemoveText("stdin") || library.SelectByName("stdin") {
+		t.Error("Expected the added text to be removed")
+	}
+	library.Reload()
+	if library.Count() != 1 || library.GetCurrentText().Name != "poem" {
+		t.Errorf("Expected only the poem after a reload, got %d texts", library.Count())
+	}
+}
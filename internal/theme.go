@@ -1,6 +1,10 @@
 package internal
 
-import "github.com/gdamore/tcell/v2"
+import (
+	"math"
+
+	"github.com/gdamore/tcell/v2"
+)
 
 // Theme defines the complete color scheme for the application.
 // All visual elements should reference colors from the active theme to ensure
@@ -352,6 +356,25 @@ var (
 		MenuSelectedFg: tcell.NewRGBColor(255, 255, 0), // #ffff00 - Yellow (inverted)
 		MenuDimText:    tcell.NewRGBColor(100, 100, 0), // #646400 - Dark yellow-green
 	}
+
+	// DeuteranopiaTheme is a colorblind-friendly dark theme for red-green color blindness.
+	// Correct and incorrect characters use blue and orange from the Okabe-Ito palette,
+	// which differ in both hue and brightness instead of relying on red versus green.
+	DeuteranopiaTheme = Theme{
+		Name:           "deuteranopia",
+		Background:     tcell.NewRGBColor(28, 28, 36),    // #1c1c24 - Dark slate
+		Foreground:     tcell.NewRGBColor(230, 230, 235), // #e6e6eb - Off-white
+		TextDefault:    tcell.NewRGBColor(120, 120, 130), // #787882 - Medium gray
+		TextCorrect:    tcell.NewRGBColor(156, 203, 255), // #9ccbff - Light blue
+		TextIncorrect:  tcell.NewRGBColor(213, 94, 0),    // #d55e00 - Vermillion orange
+		TextCursor:     tcell.NewRGBColor(240, 228, 66),  // #f0e442 - Yellow
+		Title:          tcell.NewRGBColor(240, 228, 66),  // #f0e442 - Yellow
+		Border:         tcell.NewRGBColor(70, 70, 85),    // #464655 - Dark gray
+		Help:           tcell.NewRGBColor(120, 120, 130), // #787882 - Medium gray
+		MenuSelectedBg: tcell.NewRGBColor(50, 50, 64),    // #323240 - Slightly lighter than bg
+		MenuSelectedFg: tcell.NewRGBColor(230, 230, 235), // #e6e6eb - Off-white
+		MenuDimText:    tcell.NewRGBColor(120, 120, 130), // #787882 - Medium gray
+	}
 )
 
 // AvailableThemes returns all available themes in the order they appear in the theme cycle.
@@ -379,23 +402,135 @@ func AvailableThemes() []Theme {
 		HighContrastDarkTheme,
 		HighContrastLightTheme,
 		HighVisibilityTheme,
+		// Colorblind-friendly themes
+		DeuteranopiaTheme,
+	}
+}
+
+// ContrastRatio computes the WCAG contrast ratio between two colors.
+// The result ranges from 1 (identical luminance) to 21 (black on white) and is
+// independent of argument order. Luminance differences stay visible for
+// colorblind users, which makes this a useful distinguishability measure.
+//
+// Returns 1 if either color has no RGB value (e.g. tcell.ColorDefault).
+func ContrastRatio(fg, bg tcell.Color) float64 {
+	r1, g1, b1 := fg.RGB()
+	r2, g2, b2 := bg.RGB()
+	if r1 < 0 || r2 < 0 {
+		return 1
+	}
+
+	l1 := relativeLuminance(r1, g1, b1)
+	l2 := relativeLuminance(r2, g2, b2)
+	if l1 < l2 {
+		l1, l2 = l2, l1
+	}
+	return (l1 + 0.05) / (l2 + 0.05)
+}
+
+// relativeLuminance computes the WCAG relative luminance of an sRGB color.
+func relativeLuminance(r, g, b int32) float64 {
+	channel := func(c int32) float64 {
+		v := float64(c) / 255
+		if v <= 0.03928 {
+			return v / 12.92
+		}
+		return math.Pow((v+0.055)/1.055, 2.4)
+	}
+	return 0.2126*channel(r) + 0.7152*channel(g) + 0.0722*channel(b)
+}
+
+// WithTransparentBackground returns a copy of the theme that uses the terminal's
+// default background, for transparent or image terminal backgrounds.
+// Highlight backgrounds such as the selected menu item are kept.
+func (t Theme) WithTransparentBackground() Theme {
+	t.Background = tcell.ColorDefault
+	return t
+}
+
+// NearestPaletteColor maps an RGB color to the closest entry of the xterm
+// 256-color palette, for terminals without true color support. Only the
+// 6x6x6 color cube and the grayscale ramp (indices 16-255) are considered,
+// since the first 16 colors are redefined by most terminal color schemes.
+// Non-RGB colors (named, palette or default colors) are returned unchanged.
+func NearestPaletteColor(c tcell.Color) tcell.Color {
+	if !c.Valid() || c&tcell.ColorIsRGB == 0 {
+		return c
 	}
+
+	r, g, b := c.RGB()
+	best := c
+	bestDist := int32(math.MaxInt32)
+	for i := 16; i < 256; i++ {
+		candidate := tcell.PaletteColor(i)
+		pr, pg, pb := candidate.RGB()
+		dr, dg, db := r-pr, g-pg, b-pb
+		dist := dr*dr + dg*dg + db*db
+		if dist < bestDist {
+			best, bestDist = candidate, dist
+		}
+	}
+	return best
+}
+
+// FindTheme looks up a theme by name.
+// Returns false if no theme with that name exists.
+func FindTheme(name string) (Theme, bool) {
+	for _, theme := range AvailableThemes() {
+		if theme.Name == name {
+			return theme, true
+		}
+	}
+	return Theme{}, false
 }
 
 // GetNextTheme returns the next theme in the rotation cycle.
 // When the last theme is reached, it wraps around to the first theme.
-// If the current theme is not found, returns DefaultTheme as a safe fallback.
+// With favorites, only those themes are cycled through; unknown names are
+// ignored, and without any known favorite all themes are cycled.
 //
 // Parameters:
 //   - current: the currently active theme
+//   - favorites: names of the themes to cycle through (empty for all)
 //
-// Returns the next theme in sequence, or DefaultTheme if current is not found.
-func GetNextTheme(current Theme) Theme {
-	themes := AvailableThemes()
+// Returns the next theme in sequence, or the first theme of the cycle if
+// current is not part of it.
+func GetNextTheme(current Theme, favorites []string) Theme {
+	return stepTheme(current, favorites, 1)
+}
+
+// GetPreviousTheme returns the previous theme in the rotation cycle, the
+// reverse of GetNextTheme. If current is not part of the cycle, the last theme
+// of the cycle is returned.
+func GetPreviousTheme(current Theme, favorites []string) Theme {
+	return stepTheme(current, favorites, -1)
+}
+
+// themeCycle returns the favorite themes in the given order, or all themes if
+// none of the favorites exists.
+func themeCycle(favorites []string) []Theme {
+	var themes []Theme
+	for _, name := range favorites {
+		if theme, ok := FindTheme(name); ok {
+			themes = append(themes, theme)
+		}
+	}
+	if len(themes) == 0 {
+		return AvailableThemes()
+	}
+	return themes
+}
+
+// stepTheme moves step positions from current through the theme cycle.
+func stepTheme(current Theme, favorites []string, step int) Theme {
+	themes := themeCycle(favorites)
 	for i, theme := range themes {
 		if theme.Name == current.Name {
-			return themes[(i+1)%len(themes)]
+			return themes[(i+step+len(themes))%len(themes)]
 		}
 	}
-	return DefaultTheme
+	if step < 0 {
+		return themes[len(themes)-1]
+	}
+	return themes[0]
 }
@@ -0,0 +1,125 @@
+package internal
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// minFeedbackContrast is the minimum luminance contrast between correct and
+// incorrect text for the two to remain distinguishable without hue.
+const minFeedbackContrast = 2.0
+
+func TestContrastRatio(t *testing.T) {
+	black := tcell.NewRGBColor(0, 0, 0)
+	white := tcell.NewRGBColor(255, 255, 255)
+	gray := tcell.NewRGBColor(119, 119, 119)
+
+	tests := []struct {
+		name     string
+		fg, bg   tcell.Color
+		expected float64
+	}{
+		{name: "black on white", fg: black, bg: white, expected: 21},
+		{name: "white on black", fg: white, bg: black, expected: 21},
+		{name: "identical colors", fg: gray, bg: gray, expected: 1},
+		{name: "gray on white", fg: gray, bg: white, expected: 4.48},
+		{name: "default color", fg: tcell.ColorDefault, bg: white, expected: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ContrastRatio(tt.fg, tt.bg)
+			if math.Abs(got-tt.expected) > 0.01 {
+				t.Errorf("ContrastRatio() = %.3f, want %.2f", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestThemeFeedbackContrast(t *testing.T) {
+	var flagged []string
+	for _, theme := range AvailableThemes() {
+		if ContrastRatio(theme.TextCorrect, theme.TextIncorrect) < minFeedbackContrast {
+			flagged = append(flagged, theme.Name)
+		}
+	}
+	if len(flagged) > 0 {
+		t.Logf("Themes with hard to distinguish correct/incorrect colors: %v", flagged)
+	}
+
+	// Accessibility themes must never be flagged
+	for _, theme := range []Theme{DeuteranopiaTheme, HighContrastDarkTheme, HighContrastLightTheme} {
+		ratio := ContrastRatio(theme.TextCorrect, theme.TextIncorrect)
+		if ratio < minFeedbackContrast {
+			t.Errorf("Theme %q correct/incorrect contrast %.2f is below %.1f", theme.Name, ratio, minFeedbackContrast)
+		}
+	}
+}
+
+func TestDeuteranopiaThemeAvailable(t *testing.T) {
+	if _, ok := FindTheme("deuteranopia"); !ok {
+		t.Error("Expected deuteranopia theme in AvailableThemes")
+	}
+}
+
+func TestNearestPaletteColor(t *testing.T) {
+	tests := []struct {
+		name  string
+		color tcell.Color
+		want  tcell.Color
+	}{
+		{"pure red", tcell.NewRGBColor(255, 0, 0), tcell.PaletteColor(196)},
+		{"black", tcell.NewRGBColor(0, 0, 0), tcell.PaletteColor(16)},
+		{"white", tcell.NewRGBColor(255, 255, 255), tcell.PaletteColor(231)},
+		{"mid gray", tcell.NewRGBColor(128, 128, 128), tcell.PaletteColor(244)},
+		{"gruvbox background", tcell.NewRGBColor(40, 40, 40), tcell.PaletteColor(235)},
+		{"cube entry", tcell.NewRGBColor(0x5f, 0x87, 0xaf), tcell.PaletteColor(67)},
+		{"named color unchanged", tcell.ColorRed, tcell.ColorRed},
+		{"default unchanged", tcell.ColorDefault, tcell.ColorDefault},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NearestPaletteColor(tt.color); got != tt.want {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestThemeCycleFavorites(t *testing.T) {
+	favorites := []string{GruvboxTheme.Name, DraculaTheme.Name, "no-such-theme", MintFreshTheme.Name}
+
+	if got := GetNextTheme(GruvboxTheme, favorites); got.Name != DraculaTheme.Name {
+		t.Errorf("Expected dracula after gruvbox, got %s", got.Name)
+	}
+	if got := GetNextTheme(MintFreshTheme, favorites); got.Name != GruvboxTheme.Name {
+		t.Errorf("Expected the cycle to wrap around to gruvbox, got %s", got.Name)
+	}
+	if got := GetPreviousTheme(GruvboxTheme, favorites); got.Name != MintFreshTheme.Name {
+		t.Errorf("Expected mint-fresh before gruvbox, got %s", got.Name)
+	}
+
+	// A theme outside the favorites enters the cycle at its start or end
+	if got := GetNextTheme(KanagawaTheme, favorites); got.Name != GruvboxTheme.Name {
+		t.Errorf("Expected the first favorite after a non-favorite, got %s", got.Name)
+	}
+	if got := GetPreviousTheme(KanagawaTheme, favorites); got.Name != MintFreshTheme.Name {
+		t.Errorf("Expected the last favorite before a non-favorite, got %s", got.Name)
+	}
+}
+
+func TestThemeCycleWithoutFavorites(t *testing.T) {
+	themes := AvailableThemes()
+
+	for _, favorites := range [][]string{nil, {"no-such-theme"}} {
+		if got := GetNextTheme(themes[0], favorites); got.Name != themes[1].Name {
+			t.Errorf("Expected all themes to be cycled with favorites %v, got %s", favorites, got.Name)
+		}
+		if got := GetPreviousTheme(themes[0], favorites); got.Name != themes[len(themes)-1].Name {
+			t.Errorf("Expected the previous theme to wrap around with favorites %v, got %s", favorites, got.Name)
+		}
+	}
+}
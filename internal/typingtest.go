@@ -3,8 +3,34 @@ package internal
 import (
 	"fmt"
 	"time"
+	"unicode"
 )
 
+// InputEvent records a single input action for replaying a test.
+type InputEvent struct {
+	Timestamp time.Time // When the input happened
+	Char      rune      // Typed character (unused for backspace)
+	Backspace bool      // True if this event removed the last character
+}
+
+// TypingSemantics selects how typed input is matched against the sample text.
+type TypingSemantics string
+
+const (
+	// SemanticsCharacter compares input position by position; a space is just another character.
+	SemanticsCharacter TypingSemantics = "character"
+	// SemanticsWord treats each word independently: letters are matched within the
+	// current word, extra or missing letters are allowed, space always moves on to
+	// the next word and accuracy is computed per word.
+	SemanticsWord TypingSemantics = "word"
+)
+
+// typedWord remembers the input of a finished word so backspace can return to it.
+type typedWord struct {
+	start int    // Index where the word starts in the sample text
+	input []rune // What the user typed for the word, including extra letters
+}
+
 // TypingTest manages the business logic of a typing test session.
 // It handles user input tracking, cursor position, word boundaries,
 // and coordinates with Stats for accuracy and error tracking.
@@ -14,25 +40,45 @@ import (
 type TypingTest struct {
 	sampleText  string // The reference text the user types
 	sampleRunes []rune // Cached rune slice of sampleText for efficient Unicode handling
+	shownRunes  []rune // Text displayed instead of the sample when proofreading (nil otherwise)
 	userInput   string // What the user has typed so far
 	userRunes   []rune // Cached rune slice of userInput for efficient Unicode handling
 	cursorPos   int    // Current position in sampleText (in runes, not bytes)
 	wordStart   int    // Index where current word starts (in runes, not bytes)
 	stats       *Stats // Statistics tracker
 	finished    bool   // Whether the test is complete
+
+	// Stats options applied whenever stats are recreated
+	retainKeystrokeLog bool          // Keep the full keystroke log for analytics
+	wpmLeadIn          time.Duration // Initial hesitation discounted from WPM
+	ignoreErrors       bool          // Don't track misspelled words
+
+	inputLog []InputEvent // Typed characters and backspaces for replay (when log retention is on)
+
+	// Typing behavior
+	skipToNextWordOnSpace bool            // Space typed mid-word jumps to the start of the next word
+	requireExact          bool            // Only finish once the whole input matches the sample
+	strictFinish          bool            // Trailing whitespace of the sample must be typed to finish
+	semantics             TypingSemantics // How input is matched against the sample text
+
+	// Word semantics state
+	wordInput   []rune      // Input for the current word (may be longer than the word)
+	wordHistory []typedWord // Finished words, most recent last
 }
 
 // NewTypingTest creates a new typing test with the given sample text.
 func NewTypingTest(sampleText string) *TypingTest {
 	return &TypingTest{
-		sampleText:  sampleText,
-		sampleRunes: []rune(sampleText),
-		userInput:   "",
-		userRunes:   []rune{},
-		cursorPos:   0,
-		wordStart:   0,
-		stats:       NewStats(),
-		finished:    false,
+		sampleText:   sampleText,
+		sampleRunes:  []rune(sampleText),
+		userInput:    "",
+		userRunes:    []rune{},
+		cursorPos:    0,
+		wordStart:    0,
+		stats:        NewStats(),
+		finished:     false,
+		semantics:    SemanticsCharacter,
+		strictFinish: true,
 	}
 }
 
@@ -46,6 +92,34 @@ func (t *TypingTest) GetSampleRunes() []rune {
 	return t.sampleRunes
 }
 
+// GetDisplayRunes returns the text to display: the text with typos when
+// proofreading, otherwise the sample text.
+func (t *TypingTest) GetDisplayRunes() []rune {
+	if t.shownRunes != nil {
+		return t.shownRunes
+	}
+	return t.sampleRunes
+}
+
+// IsProofreading reports whether the displayed text differs from the text
+// the input is checked against.
+func (t *TypingTest) IsProofreading() bool {
+	return t.shownRunes != nil
+}
+
+// GetLineBoundaries returns the sample positions where each line starts, followed
+// by the end of the text. Consecutive boundaries delimit one line (including its
+// trailing newline), independent of how the text is wrapped on screen.
+func (t *TypingTest) GetLineBoundaries() []int {
+	boundaries := []int{0}
+	for i, r := range t.sampleRunes {
+		if r == '\n' && i+1 < len(t.sampleRunes) {
+			boundaries = append(boundaries, i+1)
+		}
+	}
+	return append(boundaries, len(t.sampleRunes))
+}
+
 // GetUserInput returns what the user has typed so far.
 func (t *TypingTest) GetUserInput() string {
 	return t.userInput
@@ -61,6 +135,35 @@ func (t *TypingTest) GetCursorPos() int {
 	return t.cursorPos
 }
 
+// GetCurrentWord returns the word being typed and the input typed for it so far.
+// A fully typed word stays current until the separator after it is typed; at
+// the start of a word or on a separator, the next word is returned with no input.
+// With word semantics the input may be longer than the word.
+func (t *TypingTest) GetCurrentWord() (word, typed string) {
+	start := t.cursorPos
+	for start > 0 && !isWordSeparator(t.sampleRunes[start-1]) {
+		start--
+	}
+	if start == t.cursorPos {
+		for start < len(t.sampleRunes) && isWordSeparator(t.sampleRunes[start]) {
+			start++
+		}
+	}
+
+	end := start
+	for end < len(t.sampleRunes) && !isWordSeparator(t.sampleRunes[end]) {
+		end++
+	}
+
+	var input []rune
+	if t.semantics == SemanticsWord && start == t.wordStart {
+		input = t.wordInput
+	} else if start < t.cursorPos {
+		input = t.userRunes[start:t.cursorPos]
+	}
+	return string(t.sampleRunes[start:end]), string(input)
+}
+
 // GetStats returns the statistics tracker.
 func (t *TypingTest) GetStats() *Stats {
 	return t.stats
@@ -71,22 +174,183 @@ func (t *TypingTest) IsFinished() bool {
 	return t.finished
 }
 
+// Pause stops the test clock (see Stats.Pause).
+func (t *TypingTest) Pause() {
+	t.stats.Pause()
+}
+
+// Resume restarts the test clock after Pause and returns the length of the
+// pause. Like the stats, the replay log is moved forward by the pause so a
+// replay doesn't show the break.
+func (t *TypingTest) Resume() time.Duration {
+	pause := t.stats.Resume()
+	for i := range t.inputLog {
+		t.inputLog[i].Timestamp = t.inputLog[i].Timestamp.Add(pause)
+	}
+	return pause
+}
+
+// IsPaused returns whether the test clock is stopped by Pause.
+func (t *TypingTest) IsPaused() bool {
+	return t.stats.IsPaused()
+}
+
 // MarkFinished marks the test as complete and finalizes stats.
 // This should be called when ending the test early (e.g., time/word limit reached in word mode).
 func (t *TypingTest) MarkFinished() {
 	if !t.finished {
+		t.recordErrorWordOutcomes()
 		t.stats.Finish()
 		t.finished = true
 	}
 }
 
+// SetRetainKeystrokeLog enables retention of the full keystroke log.
+// The option survives resets so every new test keeps its complete history.
+func (t *TypingTest) SetRetainKeystrokeLog(enabled bool) {
+	t.retainKeystrokeLog = enabled
+	t.stats.SetRetainKeystrokeLog(enabled)
+}
+
+// SetWPMLeadIn sets how much of the start of the test is ignored when computing WPM.
+// The option survives resets.
+func (t *TypingTest) SetWPMLeadIn(d time.Duration) {
+	t.wpmLeadIn = d
+	t.stats.SetLeadInDiscount(d)
+}
+
+// SetTrackErrors enables or disables misspelled word tracking for relaxed practice.
+// The option survives resets.
+func (t *TypingTest) SetTrackErrors(enabled bool) {
+	t.ignoreErrors = !enabled
+	t.stats.SetTrackErrors(enabled)
+}
+
+// SetSkipToNextWordOnSpace enables word-based typing: a space typed in the middle
+// of a word jumps the cursor to the start of the next word, and the skipped
+// characters count as errors.
+func (t *TypingTest) SetSkipToNextWordOnSpace(enabled bool) {
+	t.skipToNextWordOnSpace = enabled
+}
+
+// SetRequireExactToFinish controls how the end of the text is handled. When enabled,
+// the test only finishes once the whole input matches the sample text; reaching
+// the end with errors stops input until they are corrected with backspace.
+func (t *TypingTest) SetRequireExactToFinish(enabled bool) {
+	t.requireExact = enabled
+}
+
+// GetRequireExactToFinish returns whether errors must be corrected before the test finishes.
+func (t *TypingTest) GetRequireExactToFinish() bool {
+	return t.requireExact
+}
+
+// IsAwaitingCorrection returns whether the end of the text was reached with errors
+// that must be corrected before the test finishes (see SetRequireExactToFinish).
+func (t *TypingTest) IsAwaitingCorrection() bool {
+	return !t.finished && t.cursorPos >= t.finishPos() && len(t.sampleRunes) > 0
+}
+
+// SetStrictFinish controls whether whitespace at the end of the sample text,
+// like a final newline, must be typed to finish (the default). When disabled,
+// the test finishes after the last non-whitespace character.
+func (t *TypingTest) SetStrictFinish(enabled bool) {
+	t.strictFinish = enabled
+}
+
+// GetStrictFinish returns whether trailing whitespace must be typed to finish.
+func (t *TypingTest) GetStrictFinish() bool {
+	return t.strictFinish
+}
+
+// finishPos returns the cursor position at which the test completes: the end
+// of the sample text, or without a strict finish the end of its last
+// non-whitespace character. A text of only whitespace ends at its end.
+func (t *TypingTest) finishPos() int {
+	end := len(t.sampleRunes)
+	if t.strictFinish {
+		return end
+	}
+	for end > 0 && unicode.IsSpace(t.sampleRunes[end-1]) {
+		end--
+	}
+	if end == 0 {
+		return len(t.sampleRunes)
+	}
+	return end
+}
+
+// GetSkipToNextWordOnSpace returns whether a space typed mid-word skips the rest of the word.
+func (t *TypingTest) GetSkipToNextWordOnSpace() bool {
+	return t.skipToNextWordOnSpace
+}
+
+// SetSemantics selects character- or word-based typing. Unknown values fall back
+// to character semantics. Progress on the current test is kept.
+func (t *TypingTest) SetSemantics(semantics TypingSemantics) {
+	if semantics != SemanticsWord {
+		semantics = SemanticsCharacter
+	}
+	t.semantics = semantics
+	t.stats.SetWordAccuracy(semantics == SemanticsWord)
+	t.resetWordState()
+}
+
+// GetSemantics returns the active typing semantics.
+func (t *TypingTest) GetSemantics() TypingSemantics {
+	return t.semantics
+}
+
+// GetInputLog returns a copy of the recorded input events for replay.
+// Returns an empty slice if keystroke log retention is disabled.
+func (t *TypingTest) GetInputLog() []InputEvent {
+	// Return a copy to prevent external modification
+	result := make([]InputEvent, len(t.inputLog))
+	copy(result, t.inputLog)
+	return result
+}
+
+// recordInput appends an input event to the replay log if retention is enabled.
+func (t *TypingTest) recordInput(char rune, backspace bool) {
+	if !t.retainKeystrokeLog || len(t.inputLog) >= maxKeystrokeLogEntries {
+		return
+	}
+	t.inputLog = append(t.inputLog, InputEvent{
+		Timestamp: time.Now(),
+		Char:      char,
+		Backspace: backspace,
+	})
+}
+
+// newStats creates a Stats instance configured with the test's stats options.
+func (t *TypingTest) newStats() *Stats {
+	stats := NewStats()
+	stats.SetRetainKeystrokeLog(t.retainKeystrokeLog)
+	stats.SetWordAccuracy(t.semantics == SemanticsWord)
+	stats.SetLeadInDiscount(t.wpmLeadIn)
+	stats.SetTrackErrors(!t.ignoreErrors)
+	return stats
+}
+
 // SetSampleText updates the sample text and resets the test.
 func (t *TypingTest) SetSampleText(text string) {
 	t.sampleText = text
 	t.sampleRunes = []rune(text)
+	t.shownRunes = nil
 	t.Reset()
 }
 
+// SetProofreadText starts a proofreading test: displayed (a text with typos)
+// is shown, while the input is checked against correct, the hidden sample
+// text. Both must have the same number of runes so positions line up;
+// otherwise displayed is ignored and correct is shown as a regular sample.
+func (t *TypingTest) SetProofreadText(displayed, correct string) {
+	t.SetSampleText(correct)
+	if shown := []rune(displayed); len(shown) == len(t.sampleRunes) {
+		t.shownRunes = shown
+	}
+}
+
 // UpdateSampleText updates the sample text WITHOUT resetting progress.
 // This is used in word mode to dynamically extend the text as the user types.
 // The user's input, cursor position, and stats are preserved.
@@ -103,8 +367,10 @@ func (t *TypingTest) Reset() {
 	t.userRunes = []rune{}
 	t.cursorPos = 0
 	t.wordStart = 0
-	t.stats = NewStats()
+	t.stats = t.newStats()
 	t.finished = false
+	t.inputLog = nil
+	t.resetWordState()
 }
 
 // RestoreState restores the test state from a saved session.
@@ -124,7 +390,9 @@ func (t *TypingTest) RestoreState(userInput string, cursorPos int) {
 	}
 
 	t.finished = false
-	t.stats = NewStats()
+	t.inputLog = nil
+	t.stats = t.newStats()
+	t.resetWordState()
 	// Stats will start when user types next character
 }
 
@@ -202,15 +470,39 @@ func (t *TypingTest) TypeCharacter(typedChar rune) bool {
 
 	t.stats.Start()
 
+	if t.semantics == SemanticsWord {
+		t.recordInput(typedChar, false)
+		if typedChar == ' ' {
+			t.advanceWord()
+		} else {
+			t.typeInWord(typedChar)
+		}
+		t.stats.RecordPosition(t.cursorPos)
+		t.checkCompletion()
+		return true
+	}
+
 	expectedChar := t.sampleRunes[t.cursorPos]
+
+	if typedChar == ' ' && t.skipToNextWordOnSpace && t.isMidWord() {
+		t.recordInput(typedChar, false)
+		t.skipRestOfWord()
+		t.stats.RecordPosition(t.cursorPos)
+		t.checkCompletion()
+		return true
+	}
+
 	correct := expectedChar == typedChar
 
 	// Record keystroke
 	t.stats.RecordKeystroke(correct)
+	t.stats.RecordExpectedChar(expectedChar)
+	t.recordInput(typedChar, false)
 
 	// Mark word as having error if incorrect
 	if !correct {
 		t.stats.MarkCurrentWordAsError(t.wordStart)
+		t.stats.RecordMistake(t.cursorPos, expectedChar, typedChar)
 	}
 
 	t.userInput += string(typedChar)
@@ -236,6 +528,7 @@ func (t *TypingTest) TypeCharacter(typedChar rune) bool {
 		}
 	}
 
+	t.stats.RecordPosition(t.cursorPos)
 	t.checkCompletion()
 	return true
 }
@@ -249,16 +542,28 @@ func (t *TypingTest) TypeNewline() bool {
 
 	t.stats.Start()
 
+	if t.semantics == SemanticsWord {
+		// Enter moves on to the next word just like space
+		t.recordInput('\n', false)
+		t.advanceWord()
+		t.stats.RecordPosition(t.cursorPos)
+		t.checkCompletion()
+		return true
+	}
+
 	expectedChar := t.sampleRunes[t.cursorPos]
 	typedChar := '\n'
 	correct := expectedChar == typedChar
 
 	// Record keystroke
 	t.stats.RecordKeystroke(correct)
+	t.stats.RecordExpectedChar(expectedChar)
+	t.recordInput(typedChar, false)
 
 	// Mark word as having error if incorrect
 	if !correct {
 		t.stats.MarkCurrentWordAsError(t.wordStart)
+		t.stats.RecordMistake(t.cursorPos, expectedChar, typedChar)
 	}
 
 	t.userInput += "\n"
@@ -276,6 +581,7 @@ func (t *TypingTest) TypeNewline() bool {
 		}
 	}
 
+	t.stats.RecordPosition(t.cursorPos)
 	t.checkCompletion()
 	return true
 }
@@ -286,7 +592,13 @@ func (t *TypingTest) Backspace() {
 		return
 	}
 
+	if t.semantics == SemanticsWord {
+		t.backspaceInWord()
+		return
+	}
+
 	t.cursorPos--
+	t.recordInput(0, true)
 
 	// Remove last rune from both string and rune slice
 	if len(t.userRunes) > 0 {
@@ -303,8 +615,183 @@ func (t *TypingTest) Backspace() {
 	}
 }
 
-// finishWord records a word as misspelled if it had any errors.
+// ClearCurrentWord removes everything typed in the current word, moving the cursor
+// back to the start of the word. If nothing has been typed in the current word yet,
+// the previous word is cleared instead, so repeated presses walk back word by word.
+// Works through Backspace, so error stats are preserved like with manual corrections.
+func (t *TypingTest) ClearCurrentWord() {
+	if t.semantics == SemanticsWord {
+		if len(t.wordInput) == 0 {
+			t.Backspace() // Return to the previous word
+		}
+		for len(t.wordInput) > 0 {
+			t.Backspace()
+		}
+		return
+	}
+
+	// Step back over separators to reach the previous word when the current one is empty
+	for t.cursorPos > 0 && isWordSeparator(t.sampleRunes[t.cursorPos-1]) {
+		t.Backspace()
+	}
+	for t.cursorPos > 0 && !isWordSeparator(t.sampleRunes[t.cursorPos-1]) {
+		t.Backspace()
+	}
+}
+
+// isMidWord reports whether the cursor is inside a word that has been partially typed.
+func (t *TypingTest) isMidWord() bool {
+	return t.cursorPos > t.wordStart && !isWordSeparator(t.sampleRunes[t.cursorPos])
+}
+
+// skipRestOfWord jumps the cursor past the rest of the current word and the
+// separator that follows it. The skipped characters are filled with spaces so the
+// user input stays aligned with the sample text and they render as errors.
+// The whole jump counts as a single incorrect keystroke.
+func (t *TypingTest) skipRestOfWord() {
+	t.stats.RecordKeystroke(false)
+	t.stats.MarkCurrentWordAsError(t.wordStart)
+
+	wordEnd := t.cursorPos
+	for wordEnd < len(t.sampleRunes) && !isWordSeparator(t.sampleRunes[wordEnd]) {
+		wordEnd++
+	}
+	for t.cursorPos < wordEnd {
+		t.userRunes = append(t.userRunes, ' ')
+		t.cursorPos++
+	}
+	t.finishWord(wordEnd)
+
+	// The typed space stands in for the separator after the word
+	if t.cursorPos < len(t.sampleRunes) {
+		t.userRunes = append(t.userRunes, ' ')
+		t.cursorPos++
+		t.wordStart = t.cursorPos
+	}
+	t.userInput = string(t.userRunes)
+}
+
+// resetWordState rebuilds the word semantics state from the aligned user input.
+func (t *TypingTest) resetWordState() {
+	t.wordHistory = nil
+	t.wordInput = nil
+	if t.semantics == SemanticsWord && t.wordStart < t.cursorPos {
+		t.wordInput = append([]rune{}, t.userRunes[t.wordStart:t.cursorPos]...)
+	}
+}
+
+// currentWordEnd returns the index just past the last letter of the current word.
+func (t *TypingTest) currentWordEnd() int {
+	end := t.wordStart
+	for end < len(t.sampleRunes) && !isWordSeparator(t.sampleRunes[end]) {
+		end++
+	}
+	return end
+}
+
+// syncWordInput projects the current word's input onto the aligned user input.
+// Letters beyond the end of the word are kept in wordInput only; the cursor
+// stays at the end of the word until space is pressed.
+func (t *TypingTest) syncWordInput() {
+	n := min(len(t.wordInput), t.currentWordEnd()-t.wordStart)
+	t.userRunes = append(t.userRunes[:t.wordStart], t.wordInput[:n]...)
+	t.userInput = string(t.userRunes)
+	t.cursorPos = t.wordStart + n
+}
+
+// typeInWord adds a letter to the current word (word semantics).
+func (t *TypingTest) typeInWord(typedChar rune) {
+	pos := t.wordStart + len(t.wordInput)
+	var expectedChar rune
+	if pos < t.currentWordEnd() {
+		expectedChar = t.sampleRunes[pos]
+	}
+	correct := expectedChar == typedChar
+
+	t.stats.RecordKeystroke(correct)
+	t.stats.RecordExpectedChar(expectedChar)
+	if !correct {
+		t.stats.MarkCurrentWordAsError(t.wordStart)
+		t.stats.RecordMistake(pos, expectedChar, typedChar)
+	}
+
+	t.wordInput = append(t.wordInput, typedChar)
+	t.syncWordInput()
+
+	// Typing the last word to its full length ends the test
+	if t.cursorPos >= len(t.sampleRunes) {
+		t.stats.RecordWordResult(t.wordStart, t.wordMatches())
+		t.finishWord(t.cursorPos)
+	}
+}
+
+// wordMatches reports whether the current word's input equals the sample word exactly.
+func (t *TypingTest) wordMatches() bool {
+	return string(t.wordInput) == string(t.sampleRunes[t.wordStart:t.currentWordEnd()])
+}
+
+// advanceWord finishes the current word and moves the cursor to the start of the
+// next one (word semantics). Missing letters are filled with spaces so they render
+// as errors. A space before any letter of the word is ignored.
+func (t *TypingTest) advanceWord() {
+	if len(t.wordInput) == 0 {
+		return
+	}
+
+	t.stats.RecordKeystroke(true)
+	wordEnd := t.currentWordEnd()
+	correct := t.wordMatches()
+	if !correct {
+		t.stats.MarkCurrentWordAsError(t.wordStart)
+	}
+	t.stats.RecordWordResult(t.wordStart, correct)
+	t.finishWord(wordEnd)
+
+	for t.cursorPos < wordEnd {
+		t.userRunes = append(t.userRunes, ' ')
+		t.cursorPos++
+	}
+	// Separators between the words count as typed
+	for t.cursorPos < len(t.sampleRunes) && isWordSeparator(t.sampleRunes[t.cursorPos]) {
+		t.userRunes = append(t.userRunes, t.sampleRunes[t.cursorPos])
+		t.cursorPos++
+	}
+	t.userInput = string(t.userRunes)
+
+	t.wordHistory = append(t.wordHistory, typedWord{start: t.wordStart, input: t.wordInput})
+	t.wordStart = t.cursorPos
+	t.wordInput = nil
+}
+
+// backspaceInWord removes the last letter of the current word, or returns to the
+// end of the previous word if the current one is empty (word semantics).
+func (t *TypingTest) backspaceInWord() {
+	if len(t.wordInput) > 0 {
+		t.recordInput(0, true)
+		t.wordInput = t.wordInput[:len(t.wordInput)-1]
+		t.syncWordInput()
+		return
+	}
+	if len(t.wordHistory) == 0 {
+		return
+	}
+
+	t.recordInput(0, true)
+	prev := t.wordHistory[len(t.wordHistory)-1]
+	t.wordHistory = t.wordHistory[:len(t.wordHistory)-1]
+	t.wordStart = prev.start
+	t.wordInput = prev.input
+	t.syncWordInput()
+}
+
+// isWordSeparator reports whether r separates words in the sample text.
+func isWordSeparator(r rune) bool {
+	return r == ' ' || r == '\n' || r == '\t'
+}
+
+// finishWord records a word as completed, and as misspelled if it had any errors.
 func (t *TypingTest) finishWord(wordEnd int) {
+	t.stats.RecordCompletedWord(t.wordStart)
 	if t.stats.WordHadError(t.wordStart) {
 		word := string(t.sampleRunes[t.wordStart:wordEnd])
 		t.stats.RecordMisspelledWord(word)
@@ -313,10 +800,23 @@ func (t *TypingTest) finishWord(wordEnd int) {
 
 // checkCompletion checks if the test is complete and finalizes stats.
 func (t *TypingTest) checkCompletion() {
-	if t.cursorPos >= len(t.sampleRunes) {
+	if t.finished {
+		return
+	}
+	if t.cursorPos >= t.finishPos() {
+		if t.requireExact && string(t.userRunes) != string(t.sampleRunes[:min(t.cursorPos, len(t.sampleRunes))]) {
+			return
+		}
+
+		// The last word was only finished by typing to the end of the text
+		if t.semantics == SemanticsCharacter && t.cursorPos < len(t.sampleRunes) && t.wordStart < t.cursorPos {
+			t.finishWord(t.cursorPos)
+		}
+
 		// Record ALL words that had errors, not just the current one
 		// This handles cases where user typed through multiple words without spaces
 		t.recordAllMisspelledWords()
+		t.recordErrorWordOutcomes()
 
 		t.stats.Finish()
 		t.finished = true
@@ -354,3 +854,35 @@ func (t *TypingTest) recordAllMisspelledWords() {
 		}
 	}
 }
+
+// recordErrorWordOutcomes records, for every fully typed word that had an error,
+// whether the final input matches the sample word (the error was corrected) or not.
+func (t *TypingTest) recordErrorWordOutcomes() {
+	wordStart := -1
+
+	for i := 0; i <= len(t.sampleRunes) && i <= t.cursorPos; i++ {
+		separator := i == len(t.sampleRunes) || isWordSeparator(t.sampleRunes[i])
+
+		if !separator && wordStart == -1 {
+			wordStart = i
+		} else if separator && wordStart != -1 {
+			if t.stats.WordHadError(wordStart) {
+				t.stats.RecordErrorWordOutcome(t.wordTypedCorrectly(wordStart, i))
+			}
+			wordStart = -1
+		}
+	}
+}
+
+// wordTypedCorrectly reports whether the final input for the word spanning
+// [start, end) matches the sample text.
+func (t *TypingTest) wordTypedCorrectly(start, end int) bool {
+	if t.semantics == SemanticsWord {
+		correct, _ := t.stats.GetWordResult(start)
+		return correct
+	}
+	if end > len(t.userRunes) {
+		return false
+	}
+	return string(t.userRunes[start:end]) == string(t.sampleRunes[start:end])
+}
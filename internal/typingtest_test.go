@@ -0,0 +1,513 @@
+package internal
+
+import "testing"
+
+func typeString(test *TypingTest, s string) {
+	for _, r := range s {
+		test.TypeCharacter(r)
+	}
+}
+
+func TestSkipToNextWordOnSpaceMidWord(t *testing.T) {
+	test := NewTypingTest("hello world")
+	test.SetSkipToNextWordOnSpace(true)
+
+	typeString(test, "he ")
+
+	if test.GetCursorPos() != 6 {
+		t.Fatalf("Expected cursor at start of next word (6), got %d", test.GetCursorPos())
+	}
+	if got := test.GetUserInput(); got != "he    " {
+		t.Errorf("Expected skipped characters to be filled, got %q", got)
+	}
+	if test.GetTotalKeystrokes() != 3 {
+		t.Errorf("Expected 3 keystrokes, got %d", test.GetTotalKeystrokes())
+	}
+	if test.GetCorrectKeystrokes() != 2 {
+		t.Errorf("Expected 2 correct keystrokes, got %d", test.GetCorrectKeystrokes())
+	}
+	if test.GetMisspelledWordsMap()["hello"] != 1 {
+		t.Errorf("Expected skipped word to be recorded as misspelled, got %v", test.GetMisspelledWordsMap())
+	}
+
+	typeString(test, "world")
+	if !test.IsFinished() {
+		t.Error("Expected test to finish after typing the next word")
+	}
+	if test.GetWordErrorsMap()["6"] != 0 {
+		t.Error("Expected the next word to start without errors")
+	}
+}
+
+func TestSkipToNextWordOnSpaceLastWord(t *testing.T) {
+	test := NewTypingTest("hello world")
+	test.SetSkipToNextWordOnSpace(true)
+
+	typeString(test, "hello wo ")
+
+	if !test.IsFinished() {
+		t.Fatal("Expected skipping the last word to finish the test")
+	}
+	if test.GetCursorPos() != len("hello world") {
+		t.Errorf("Expected cursor at end of text, got %d", test.GetCursorPos())
+	}
+}
+
+func TestSkipToNextWordOnSpaceAtWordStart(t *testing.T) {
+	test := NewTypingTest("hello world")
+	test.SetSkipToNextWordOnSpace(true)
+
+	// A space before typing anything in the word is a regular (wrong) character
+	typeString(test, " ")
+
+	if test.GetCursorPos() != 1 {
+		t.Errorf("Expected cursor to advance by one, got %d", test.GetCursorPos())
+	}
+}
+
+func TestSkipToNextWordOnSpaceBackspace(t *testing.T) {
+	test := NewTypingTest("hello world")
+	test.SetSkipToNextWordOnSpace(true)
+
+	typeString(test, "he ")
+	test.Backspace()
+	test.Backspace()
+	test.Backspace()
+	test.Backspace()
+
+	if test.GetCursorPos() != 2 || test.GetUserInput() != "he" {
+		t.Fatalf("Expected to backspace into the skipped word, got cursor %d input %q",
+			test.GetCursorPos(), test.GetUserInput())
+	}
+
+	typeString(test, "llo world")
+	if !test.IsFinished() {
+		t.Error("Expected test to finish after retyping")
+	}
+}
+
+func TestSpaceMidWordWithoutSkip(t *testing.T) {
+	test := NewTypingTest("hello world")
+
+	typeString(test, "he ")
+
+	if test.GetCursorPos() != 3 {
+		t.Errorf("Expected space to be a regular character, got cursor %d", test.GetCursorPos())
+	}
+}
+
+func TestSemanticsWithSkippedLetter(t *testing.T) {
+	const sample = "the quick fox"
+	const typed = "the quck fox" // 'i' skipped
+
+	character := NewTypingTest(sample)
+	typeString(character, typed)
+
+	if character.IsFinished() {
+		t.Error("Character semantics: expected test to be unfinished after a skipped letter")
+	}
+	// Every character after the skipped letter is shifted by one
+	if acc := character.GetStats().GetAccuracy(); acc > 60 {
+		t.Errorf("Character semantics: expected shifted input to ruin accuracy, got %.1f%%", acc)
+	}
+
+	word := NewTypingTest(sample)
+	word.SetSemantics(SemanticsWord)
+	typeString(word, typed)
+
+	if !word.IsFinished() {
+		t.Fatal("Word semantics: expected test to finish")
+	}
+	if acc := word.GetStats().GetAccuracy(); acc < 66 || acc > 67 {
+		t.Errorf("Word semantics: expected 2 of 3 words correct (66.7%%), got %.1f%%", acc)
+	}
+	misspelled := word.GetMisspelledWordsMap()
+	if misspelled["quick"] == 0 || misspelled["fox"] != 0 || misspelled["the"] != 0 {
+		t.Errorf("Word semantics: expected only \"quick\" misspelled, got %v", misspelled)
+	}
+}
+
+func TestWordSemanticsExtraLetters(t *testing.T) {
+	test := NewTypingTest("ab cd")
+	test.SetSemantics(SemanticsWord)
+
+	typeString(test, "abx")
+	if test.GetCursorPos() != 2 {
+		t.Errorf("Expected cursor to stay at end of word with extra letters, got %d", test.GetCursorPos())
+	}
+
+	// Removing the extra letter makes the word correct again
+	test.Backspace()
+	typeString(test, " cd")
+
+	if !test.IsFinished() {
+		t.Fatal("Expected test to finish")
+	}
+	if acc := test.GetStats().GetAccuracy(); acc != 100 {
+		t.Errorf("Expected 100%% word accuracy, got %.1f%%", acc)
+	}
+}
+
+func TestWordSemanticsBackspaceIntoPreviousWord(t *testing.T) {
+	test := NewTypingTest("ab cd")
+	test.SetSemantics(SemanticsWord)
+
+	typeString(test, "a ")
+	if test.GetCursorPos() != 3 || test.GetUserInput() != "a  " {
+		t.Fatalf("Expected to advance past the missing letter, got cursor %d input %q",
+			test.GetCursorPos(), test.GetUserInput())
+	}
+
+	test.Backspace()
+	if test.GetCursorPos() != 1 || test.GetUserInput() != "a" {
+		t.Fatalf("Expected to return to the previous word, got cursor %d input %q",
+			test.GetCursorPos(), test.GetUserInput())
+	}
+
+	typeString(test, "b cd")
+	if !test.IsFinished() {
+		t.Fatal("Expected test to finish")
+	}
+	if acc := test.GetStats().GetAccuracy(); acc != 100 {
+		t.Errorf("Expected corrected word to count as correct, got %.1f%%", acc)
+	}
+}
+
+func TestWordSemanticsIgnoresLeadingSpace(t *testing.T) {
+	test := NewTypingTest("ab cd")
+	test.SetSemantics(SemanticsWord)
+
+	typeString(test, " ")
+	if test.GetCursorPos() != 0 {
+		t.Errorf("Expected space before any letter to be ignored, got cursor %d", test.GetCursorPos())
+	}
+}
+
+func TestClearCurrentWord(t *testing.T) {
+	test := NewTypingTest("hello world again")
+
+	typeString(test, "hello wxr")
+	test.ClearCurrentWord()
+
+	if test.GetCursorPos() != 6 || test.GetUserInput() != "hello " {
+		t.Fatalf("Expected only the current word to be cleared, got cursor %d input %q",
+			test.GetCursorPos(), test.GetUserInput())
+	}
+
+	// Errors made before clearing are kept
+	if test.GetTotalKeystrokes() != 9 || test.GetCorrectKeystrokes() != 8 {
+		t.Errorf("Expected keystroke stats to be preserved, got %d/%d",
+			test.GetCorrectKeystrokes(), test.GetTotalKeystrokes())
+	}
+	if test.GetWordErrorsMap()["6"] != 1 {
+		t.Error("Expected the cleared word to stay marked as having an error")
+	}
+
+	// With nothing typed in the current word, the previous word is cleared
+	test.ClearCurrentWord()
+	if test.GetCursorPos() != 0 || test.GetUserInput() != "" {
+		t.Errorf("Expected previous word to be cleared, got cursor %d input %q",
+			test.GetCursorPos(), test.GetUserInput())
+	}
+
+	// Clearing at the very start is a no-op
+	test.ClearCurrentWord()
+	if test.GetCursorPos() != 0 {
+		t.Errorf("Expected cursor to stay at 0, got %d", test.GetCursorPos())
+	}
+}
+
+func TestClearCurrentWordWordSemantics(t *testing.T) {
+	test := NewTypingTest("ab cd")
+	test.SetSemantics(SemanticsWord)
+
+	typeString(test, "ab cxyz")
+	test.ClearCurrentWord()
+	if test.GetCursorPos() != 3 || test.GetUserInput() != "ab " {
+		t.Fatalf("Expected current word including extra letters cleared, got cursor %d input %q",
+			test.GetCursorPos(), test.GetUserInput())
+	}
+
+	test.ClearCurrentWord()
+	if test.GetCursorPos() != 0 || test.GetUserInput() != "" {
+		t.Errorf("Expected previous word to be cleared, got cursor %d input %q",
+			test.GetCursorPos(), test.GetUserInput())
+	}
+}
+
+func TestGetLineBoundaries(t *testing.T) {
+	test := NewTypingTest("ab\ncd\n\nef\n")
+	want := []int{0, 3, 6, 7, 10}
+
+	got := test.GetLineBoundaries()
+	if len(got) != len(want) {
+		t.Fatalf("Expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Expected %v, got %v", want, got)
+			break
+		}
+	}
+}
+
+func TestGetCurrentWord(t *testing.T) {
+	tests := []struct {
+		name      string
+		typed     string
+		wantWord  string
+		wantTyped string
+	}{
+		{"nothing typed", "", "hello", ""},
+		{"within first word", "hel", "hello", "hel"},
+		{"with a mistake", "hx", "hello", "hx"},
+		{"word complete, separator pending", "hello", "hello", "hello"},
+		{"start of next word", "hello ", "world", ""},
+		{"within next word", "hello wo", "world", "wo"},
+		{"finished", "hello world", "world", "world"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test := NewTypingTest("hello world")
+			typeString(test, tt.typed)
+
+			word, typed := test.GetCurrentWord()
+			if word != tt.wantWord || typed != tt.wantTyped {
+				t.Errorf("Expected (%q, %q), got (%q, %q)", tt.wantWord, tt.wantTyped, word, typed)
+			}
+		})
+	}
+}
+
+func TestGetCurrentWordSkipsSeparators(t *testing.T) {
+	test := NewTypingTest("ab\n\ncd")
+	typeString(test, "ab\n")
+
+	if word, typed := test.GetCurrentWord(); word != "cd" || typed != "" {
+		t.Errorf("Expected next word after separators, got (%q, %q)", word, typed)
+	}
+}
+
+func TestGetCurrentWordWordSemanticsExtraLetters(t *testing.T) {
+	test := NewTypingTest("ab cd")
+	test.SetSemantics(SemanticsWord)
+	typeString(test, "abx")
+
+	if word, typed := test.GetCurrentWord(); word != "ab" || typed != "abx" {
+		t.Errorf("Expected extra letters in typed input, got (%q, %q)", word, typed)
+	}
+}
+
+func TestCorrectedWordCount(t *testing.T) {
+	test := NewTypingTest("ab cd ef")
+
+	// "ab" is fixed via backspace, "cd" is left wrong, "ef" is typed cleanly
+	typeString(test, "ax")
+	test.Backspace()
+	typeString(test, "b cx ef")
+
+	if !test.IsFinished() {
+		t.Fatal("Expected test to finish")
+	}
+	stats := test.GetStats()
+	if got := stats.GetCorrectedWordCount(); got != 1 {
+		t.Errorf("Expected 1 corrected word, got %d", got)
+	}
+	if got := stats.GetUncorrectedWordCount(); got != 1 {
+		t.Errorf("Expected 1 word left wrong, got %d", got)
+	}
+}
+
+func TestCorrectedWordCountWordSemantics(t *testing.T) {
+	test := NewTypingTest("ab cd ef")
+	test.SetSemantics(SemanticsWord)
+
+	typeString(test, "abx")
+	test.Backspace()
+	typeString(test, " c ef")
+
+	if !test.IsFinished() {
+		t.Fatal("Expected test to finish")
+	}
+	stats := test.GetStats()
+	if stats.GetCorrectedWordCount() != 1 || stats.GetUncorrectedWordCount() != 1 {
+		t.Errorf("Expected 1 corrected and 1 uncorrected word, got %d and %d",
+			stats.GetCorrectedWordCount(), stats.GetUncorrectedWordCount())
+	}
+}
+
+func TestCorrectedWordCountEndedEarly(t *testing.T) {
+	test := NewTypingTest("ab cd ef")
+
+	typeString(test, "xb c")
+	test.MarkFinished()
+
+	// "cd" is unfinished, so only "ab" counts
+	stats := test.GetStats()
+	if stats.GetCorrectedWordCount() != 0 || stats.GetUncorrectedWordCount() != 1 {
+		t.Errorf("Expected only the finished word counted, got %d corrected and %d uncorrected",
+			stats.GetCorrectedWordCount(), stats.GetUncorrectedWordCount())
+	}
+}
+
+func TestWordAccuracy(t *testing.T) {
+	test := NewTypingTest("ab cd ef gh")
+	stats := test.GetStats()
+
+	// "ab" is fixed via backspace but still counts as errored, "cd" has two mistakes
+	typeString(test, "ax")
+	test.Backspace()
+	typeString(test, "b xx ")
+	if got := stats.GetCompletedWordCount(); got != 2 {
+		t.Fatalf("Expected 2 completed words, got %d", got)
+	}
+	if got := stats.GetWordAccuracy(); got != 0 {
+		t.Errorf("Expected 0%% word accuracy after two errored words, got %.1f", got)
+	}
+
+	// The unfinished word doesn't count yet
+	typeString(test, "ef g")
+	if got := stats.GetCompletedWordCount(); got != 3 {
+		t.Errorf("Expected 3 completed words, got %d", got)
+	}
+
+	typeString(test, "h")
+	if got := stats.GetWordAccuracy(); got != 50 {
+		t.Errorf("Expected 50%% word accuracy with 2 of 4 words clean, got %.1f", got)
+	}
+	if got := NewStats().GetWordAccuracy(); got != 100 {
+		t.Errorf("Expected 100%% before any word is completed, got %.1f", got)
+	}
+}
+
+func TestRequireExactToFinish(t *testing.T) {
+	// Default: reaching the end finishes even with a trailing error
+	lenient := NewTypingTest("abc")
+	typeString(lenient, "abx")
+	if !lenient.IsFinished() {
+		t.Error("Expected the test to finish at the end of the text by default")
+	}
+
+	exact := NewTypingTest("abc")
+	exact.SetRequireExactToFinish(true)
+	typeString(exact, "abx")
+
+	if exact.IsFinished() {
+		t.Fatal("Expected the test not to finish with a trailing error")
+	}
+	if !exact.IsAwaitingCorrection() {
+		t.Error("Expected the test to await correction")
+	}
+	if exact.TypeCharacter('c') {
+		t.Error("Expected input past the end to be ignored")
+	}
+
+	exact.Backspace()
+	typeString(exact, "c")
+	if !exact.IsFinished() || exact.IsAwaitingCorrection() {
+		t.Error("Expected the test to finish once the input is exact")
+	}
+}
+
+func TestRequireExactToFinishWordSemantics(t *testing.T) {
+	test := NewTypingTest("ab cd")
+	test.SetSemantics(SemanticsWord)
+	test.SetRequireExactToFinish(true)
+
+	// The first word is left wrong, the last one is typed correctly
+	typeString(test, "a cd")
+	if test.IsFinished() || !test.IsAwaitingCorrection() {
+		t.Fatal("Expected an earlier wrong word to block finishing")
+	}
+
+	// Back through "cd" into the first word, which keeps its input "a"
+	for range 3 {
+		test.Backspace()
+	}
+	typeString(test, "b cd")
+	if !test.IsFinished() {
+		t.Errorf("Expected the corrected test to finish, got input %q", test.GetUserInput())
+	}
+}
+
+func TestStrictFinish(t *testing.T) {
+	// Default: the trailing newline must be typed
+	strict := NewTypingTest("ab cd\n")
+	typeString(strict, "ab cd")
+	if strict.IsFinished() {
+		t.Fatal("Expected the test not to finish before the trailing newline is typed")
+	}
+	strict.TypeNewline()
+	if !strict.IsFinished() || strict.GetUserInput() != "ab cd\n" {
+		t.Errorf("Expected the test to finish with the newline typed, got %q", strict.GetUserInput())
+	}
+
+	lenient := NewTypingTest("ab cd\n")
+	lenient.SetStrictFinish(false)
+	typeString(lenient, "ab cx")
+	if !lenient.IsFinished() {
+		t.Fatal("Expected the test to finish after the last visible character")
+	}
+	if words := lenient.GetStats().GetMisspelledWords(); len(words) != 1 || words[0] != "cd" {
+		t.Errorf("Expected the last word to be recorded as misspelled, got %v", words)
+	}
+	if got := lenient.GetStats().GetCompletedWordCount(); got != 2 {
+		t.Errorf("Expected both words to count as completed, got %d", got)
+	}
+}
+
+func TestStrictFinishWithRequireExact(t *testing.T) {
+	test := NewTypingTest("ab\n\n")
+	test.SetStrictFinish(false)
+	test.SetRequireExactToFinish(true)
+
+	typeString(test, "ax")
+	if test.IsFinished() || !test.IsAwaitingCorrection() {
+		t.Fatal("Expected an error before the trailing newlines to block finishing")
+	}
+	test.Backspace()
+	typeString(test, "b")
+	if !test.IsFinished() {
+		t.Error("Expected the corrected test to finish without typing the trailing newlines")
+	}
+}
+
+func TestProofreadScoresAgainstCorrectText(t *testing.T) {
+	test := NewTypingTest("")
+	test.SetProofreadText("teh cat", "the cat")
+
+	if got := string(test.GetDisplayRunes()); got != "teh cat" {
+		t.Errorf("Expected the text with typos to be displayed, got %q", got)
+	}
+
+	// Copying the displayed typo is a mistake
+	typeString(test, "te")
+	if test.GetCorrectKeystrokes() != 1 {
+		t.Errorf("Expected only 't' to count as correct, got %d", test.GetCorrectKeystrokes())
+	}
+
+	test.Reset()
+	typeString(test, "the cat")
+	if !test.IsFinished() {
+		t.Error("Expected the corrected text to finish the test")
+	}
+	if test.GetCorrectKeystrokes() != 7 || test.GetTotalKeystrokes() != 7 {
+		t.Errorf("Expected all 7 keystrokes correct, got %d of %d", test.GetCorrectKeystrokes(), test.GetTotalKeystrokes())
+	}
+
+	// A regular sample ends proofreading
+	test.SetSampleText("dog")
+	if test.IsProofreading() || string(test.GetDisplayRunes()) != "dog" {
+		t.Error("Expected a regular sample to be displayed as is")
+	}
+}
+
+func TestProofreadRejectsMismatchedLength(t *testing.T) {
+	test := NewTypingTest("")
+	test.SetProofreadText("the cats", "the cat")
+
+	if test.IsProofreading() || string(test.GetDisplayRunes()) != "the cat" {
+		t.Error("Expected a displayed text of different length to be ignored")
+	}
+}
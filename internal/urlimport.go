@@ -0,0 +1,114 @@
+package internal
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	// importTimeout bounds how long fetching a text from a URL may take.
+	importTimeout = 10 * time.Second
+
+	// maxImportBytes caps the size of a fetched page.
+	maxImportBytes = 5 << 20
+)
+
+var (
+	htmlCommentPattern = regexp.MustCompile(`(?s)<!--.*?-->`)
+	htmlScriptPattern  = regexp.MustCompile(`(?is)<script\b.*?</script\s*>`)
+	htmlStylePattern   = regexp.MustCompile(`(?is)<style\b.*?</style\s*>`)
+	htmlHeadPattern    = regexp.MustCompile(`(?is)<head\b.*?</head\s*>`)
+	htmlLineBreakTags  = regexp.MustCompile(`(?i)<(br|hr|/p|/div|/li|/h[1-6]|/tr|/blockquote|/pre|/title)\b[^>]*>`)
+	htmlTagPattern     = regexp.MustCompile(`<[^>]*>`)
+	htmlMediaTypes     = []string{"text/html", "application/xhtml+xml"}
+)
+
+// StripHTML turns an HTML document into plain text. Scripts, styles, the head
+// and comments are dropped, runs of whitespace are collapsed, block elements
+// end a line and entities are decoded. Empty lines are removed.
+func StripHTML(body string) string {
+	body = htmlCommentPattern.ReplaceAllString(body, "")
+	body = htmlScriptPattern.ReplaceAllString(body, "")
+	body = htmlStylePattern.ReplaceAllString(body, "")
+	body = htmlHeadPattern.ReplaceAllString(body, "")
+	// Line breaks in the source are just whitespace; only tags end lines
+	body = strings.Join(strings.Fields(body), " ")
+	body = htmlLineBreakTags.ReplaceAllString(body, "\n")
+	body = htmlTagPattern.ReplaceAllString(body, "")
+	body = html.UnescapeString(body)
+
+	var lines []string
+	for _, line := range strings.Split(body, "\n") {
+		if line = strings.Join(strings.Fields(line), " "); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// FetchText downloads rawURL and returns its content as plain text. HTML pages
+// are stripped with StripHTML. Fails on non-200 responses, on timeouts of the
+// client and if no text is left.
+func FetchText(client *http.Client, rawURL string) (string, error) {
+	resp, err := client.Get(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch %s: %w", rawURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to fetch %s: %s", rawURL, resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxImportBytes))
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", rawURL, err)
+	}
+
+	text := string(data)
+	if isHTML(resp.Header.Get("Content-Type"), text) {
+		text = StripHTML(text)
+	}
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return "", fmt.Errorf("no text found at %s", rawURL)
+	}
+	return text, nil
+}
+
+// isHTML reports whether a response is an HTML page, going by its content type
+// or, without one, by its first bytes.
+func isHTML(contentType, body string) bool {
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+		for _, htmlType := range htmlMediaTypes {
+			if mediaType == htmlType {
+				return true
+			}
+		}
+		return false
+	}
+	start := strings.ToLower(strings.TrimSpace(body[:min(len(body), 512)]))
+	return strings.HasPrefix(start, "<!doctype html") || strings.HasPrefix(start, "<html")
+}
+
+// ImportedTextName derives a text name from a URL: the last path element
+// without extension, or the host for URLs without a path.
+func ImportedTextName(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	name := strings.TrimSuffix(path.Base(parsed.Path), path.Ext(parsed.Path))
+	if name == "" || name == "." || name == "/" {
+		return parsed.Host
+	}
+	return name
+}
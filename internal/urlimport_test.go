@@ -0,0 +1,129 @@
+package internal
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestStripHTML(t *testing.T) {
+	page := `<!DOCTYPE html>
+<html><head><title>Poem</title><style>p { color: red; }</style></head>
+<body>
+<!-- navigation -->
+<script>var x = "<p>not text</p>";</script>
+<h1>The Road</h1>
+<p>Roads go   ever
+ever on,<br>Over rock &amp; under tree.</p>
+<ul><li>One</li><li>Two</li></ul>
+</body></html>`
+
+	want := "The Road\nRoads go ever ever on,\nOver rock & under tree.\nOne\nTwo"
+	if got := StripHTML(page); got != want {
+		t.Errorf("StripHTML() =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestFetchText(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/poem.html":
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			w.Write([]byte("<p>Over snow</p><p>by winter sown</p>"))
+		case "/plain.txt":
+			w.Header().Set("Content-Type", "text/plain")
+			w.Write([]byte("  a <b>literal</b> text \n"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	text, err := FetchText(server.Client(), server.URL+"/poem.html")
+	if err != nil || text != "Over snow\nby winter sown" {
+		t.Errorf("Expected the stripped page, got %q (err %v)", text, err)
+	}
+
+	text, err = FetchText(server.Client(), server.URL+"/plain.txt")
+	if err != nil || text != "a <b>literal</b> text" {
+		t.Errorf("Expected plain text to be kept as is, got %q (err %v)", text, err)
+	}
+
+	if _, err := FetchText(server.Client(), server.URL+"/missing"); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("Expected a 404 error, got %v", err)
+	}
+}
+
+func TestFetchTextTimeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := server.Client()
+	client.Timeout = 50 * time.Millisecond
+
+	start := time.Now()
+	if _, err := FetchText(client, server.URL); err == nil {
+		t.Fatal("Expected a timeout error")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Expected the client timeout to end the request early, took %v", elapsed)
+	}
+}
+
+func TestImportedTextName(t *testing.T) {
+	tests := map[string]string{
+		"https://example.com/books/poem.html": "poem",
+		"https://example.com/":                "example.com",
+		"https://example.com":                 "example.com",
+	}
+	for rawURL, want := range tests {
+		if got := ImportedTextName(rawURL); got != want {
+			t.Errorf("ImportedTextName(%q) = %q, want %q", rawURL, got, want)
+		}
+	}
+}
+
+func TestImportURLInBackground(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte("Imported words to type"))
+	}))
+	defer server.Close()
+
+	app := newTestApp(t)
+	app.importURL(server.URL + "/story.txt")
+	if app.confirm == nil || !strings.Contains(app.confirm.message, "Fetching") {
+		t.Fatalf("Expected a fetching notice while the download runs, got %+v", app.confirm)
+	}
+	close(release)
+
+	events := make(chan tcell.Event)
+	go func() { events <- app.screen.PollEvent() }()
+	select {
+	case ev := <-events:
+		interrupt, ok := ev.(*tcell.EventInterrupt)
+		if !ok {
+			t.Fatalf("Expected the result as an interrupt event, got %T", ev)
+		}
+		app.finishImport(interrupt.Data().(importResult))
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the import result to be posted")
+	}
+
+	if app.confirm != nil {
+		t.Errorf("Expected the fetching notice to be cleared, got %q", app.confirm.message)
+	}
+	if got := app.typingTest.GetSampleText(); got != "Imported words to type" {
+		t.Errorf("Expected the imported text to be selected, got %q", got)
+	}
+}
@@ -1,19 +1,113 @@
 package internal
 
 import (
+	"encoding/json"
 	"fmt"
+	"math"
 	"math/rand"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
+	"unicode"
+)
+
+// WordCase selects how the letter case of generated words is changed.
+type WordCase string
+
+const (
+	// WordCaseAsIs keeps words as they appear in the word set.
+	WordCaseAsIs WordCase = "as-is"
+	// WordCaseCapitalized uppercases the first letter of every word.
+	WordCaseCapitalized WordCase = "capitalized"
+	// WordCaseRandomCapitals uppercases one random letter of every word.
+	WordCaseRandomCapitals WordCase = "random-capitals"
+)
+
+// WordGenOptions shape generated words into sentence-like text.
+type WordGenOptions struct {
+	Punctuation    bool    // End sentences of a few words with . ? or ! and put , or ; between some words
+	Capitalization bool    // Capitalize the first letter of some words and, with punctuation, of every sentence; added on top of the WordCase, never undoing its capitals
+	Numbers        bool    // Replace some words with numbers like 42 or 2024
+	NumberChance   float64 // Chance of a word being replaced by a number (0 = defaultNumberChance)
+}
+
+const (
+	minSentenceWords    = 4    // Shortest generated sentence in words
+	maxSentenceWords    = 12   // Longest generated sentence in words
+	pauseChance         = 0.12 // Chance of a comma or semicolon after a word within a sentence
+	capitalizeChance    = 0.15 // Chance of capitalizing a word that doesn't start a sentence
+	defaultNumberChance = 0.15 // Chance of a word being replaced by a number
+	maxNumberDigits     = 4    // Longest generated number in digits
+	sentenceEndMarks    = "...?!"
+	sentencePauseMarks  = ",,;"
 )
 
 // WordSet represents a word list with its metadata.
 type WordSet struct {
-	Name  string   // Display name (filename without extension)
-	Words []string // The list of words
-	Path  string   // Full file path
+	Name       string    // Display name (from JSON metadata, or filename without extension)
+	Words      []string  // The list of words
+	Weights    []float64 // Selection weight of each word, parallel to Words; nil picks words uniformly
+	Path       string    // Full file path
+	Language   string    // Language of the words (JSON word sets only)
+	Difficulty string    // Recommended difficulty, e.g. "easy" (JSON word sets only)
+}
+
+// hardWeightQuantile is the share of a weighted word set's words, lightest
+// first, left out by the hard words only option.
+const hardWeightQuantile = 0.5
+
+// wordSetFile is the format of .json word set files.
+type wordSetFile struct {
+	Name       string    `json:"name"`
+	Language   string    `json:"language"`
+	Difficulty string    `json:"difficulty"`
+	Words      []string  `json:"words"`
+	Weights    []float64 `json:"weights"` // Optional weight per entry of Words (see parseWeightedWordList)
+}
+
+// wordsAndWeights returns the words of the file along with their weights (nil
+// if it has none). Entries holding several words are split like text lists,
+// which leaves no room for weights. Returns false if the weights don't match
+// the words: one valid weight per entry, and a single word per entry.
+func (f wordSetFile) wordsAndWeights() ([]string, []float64, bool) {
+	if f.Weights == nil {
+		return parseWordList(strings.Join(f.Words, "\n")), nil, true
+	}
+	if len(f.Weights) != len(f.Words) {
+		return nil, nil, false
+	}
+	for i, word := range f.Words {
+		if len(strings.Fields(word)) != 1 || !validWeight(f.Weights[i]) {
+			return nil, nil, false
+		}
+	}
+	words := make([]string, len(f.Words))
+	for i, word := range f.Words {
+		words[i] = strings.TrimSpace(word)
+	}
+	return words, f.Weights, true
+}
+
+// Describe returns a short description of the word set for menus, including
+// its language and difficulty when known.
+func (ws WordSet) Describe() string {
+	description := fmt.Sprintf("Practice random words from '%s'", ws.Name)
+
+	var details []string
+	for _, detail := range []string{ws.Language, ws.Difficulty} {
+		if detail != "" {
+			details = append(details, detail)
+		}
+	}
+	if len(details) > 0 {
+		description += " (" + strings.Join(details, ", ") + ")"
+	}
+	return description
 }
 
 // WordLibrary manages the collection of available word sets.
@@ -22,13 +116,28 @@ type WordLibrary struct {
 	currentIdx int    // Index of currently selected word set
 	wordsDir   string // Directory where word files are stored
 	rand       *rand.Rand
+	style      wordStyle  // How words are generated by GenerateRandomWords
+	mu         sync.Mutex // Guards rand and style, since words are also generated off the main loop
 }
 
+// wordStyle holds the settings that change which words are generated and how
+// they look. The zero value (plus WordCaseAsIs) leaves the words as listed.
+type wordStyle struct {
+	wordCase   WordCase       // Case transform applied to generated words
+	minLength  int            // Minimum length (in grapheme clusters) of generated words; 0 for any length
+	genOptions WordGenOptions // Punctuation, capitals and numbers added to generated words
+	hardOnly   bool           // Only generate the heaviest words of weighted word sets
+}
+
+// neutralStyle generates the words of a set unchanged, so everyone gets the
+// same words from the same seed whatever their settings.
+var neutralStyle = wordStyle{wordCase: WordCaseAsIs}
+
 // NewWordLibrary creates a new WordLibrary instance.
-// It loads all .txt files from the specified directory.
+// It loads all .txt and .json files from the specified directory.
 //
 // Parameters:
-//   - wordsDir: directory path to search for .txt files with word lists
+//   - wordsDir: directory path to search for .txt and .json word lists
 //
 // Returns a WordLibrary (may be empty if no files found).
 func NewWordLibrary(wordsDir string) *WordLibrary {
@@ -37,6 +146,7 @@ func NewWordLibrary(wordsDir string) *WordLibrary {
 		wordSets:   make([]WordSet, 0),
 		currentIdx: 0,
 		rand:       rand.New(rand.NewSource(time.Now().UnixNano())),
+		style:      wordStyle{wordCase: WordCaseAsIs},
 	}
 
 	// Try to load word sets from directory
@@ -45,8 +155,10 @@ func NewWordLibrary(wordsDir string) *WordLibrary {
 	return wl
 }
 
-// loadWordSets reads all .txt files from the words directory.
-// Each file should contain words (one per line or space-separated).
+// loadWordSets reads all .txt and .json files from the words directory.
+// Text files contain words (one per line or space-separated), or one
+// "word,weight" pair per line (see parseWeightedWordList); JSON files contain
+// the words along with metadata and optional weights (see wordSetFile).
 func (wl *WordLibrary) loadWordSets() error {
 	// Check if directory exists
 	if _, err := os.Stat(wl.wordsDir); os.IsNotExist(err) {
@@ -59,14 +171,14 @@ func (wl *WordLibrary) loadWordSets() error {
 		return fmt.Errorf("failed to read words directory: %w", err)
 	}
 
-	// Load each .txt file
+	// Load each .txt and .json file
 	for _, entry := range entries {
 		if entry.IsDir() {
 			continue
 		}
 
-		// Only process .txt files
-		if !strings.HasSuffix(strings.ToLower(entry.Name()), ".txt") {
+		ext := strings.ToLower(filepath.Ext(entry.Name()))
+		if ext != ".txt" && ext != ".json" {
 			continue
 		}
 
@@ -77,39 +189,97 @@ func (wl *WordLibrary) loadWordSets() error {
 			continue
 		}
 
-		// Parse words from file (support both newline and space-separated)
-		text := string(content)
-		words := make([]string, 0)
-
-		// Split by both newlines and spaces
-		lines := strings.Split(text, "\n")
-		for _, line := range lines {
-			line = strings.TrimSpace(line)
-			if line == "" {
+		wordSet := WordSet{
+			Name: strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name())),
+			Path: path,
+		}
+		if ext == ".json" {
+			var file wordSetFile
+			if err := json.Unmarshal(content, &file); err != nil {
+				// Skip malformed files
+				continue
+			}
+			words, weights, ok := file.wordsAndWeights()
+			if !ok {
 				continue
 			}
-			// Split each line by spaces in case words are space-separated
-			lineWords := strings.Fields(line)
-			words = append(words, lineWords...)
+			if file.Name != "" {
+				wordSet.Name = file.Name
+			}
+			wordSet.Language = file.Language
+			wordSet.Difficulty = file.Difficulty
+			wordSet.Words = words
+			wordSet.Weights = weights
+		} else if words, weights, ok := parseWeightedWordList(string(content)); ok {
+			wordSet.Words = words
+			wordSet.Weights = weights
+		} else {
+			wordSet.Words = parseWordList(string(content))
 		}
 
 		// Skip empty word sets
-		if len(words) == 0 {
+		if len(wordSet.Words) == 0 {
 			continue
 		}
 
-		// Create word set
-		name := strings.TrimSuffix(entry.Name(), ".txt")
-		wl.wordSets = append(wl.wordSets, WordSet{
-			Name:  name,
-			Words: words,
-			Path:  path,
-		})
+		wl.wordSets = append(wl.wordSets, wordSet)
 	}
 
 	return nil
 }
 
+// parseWordList splits text into words, supporting both newline and space separation.
+func parseWordList(text string) []string {
+	words := make([]string, 0)
+
+	// Split by both newlines and spaces
+	lines := strings.Split(text, "\n")
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		// Split each line by spaces in case words are space-separated
+		lineWords := strings.Fields(line)
+		words = append(words, lineWords...)
+	}
+
+	return words
+}
+
+// parseWeightedWordList parses one "word,weight" pair per line, e.g.
+// "rhythm,3", where the weight is a positive number that makes a word that
+// many times as likely to be picked as a word of weight 1. Empty lines are
+// skipped. Returns false if any line is not such a pair, so plain word lists
+// (including words with commas) are left to parseWordList.
+func parseWeightedWordList(text string) ([]string, []float64, bool) {
+	var words []string
+	var weights []float64
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		word, weightText, found := strings.Cut(line, ",")
+		word = strings.TrimSpace(word)
+		if !found || word == "" || strings.ContainsAny(word, " \t") {
+			return nil, nil, false
+		}
+		weight, err := strconv.ParseFloat(strings.TrimSpace(weightText), 64)
+		if err != nil || !validWeight(weight) {
+			return nil, nil, false
+		}
+		words = append(words, word)
+		weights = append(weights, weight)
+	}
+	return words, weights, len(words) > 0
+}
+
+// validWeight reports whether weight can weigh a word: a positive, finite number.
+func validWeight(weight float64) bool {
+	return weight > 0 && !math.IsInf(weight, 0) && !math.IsNaN(weight)
+}
+
 // GetCurrentWordSet returns the currently selected word set.
 // Returns empty WordSet if none selected or library is empty.
 func (wl *WordLibrary) GetCurrentWordSet() WordSet {
@@ -149,20 +319,347 @@ func (wl *WordLibrary) Count() int {
 //
 // Returns empty string if no word set is selected or word set is empty.
 func (wl *WordLibrary) GenerateRandomWords(count int) string {
-	wordSet := wl.GetCurrentWordSet()
-	if len(wordSet.Words) == 0 {
+	return wl.randomWords(wl.GetCurrentWordSet(), count, nil, nil, "")
+}
+
+// GenerateRandomWordsFrom generates random words from the named word set
+// without changing the current selection. This is used for previews.
+//
+// Parameters:
+//   - name: name of the word set to sample from
+//   - count: number of words to generate
+//
+// Returns empty string if no word set with that name exists.
+func (wl *WordLibrary) GenerateRandomWordsFrom(name string, count int) string {
+	for _, wordSet := range wl.wordSets {
+		if wordSet.Name == name {
+			return wl.randomWords(wordSet, count, nil, nil, "")
+		}
+	}
+	return ""
+}
+
+// ContinueWords generates random words from the named word set like
+// GenerateRandomWordsFrom, to be appended to text. A sentence left open at the
+// end of text is carried on, so punctuation and capitals read as if the
+// words had been generated at once. Returns empty string if no word set with
+// that name exists.
+func (wl *WordLibrary) ContinueWords(name, text string, count int) string {
+	for _, wordSet := range wl.wordSets {
+		if wordSet.Name == name {
+			return wl.randomWords(wordSet, count, nil, nil, text)
+		}
+	}
+	return ""
+}
+
+// GenerateSeededWords generates words from the current word set like
+// GenerateRandomWords, but draws them from a source seeded with seed so the
+// same seed always yields the same words. Used for the daily challenge, so the
+// word case, length, sentence, number and hard-only settings don't apply:
+// everyone gets the same words on the same day.
+func (wl *WordLibrary) GenerateSeededWords(seed int64, count int) string {
+	return wl.randomWords(wl.GetCurrentWordSet(), count, rand.New(rand.NewSource(seed)), &neutralStyle, "")
+}
+
+// randomWords picks count random words (with replacement) from the given set,
+// honoring the minimum word length and the generation options of style, or of
+// the library's style if it is nil. Words are drawn from random, or from the
+// library's source if it is nil. The words continue the sentence left open at
+// the end of before ("" for a new text). Returns empty string if the word set
+// is empty. Safe to call from another goroutine.
+func (wl *WordLibrary) randomWords(wordSet WordSet, count int, random *rand.Rand, style *wordStyle, before string) string {
+	wl.mu.Lock()
+	defer wl.mu.Unlock()
+
+	if random == nil {
+		random = wl.rand
+	}
+	if style == nil {
+		style = &wl.style
+	}
+	candidates, weights := wordSet.Words, wordSet.Weights
+	if style.hardOnly {
+		candidates, weights = hardWords(candidates, weights)
+	}
+	candidates, weights = style.longEnough(candidates, weights)
+	if len(candidates) == 0 {
 		return ""
 	}
 
+	pick := func() string { return candidates[random.Intn(len(candidates))] }
+	if weights != nil {
+		cumulative := make([]float64, len(weights))
+		total := 0.0
+		for i, weight := range weights {
+			total += weight
+			cumulative[i] = total
+		}
+		pick = func() string {
+			idx := sort.SearchFloat64s(cumulative, random.Float64()*total)
+			return candidates[min(idx, len(candidates)-1)]
+		}
+	}
+
 	words := make([]string, count)
 	for i := range count {
-		words[i] = wordSet.Words[wl.rand.Intn(len(wordSet.Words))]
+		words[i] = style.applyCase(pick(), random)
 	}
+	style.injectNumbers(words, random)
+	style.shapeSentences(words, random, before)
 
 	return strings.Join(words, " ")
 }
 
+// shapeSentences adds the punctuation and capitals enabled by the generation
+// options to words in place. Marks are attached to the words, so the words stay
+// separated by single spaces. The first sentence continues the one left open
+// at the end of before.
+func (s *wordStyle) shapeSentences(words []string, random *rand.Rand, before string) {
+	opts := s.genOptions
+	if !opts.Punctuation && !opts.Capitalization {
+		return
+	}
+
+	sentenceStart, remaining := true, sentenceLength(random)
+	if opts.Punctuation {
+		sentenceStart, remaining = openSentence(before, random)
+	}
+	for i, word := range words {
+		if opts.Capitalization && (sentenceStart && opts.Punctuation || random.Float64() < capitalizeChance) {
+			word = capitalizeFirst(word)
+		}
+		sentenceStart = false
+
+		if opts.Punctuation {
+			remaining--
+			switch {
+			case remaining <= 0:
+				word += string(sentenceEndMarks[random.Intn(len(sentenceEndMarks))])
+				sentenceStart = true
+				remaining = sentenceLength(random)
+			case random.Float64() < pauseChance:
+				word += string(sentencePauseMarks[random.Intn(len(sentencePauseMarks))])
+			}
+		}
+		words[i] = word
+	}
+}
+
+// injectNumbers replaces some words with random numbers if the generation
+// options enable numbers. The numbers take the place of words, so the word
+// count stays the same.
+func (s *wordStyle) injectNumbers(words []string, random *rand.Rand) {
+	opts := s.genOptions
+	if !opts.Numbers {
+		return
+	}
+	chance := opts.NumberChance
+	if chance <= 0 {
+		chance = defaultNumberChance
+	}
+	for i := range words {
+		if random.Float64() < chance {
+			words[i] = randomNumber(random)
+		}
+	}
+}
+
+// randomNumber returns a number of one to maxNumberDigits digits without a
+// leading zero, e.g. "7", "42" or "2024".
+func randomNumber(random *rand.Rand) string {
+	digits := 1 + random.Intn(maxNumberDigits)
+	if digits == 1 {
+		return strconv.Itoa(random.Intn(10))
+	}
+	low := 1
+	for range digits - 1 {
+		low *= 10
+	}
+	return strconv.Itoa(low + random.Intn(9*low))
+}
+
+// openSentence returns the sentence state at the end of text: whether the next
+// word starts a sentence, and how many more words the sentence gets (at least
+// one, and no more than a sentence of maxSentenceWords words allows).
+func openSentence(text string, random *rand.Rand) (sentenceStart bool, remaining int) {
+	// Count the words after the last sentence end, from the back
+	words := 0
+	for rest := strings.TrimRight(text, " \n"); rest != ""; words++ {
+		i := strings.LastIndexAny(rest, " \n")
+		if strings.ContainsAny(rest[len(rest)-1:], sentenceEndMarks) {
+			break
+		}
+		if i < 0 {
+			rest = ""
+		} else {
+			rest = strings.TrimRight(rest[:i], " \n")
+		}
+	}
+	if words == 0 {
+		return true, sentenceLength(random)
+	}
+	return false, max(sentenceLength(random)-words, 1)
+}
+
+// sentenceLength returns a random sentence length in words.
+func sentenceLength(random *rand.Rand) int {
+	return minSentenceWords + random.Intn(maxSentenceWords-minSentenceWords+1)
+}
+
+// capitalizeFirst uppercases the first letter of word. Words without letters
+// are returned unchanged.
+func capitalizeFirst(word string) string {
+	runes := []rune(word)
+	for i, r := range runes {
+		if unicode.IsLetter(r) {
+			runes[i] = unicode.ToUpper(r)
+			return string(runes)
+		}
+	}
+	return word
+}
+
+// longEnough returns the words that are at least the minimum word length long,
+// along with their weights (nil if words has none). If none are, all words are
+// returned, so a set of short words stays usable.
+func (s *wordStyle) longEnough(words []string, weights []float64) ([]string, []float64) {
+	if s.minLength <= 0 {
+		return words, weights
+	}
+	return filterWords(words, weights, func(i int) bool {
+		return GraphemeCount(words[i]) >= s.minLength
+	})
+}
+
+// hardWords returns the words of a weighted word set that are heavier than the
+// lightest hardWeightQuantile of its words, along with their weights. Unweighted
+// words are returned unchanged.
+func hardWords(words []string, weights []float64) ([]string, []float64) {
+	if weights == nil {
+		return words, weights
+	}
+	sorted := slices.Clone(weights)
+	slices.Sort(sorted)
+	threshold := sorted[int(float64(len(sorted)-1)*hardWeightQuantile)]
+	return filterWords(words, weights, func(i int) bool {
+		return weights[i] > threshold
+	})
+}
+
+// filterWords returns the words, and their weights if there are any, for which
+// keep returns true. If it keeps none, all words are returned.
+func filterWords(words []string, weights []float64, keep func(i int) bool) ([]string, []float64) {
+	var kept []string
+	var keptWeights []float64
+	for i, word := range words {
+		if !keep(i) {
+			continue
+		}
+		kept = append(kept, word)
+		if weights != nil {
+			keptWeights = append(keptWeights, weights[i])
+		}
+	}
+	if len(kept) == 0 {
+		return words, weights
+	}
+	return kept, keptWeights
+}
+
+// SetMinWordLength sets the minimum length of generated words, counted in
+// user-perceived characters (grapheme clusters) rather than runes.
+// 0 allows words of any length.
+func (wl *WordLibrary) SetMinWordLength(length int) {
+	wl.mu.Lock()
+	defer wl.mu.Unlock()
+	wl.style.minLength = max(length, 0)
+}
+
+// GetMinWordLength returns the minimum length of generated words (0 for any length).
+func (wl *WordLibrary) GetMinWordLength() int {
+	wl.mu.Lock()
+	defer wl.mu.Unlock()
+	return wl.style.minLength
+}
+
+// SetHardOnly sets whether words from weighted word sets are limited to the
+// heaviest ones (see hardWords). Unweighted word sets are not affected.
+func (wl *WordLibrary) SetHardOnly(hardOnly bool) {
+	wl.mu.Lock()
+	defer wl.mu.Unlock()
+	wl.style.hardOnly = hardOnly
+}
+
+// GetHardOnly reports whether words are limited to the heaviest ones.
+func (wl *WordLibrary) GetHardOnly() bool {
+	wl.mu.Lock()
+	defer wl.mu.Unlock()
+	return wl.style.hardOnly
+}
+
+// SetWordCase sets the case transform applied to generated words.
+func (wl *WordLibrary) SetWordCase(wordCase WordCase) {
+	wl.mu.Lock()
+	defer wl.mu.Unlock()
+	wl.style.wordCase = wordCase
+}
+
+// GetWordCase returns the case transform applied to generated words.
+func (wl *WordLibrary) GetWordCase() WordCase {
+	wl.mu.Lock()
+	defer wl.mu.Unlock()
+	return wl.style.wordCase
+}
+
+// SetGenOptions sets the punctuation and capitals added to generated words.
+func (wl *WordLibrary) SetGenOptions(opts WordGenOptions) {
+	wl.mu.Lock()
+	defer wl.mu.Unlock()
+	wl.style.genOptions = opts
+}
+
+// GetGenOptions returns the punctuation and capitals added to generated words.
+func (wl *WordLibrary) GetGenOptions() WordGenOptions {
+	wl.mu.Lock()
+	defer wl.mu.Unlock()
+	return wl.style.genOptions
+}
+
+// applyCase uppercases the first or a random letter of word, depending on the
+// word case setting, picking random letters from random. Words without letters
+// are returned unchanged.
+func (s *wordStyle) applyCase(word string, random *rand.Rand) string {
+	runes := []rune(word)
+
+	var letters []int
+	for i, r := range runes {
+		if unicode.IsLetter(r) {
+			letters = append(letters, i)
+		}
+	}
+	if len(letters) == 0 {
+		return word
+	}
+
+	switch s.wordCase {
+	case WordCaseCapitalized:
+		runes[letters[0]] = unicode.ToUpper(runes[letters[0]])
+	case WordCaseRandomCapitals:
+		i := letters[random.Intn(len(letters))]
+		runes[i] = unicode.ToUpper(runes[i])
+	default:
+		return word
+	}
+	return string(runes)
+}
+
 // HasWordSets returns true if the library has at least one word set.
 func (wl *WordLibrary) HasWordSets() bool {
 	return len(wl.wordSets) > 0
 }
+
+// GetWordsDir returns the directory the word sets were loaded from.
+func (wl *WordLibrary) GetWordsDir() string {
+	return wl.wordsDir
+}
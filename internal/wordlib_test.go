@@ -0,0 +1,328 @@
+package internal
+
+import (
+	"math/rand"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"unicode"
+)
+
+// newTestWordLibrary creates a word library with a single word set.
+func newTestWordLibrary(t *testing.T, words string) *WordLibrary {
+	t.Helper()
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "test.txt"), []byte(words), 0644); err != nil {
+		t.Fatal(err)
+	}
+	wl := NewWordLibrary(dir)
+	if !wl.SelectByName("test") {
+		t.Fatal("Expected test word set to load")
+	}
+	return wl
+}
+
+func TestGenerateCapitalizedWords(t *testing.T) {
+	wl := newTestWordLibrary(t, "apple banana cherry 'quoted")
+	wl.SetWordCase(WordCaseCapitalized)
+
+	for _, word := range strings.Fields(wl.GenerateRandomWords(50)) {
+		first := []rune(strings.TrimLeft(word, "'"))[0]
+		if !unicode.IsUpper(first) {
+			t.Errorf("Expected %q to start with an uppercase letter", word)
+		}
+		if rest := strings.TrimLeft(word, "'")[1:]; rest != strings.ToLower(rest) {
+			t.Errorf("Expected only the first letter of %q to be uppercase", word)
+		}
+	}
+}
+
+func TestGenerateRandomCapitalWords(t *testing.T) {
+	wl := newTestWordLibrary(t, "apple banana cherry")
+	wl.SetWordCase(WordCaseRandomCapitals)
+
+	for _, word := range strings.Fields(wl.GenerateRandomWords(50)) {
+		upper := 0
+		for _, r := range word {
+			if unicode.IsUpper(r) {
+				upper++
+			}
+		}
+		if upper != 1 {
+			t.Errorf("Expected exactly one uppercase letter in %q", word)
+		}
+	}
+}
+
+// seededWords generates count words like GenerateRandomWords, with the
+// library's options, but from a fixed seed so the output is reproducible.
+func seededWords(wl *WordLibrary, count int) string {
+	return wl.randomWords(wl.GetCurrentWordSet(), count, rand.New(rand.NewSource(1)), nil, "")
+}
+
+func TestGenerateWordsAsIs(t *testing.T) {
+	wl := newTestWordLibrary(t, "apple banana cherry")
+
+	words := wl.GenerateRandomWords(20)
+	if words != strings.ToLower(words) {
+		t.Errorf("Expected words unchanged by default, got %q", words)
+	}
+}
+
+func TestGenerateWordsWithPunctuationAndCapitalization(t *testing.T) {
+	wl := newTestWordLibrary(t, "apple banana cherry")
+	wl.SetGenOptions(WordGenOptions{Punctuation: true, Capitalization: true})
+
+	generated := seededWords(wl, 100)
+	words := strings.Split(generated, " ")
+	if len(words) != 100 || strings.Join(strings.Fields(generated), " ") != generated {
+		t.Fatalf("Expected 100 words joined by single spaces, got %q", generated)
+	}
+
+	sentenceStart := true
+	sentences := 0
+	for i, word := range words {
+		bare := strings.TrimRight(word, ".,;?!")
+		if bare != "apple" && bare != "banana" && bare != "cherry" && bare != "Apple" && bare != "Banana" && bare != "Cherry" {
+			t.Fatalf("Unexpected word %q", word)
+		}
+		if len(word)-len(bare) > 1 {
+			t.Errorf("Expected at most one mark after %q", word)
+		}
+		if sentenceStart && !unicode.IsUpper([]rune(word)[0]) {
+			t.Errorf("Expected word %d (%q) to start a sentence with a capital", i, word)
+		}
+		sentenceStart = strings.ContainsAny(word, ".?!")
+		if sentenceStart {
+			sentences++
+		}
+	}
+	if sentences < 100/maxSentenceWords {
+		t.Errorf("Expected a sentence end at least every %d words, got %d sentences", maxSentenceWords, sentences)
+	}
+}
+
+func TestContinueWordsCarriesSentences(t *testing.T) {
+	wl := newTestWordLibrary(t, "apple banana cherry")
+	wl.SetGenOptions(WordGenOptions{Punctuation: true, Capitalization: true})
+
+	// Chunks of a few words end mid-sentence most of the time
+	text := wl.GenerateRandomWords(3)
+	for range 60 {
+		text += " " + wl.ContinueWords("test", text, 3)
+	}
+
+	words := strings.Fields(text)
+	sentenceStart := true
+	length, sentences := 0, 0
+	for i, word := range words {
+		if sentenceStart && !unicode.IsUpper([]rune(word)[0]) {
+			t.Errorf("Expected word %d (%q) to start a sentence with a capital", i, word)
+		}
+		length++
+		sentenceStart = strings.ContainsAny(word, ".?!")
+		if sentenceStart {
+			if length < minSentenceWords || length > maxSentenceWords {
+				t.Errorf("Expected sentences of %d to %d words across chunks, got %d before word %d", minSentenceWords, maxSentenceWords, length, i)
+			}
+			length = 0
+			sentences++
+		}
+	}
+	if sentences < len(words)/maxSentenceWords {
+		t.Errorf("Expected a sentence end at least every %d words across chunks, got %d sentences in %d words", maxSentenceWords, sentences, len(words))
+	}
+}
+
+func TestOpenSentence(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	tests := []struct {
+		text      string
+		wantStart bool
+		wantWords int // Words already in the open sentence
+	}{
+		{"", true, 0},
+		{"Apple banana.", true, 0},
+		{"Apple banana. Cherry apple ", false, 2},
+		{"apple", false, 1},
+	}
+	for _, tt := range tests {
+		start, remaining := openSentence(tt.text, random)
+		if start != tt.wantStart || remaining < 1 || tt.wantWords+remaining > maxSentenceWords {
+			t.Errorf("openSentence(%q) = %v, %d; want start %v and at most %d more words", tt.text, start, remaining, tt.wantStart, maxSentenceWords-tt.wantWords)
+		}
+	}
+}
+
+func TestCapitalizationAddsToWordCase(t *testing.T) {
+	wl := newTestWordLibrary(t, "apple banana cherry")
+	wl.SetWordCase(WordCaseCapitalized)
+	wl.SetGenOptions(WordGenOptions{Punctuation: true, Capitalization: true})
+
+	for _, word := range strings.Fields(seededWords(wl, 100)) {
+		if !unicode.IsUpper([]rune(word)[0]) {
+			t.Errorf("Expected every word to keep its capital, got %q", word)
+		}
+	}
+}
+
+func TestGenerateWordsWithCapitalizationOnly(t *testing.T) {
+	wl := newTestWordLibrary(t, "apple banana cherry")
+	wl.SetGenOptions(WordGenOptions{Capitalization: true})
+
+	generated := seededWords(wl, 200)
+	if strings.ContainsAny(generated, ".,;?!") {
+		t.Errorf("Expected no punctuation without the punctuation option, got %q", generated)
+	}
+	capitalized := 0
+	for _, word := range strings.Fields(generated) {
+		if unicode.IsUpper([]rune(word)[0]) {
+			capitalized++
+		}
+	}
+	if capitalized == 0 || capitalized == 200 {
+		t.Errorf("Expected only some words to be capitalized, got %d of 200", capitalized)
+	}
+}
+
+func TestGenerateWordsWithNumbers(t *testing.T) {
+	wl := newTestWordLibrary(t, "apple banana cherry")
+	wl.SetGenOptions(WordGenOptions{Numbers: true, NumberChance: 0.5})
+
+	words := strings.Fields(seededWords(wl, 200))
+	if len(words) != 200 {
+		t.Fatalf("Expected numbers to replace words, got %d words", len(words))
+	}
+	numbers := 0
+	for _, word := range words {
+		if word == "apple" || word == "banana" || word == "cherry" {
+			continue
+		}
+		n, err := strconv.Atoi(word)
+		if err != nil || n < 0 || len(word) > maxNumberDigits || len(word) > 1 && word[0] == '0' {
+			t.Fatalf("Unexpected word %q", word)
+		}
+		numbers++
+	}
+	if numbers < 50 || numbers > 150 {
+		t.Errorf("Expected about half of the words to be numbers, got %d of 200", numbers)
+	}
+
+	wl.SetGenOptions(WordGenOptions{NumberChance: 1})
+	if generated := seededWords(wl, 50); strings.ContainsAny(generated, "0123456789") {
+		t.Errorf("Expected no numbers without the numbers option, got %q", generated)
+	}
+}
+
+func TestLoadJSONWordSet(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"basic.txt":     "one two\nthree",
+		"german.json":   `{"name": "German Basics", "language": "German", "difficulty": "easy", "words": ["eins", "zwei drei", ""]}`,
+		"plain.json":    `{"words": ["alpha", "beta"]}`,
+		"broken.json":   `{"words": [`,
+		"empty.json":    `{"name": "Empty", "words": []}`,
+		"weighted.json": `{"name": "Weighted", "words": ["easy", "hard"], "weights": [1, 9]}`,
+		"uneven.json":   `{"words": ["easy", "hard"], "weights": [1]}`,
+		"grouped.json":  `{"words": ["easy", "very hard"], "weights": [1, 9]}`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	wl := NewWordLibrary(dir)
+	if wl.Count() != 4 {
+		t.Fatalf("Expected 4 word sets (broken, empty and mismatched weights skipped), got %d", wl.Count())
+	}
+	if !wl.SelectByName("Weighted") || !slices.Equal(wl.GetCurrentWordSet().Weights, []float64{1, 9}) {
+		t.Errorf("Expected the weights of a JSON word set, got %v", wl.GetCurrentWordSet().Weights)
+	}
+
+	if !wl.SelectByName("German Basics") {
+		t.Fatal("Expected the JSON name to be used as the word set name")
+	}
+	german := wl.GetCurrentWordSet()
+	if german.Language != "German" || german.Difficulty != "easy" {
+		t.Errorf("Expected metadata German/easy, got %q/%q", german.Language, german.Difficulty)
+	}
+	if strings.Join(german.Words, " ") != "eins zwei drei" {
+		t.Errorf("Expected words split like text lists, got %v", german.Words)
+	}
+	if got := german.Describe(); got != "Practice random words from 'German Basics' (German, easy)" {
+		t.Errorf("Unexpected description %q", got)
+	}
+
+	// Without a name the filename is used; without metadata the description is plain
+	if !wl.SelectByName("plain") {
+		t.Fatal("Expected a JSON word set without a name to use its filename")
+	}
+	if got := wl.GetCurrentWordSet().Describe(); got != "Practice random words from 'plain'" {
+		t.Errorf("Unexpected description %q", got)
+	}
+}
+
+func TestGenerateWordsMinLength(t *testing.T) {
+	wl := newTestWordLibrary(t, "a be cat door eagle")
+	wl.SetMinWordLength(4)
+
+	for _, word := range strings.Fields(wl.GenerateRandomWords(50)) {
+		if len(word) < 4 {
+			t.Fatalf("Expected only words with at least 4 letters, got %q", word)
+		}
+	}
+
+	// No word is long enough: fall back to the whole set
+	wl.SetMinWordLength(10)
+	words := strings.Fields(wl.GenerateRandomWords(50))
+	if len(words) != 50 {
+		t.Fatalf("Expected 50 words from the whole set, got %d", len(words))
+	}
+}
+
+func TestParseWeightedWordList(t *testing.T) {
+	words, weights, ok := parseWeightedWordList("the, 1\n\nrhythm,3.5\n")
+	if !ok || strings.Join(words, " ") != "the rhythm" || len(weights) != 2 || weights[0] != 1 || weights[1] != 3.5 {
+		t.Errorf("Expected two weighted words, got %v %v %v", words, weights, ok)
+	}
+	for _, text := range []string{"one two\nthree", "a,1\nb", "a,0", "a,-2", "a,heavy", "a,NaN", "a,inf", ",,\n;;", "two words,1", ""} {
+		if _, _, ok := parseWeightedWordList(text); ok {
+			t.Errorf("Expected %q to be left to the plain word list parser", text)
+		}
+	}
+}
+
+func TestGenerateWeightedWords(t *testing.T) {
+	wl := newTestWordLibrary(t, "easy,1\nhard,9")
+	if weights := wl.GetCurrentWordSet().Weights; len(weights) != 2 {
+		t.Fatalf("Expected the weights to be loaded, got %v", weights)
+	}
+
+	counts := map[string]int{}
+	for _, word := range strings.Fields(seededWords(wl, 1000)) {
+		counts[word]++
+	}
+	if counts["easy"]+counts["hard"] != 1000 || counts["hard"] < 850 || counts["easy"] < 50 {
+		t.Errorf("Expected about 9 hard words for every easy one, got %v", counts)
+	}
+
+	wl.SetHardOnly(true)
+	if words := seededWords(wl, 100); strings.Contains(words, "easy") {
+		t.Errorf("Expected only the heavier words with hard only, got %q", words)
+	}
+}
+
+func TestHardOnlyIgnoresUnweightedWords(t *testing.T) {
+	wl := newTestWordLibrary(t, "apple banana")
+	wl.SetHardOnly(true)
+
+	words := seededWords(wl, 100)
+	if !strings.Contains(words, "apple") || !strings.Contains(words, "banana") {
+		t.Errorf("Expected all words of an unweighted set, got %q", words)
+	}
+}
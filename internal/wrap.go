@@ -0,0 +1,138 @@
+package internal
+
+// WrappedLine is one line of wrapped text together with the index (in runes)
+// where it starts in the original text.
+type WrappedLine struct {
+	Start int    // Index of the line's first character in the text
+	Text  string // Line content, including a trailing newline if it has one
+}
+
+// wrapText breaks text into lines that fit within maxWidth characters.
+// Respects explicit newlines and attempts to break at word boundaries.
+func wrapText(text string, maxWidth int) []string {
+	runes := []rune(text)
+
+	var lines []string
+	for start := 0; start < len(runes); {
+		end := nextLineEnd(runes, start, maxWidth)
+		lines = append(lines, string(runes[start:end]))
+		start = end
+	}
+	return lines
+}
+
+// WrapWindow wraps only part of the text: up to maxLines lines beginning at
+// startChar, which must be the start of a wrapped line (e.g. 0, the character
+// after a newline, or a Start returned by an earlier wrap). The result equals
+// the corresponding lines of a full wrap, without wrapping the rest of the text.
+func WrapWindow(text string, maxWidth, startChar, maxLines int) []WrappedLine {
+	return wrapRunesWindow([]rune(text), maxWidth, startChar, maxLines)
+}
+
+// wrapRunesWindow is WrapWindow for text that is already a rune slice.
+func wrapRunesWindow(runes []rune, maxWidth, startChar, maxLines int) []WrappedLine {
+	var lines []WrappedLine
+	for start := max(startChar, 0); start < len(runes) && len(lines) < maxLines; {
+		end := nextLineEnd(runes, start, maxWidth)
+		lines = append(lines, WrappedLine{Start: start, Text: string(runes[start:end])})
+		start = end
+	}
+	return lines
+}
+
+// wrappedLineStarts returns the index where each wrapped line begins. It is
+// much cheaper than wrapText for long texts since no line strings are built.
+func wrappedLineStarts(runes []rune, maxWidth int) []int {
+	var starts []int
+	for start := 0; start < len(runes); start = nextLineEnd(runes, start, maxWidth) {
+		starts = append(starts, start)
+	}
+	return starts
+}
+
+// textLayout is the wrapped layout of a text at one width.
+type textLayout struct {
+	starts      []int // Index where each wrapped line begins
+	sourceLines []int // Line of the text (from 1) each wrapped line is part of
+	longest     int   // Characters in the longest line, without its newline
+}
+
+// newTextLayout wraps runes at maxWidth.
+func newTextLayout(runes []rune, maxWidth int) textLayout {
+	layout := textLayout{starts: wrappedLineStarts(runes, maxWidth)}
+	layout.sourceLines = make([]int, len(layout.starts))
+	sourceLine := 1
+	for i, start := range layout.starts {
+		end := len(runes)
+		if i+1 < len(layout.starts) {
+			end = layout.starts[i+1]
+		}
+		lineLen := end - start
+		if runes[end-1] == '\n' {
+			lineLen--
+		}
+		layout.longest = max(layout.longest, lineLen)
+
+		if start > 0 && runes[start-1] == '\n' {
+			sourceLine++
+		}
+		layout.sourceLines[i] = sourceLine
+	}
+	if layout.starts == nil {
+		layout.starts = []int{} // Tells cached empty texts from no cached text
+	}
+	return layout
+}
+
+// layoutCache keeps the layout of the last wrapped text, so the typing screen
+// only walks the whole text again when the text or the width changes.
+type layoutCache struct {
+	runes    []rune
+	maxWidth int
+	layout   textLayout
+}
+
+// get returns the layout of runes wrapped at maxWidth. Texts are told apart by
+// their backing array and length, since the typing test replaces its rune
+// slices when the text changes instead of editing them.
+func (c *layoutCache) get(runes []rune, maxWidth int) textLayout {
+	if c.layout.starts != nil && c.maxWidth == maxWidth && sameRunes(c.runes, runes) {
+		return c.layout
+	}
+
+	c.runes, c.maxWidth = runes, maxWidth
+	c.layout = newTextLayout(runes, maxWidth)
+	return c.layout
+}
+
+// sameRunes reports whether a and b are the same slice of the same array.
+func sameRunes(a, b []rune) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	return len(a) == 0 || &a[0] == &b[0]
+}
+
+// nextLineEnd returns the index just past the wrapped line beginning at start.
+// A line ends after a newline, or once it holds maxWidth characters and more
+// follow; it is then broken after its last space, or at maxWidth if it has none.
+// Such a break moves to a grapheme cluster boundary, so a syllable made of
+// several runes is never split across lines.
+func nextLineEnd(runes []rune, start, maxWidth int) int {
+	maxWidth = max(maxWidth, 1)
+
+	for i := start; i < len(runes); i++ {
+		if runes[i] == '\n' {
+			return i + 1
+		}
+		if i-start >= maxWidth {
+			for j := i - 1; j >= start; j-- {
+				if runes[j] == ' ' {
+					return j + 1
+				}
+			}
+			return clusterBreak(runes, start, i)
+		}
+	}
+	return len(runes)
+}
@@ -0,0 +1,115 @@
+package internal
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestWrapWindowMatchesFullWrap(t *testing.T) {
+	texts := map[string]string{
+		"paragraphs":    "the quick brown fox jumps over the lazy dog\n\nand keeps running far away\nend",
+		"single line":   strings.Repeat("lorem ipsum dolor sit amet ", 200),
+		"no spaces":     strings.Repeat("x", 95),
+		"long words":    "a " + strings.Repeat("y", 30) + " b " + strings.Repeat("z", 25) + "\n",
+		"trailing line": "one two three four five six seven\n",
+	}
+
+	for name, text := range texts {
+		t.Run(name, func(t *testing.T) {
+			const width = 12
+			full := wrapText(text, width)
+
+			// Walk the full wrap and check every window starting at each line
+			start := 0
+			for i := range full {
+				window := WrapWindow(text, width, start, 3)
+				want := full[i:min(i+3, len(full))]
+				if len(window) != len(want) {
+					t.Fatalf("line %d: expected %d lines, got %d", i, len(want), len(window))
+				}
+				lineStart := start
+				for j, line := range window {
+					if line.Text != want[j] || line.Start != lineStart {
+						t.Fatalf("line %d: expected %q at %d, got %q at %d", i+j, want[j], lineStart, line.Text, line.Start)
+					}
+					lineStart += len([]rune(want[j]))
+				}
+				start += len([]rune(full[i]))
+			}
+		})
+	}
+}
+
+func TestWrapWindowBounds(t *testing.T) {
+	text := "aaa bbb ccc ddd"
+
+	if got := WrapWindow(text, 4, len([]rune(text)), 5); len(got) != 0 {
+		t.Errorf("Expected no lines past the end, got %v", got)
+	}
+	if got := WrapWindow(text, 4, 0, 0); len(got) != 0 {
+		t.Errorf("Expected no lines for maxLines 0, got %v", got)
+	}
+	if got := WrapWindow(text, 4, 0, 100); len(got) != 4 {
+		t.Errorf("Expected all 4 lines, got %v", got)
+	}
+}
+
+func TestWrappedLineStarts(t *testing.T) {
+	text := strings.Repeat("word ", 50) + "\nlast"
+	lines := wrapText(text, 17)
+	starts := wrappedLineStarts([]rune(text), 17)
+
+	if len(starts) != len(lines) {
+		t.Fatalf("Expected %d line starts, got %d", len(lines), len(starts))
+	}
+	pos := 0
+	for i, line := range lines {
+		if starts[i] != pos {
+			t.Errorf("line %d: expected start %d, got %d", i, pos, starts[i])
+		}
+		pos += len([]rune(line))
+	}
+}
+
+func TestCalculateCursorLine(t *testing.T) {
+	text := "aaa bbb\nccc ddd eee"
+	// Lines at width 8: "aaa bbb\n", "ccc ddd ", "eee"
+	tests := []struct {
+		cursor int
+		want   int
+	}{
+		{0, 0}, {7, 0}, {8, 1}, {15, 1}, {16, 2}, {19, 2}, {100, 2},
+	}
+	for _, tt := range tests {
+		if got := CalculateCursorLine(text, tt.cursor, 8); got != tt.want {
+			t.Errorf("cursor %d: expected line %d, got %d", tt.cursor, tt.want, got)
+		}
+	}
+}
+
+func TestLayoutCache(t *testing.T) {
+	runes := []rune("aaa bbb\nccc ddd eee")
+	var cache layoutCache
+
+	layout := cache.get(runes, 8) // "aaa bbb\n", "ccc ddd ", "eee"
+	if !slices.Equal(layout.starts, []int{0, 8, 16}) || layout.longest != 8 {
+		t.Fatalf("Expected starts [0 8 16] and longest line 8, got %v and %d", layout.starts, layout.longest)
+	}
+	if !slices.Equal(layout.sourceLines, []int{1, 2, 2}) {
+		t.Errorf("Expected the wrapped lines on text lines [1 2 2], got %v", layout.sourceLines)
+	}
+	if again := cache.get(runes, 8); &again.starts[0] != &layout.starts[0] {
+		t.Error("Expected the cached layout for the same text and width")
+	}
+	wider := cache.get(runes, 12)
+	if !slices.Equal(wider.starts, []int{0, 8}) {
+		t.Errorf("Expected a new layout for another width, got %v", wider.starts)
+	}
+	if other := cache.get([]rune("aaa bbb\nccc ddd eee"), 12); &other.starts[0] == &wider.starts[0] {
+		t.Error("Expected a new layout for another text")
+	}
+	if empty := cache.get(nil, 12); len(empty.starts) != 0 {
+		t.Errorf("Expected no lines for an empty text, got %v", empty.starts)
+	}
+}
@@ -0,0 +1,141 @@
+package internal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"sync"
+)
+
+// xpPerLevelStep is how much more XP each level needs than the one before:
+// level 2 takes 100 XP, level 3 another 200, level 4 another 300 and so on.
+const xpPerLevelStep = 100
+
+// Profile is the long-term progression stored across sessions.
+type Profile struct {
+	XP int `json:"xp"` // Total XP earned
+}
+
+// Level returns the level reached with the profile's XP.
+func (p Profile) Level() int {
+	return LevelForXP(p.XP)
+}
+
+// XPForLevel returns the total XP needed to reach a level. Level 1 needs none.
+func XPForLevel(level int) int {
+	if level <= 1 {
+		return 0
+	}
+	return xpPerLevelStep * level * (level - 1) / 2
+}
+
+// LevelForXP returns the highest level whose XP requirement is met.
+func LevelForXP(xp int) int {
+	level := 1
+	for XPForLevel(level+1) <= xp {
+		level++
+	}
+	return level
+}
+
+// TestXP returns the XP awarded for a completed test. Typed words are the base,
+// scaled by speed relative to 40 WPM and by the square of the accuracy so
+// careless typing earns much less.
+func TestXP(wpm, accuracy float64, keystrokes int) int {
+	if wpm <= 0 || accuracy <= 0 || keystrokes <= 0 {
+		return 0
+	}
+	words := float64(keystrokes) / 5
+	accuracyFactor := (accuracy / 100) * (accuracy / 100)
+	return int(math.Round(words * (wpm / 40) * accuracyFactor))
+}
+
+// XPAward describes the outcome of adding XP to the profile.
+type XPAward struct {
+	Gained    int  // XP earned by the test
+	Level     int  // Level after the award
+	LeveledUp bool // The award reached at least one new level
+}
+
+// XPManager handles loading and updating the XP profile.
+type XPManager struct {
+	profilePath string
+	mu          sync.Mutex // Serializes read-modify-write cycles of the profile
+}
+
+// NewXPManager creates a new XP manager.
+// It uses the platform-appropriate config directory.
+func NewXPManager() (*XPManager, error) {
+	profilePath, err := GetProfilePath()
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve profile path: %w", err)
+	}
+
+	return &XPManager{
+		profilePath: profilePath,
+	}, nil
+}
+
+// Load reads the profile. A missing file yields an empty profile at level 1.
+// If the file is corrupt, the backup kept by the last save is used instead; if
+// that is unusable too, an empty profile is returned along with an error.
+func (m *XPManager) Load() (Profile, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.load()
+}
+
+// Award adds XP to the profile and reports whether a new level was reached.
+// A corrupt profile is recovered as described in Load and then overwritten.
+func (m *XPManager) Award(gained int) (XPAward, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	profile, loadErr := m.load()
+	before := profile.Level()
+	profile.XP += gained
+	award := XPAward{
+		Gained:    gained,
+		Level:     profile.Level(),
+		LeveledUp: profile.Level() > before,
+	}
+
+	data, err := json.MarshalIndent(profile, "", "  ")
+	if err != nil {
+		return award, fmt.Errorf("failed to marshal profile: %w", err)
+	}
+	if err := writeFileAtomic(m.profilePath, data, 0644); err != nil {
+		return award, fmt.Errorf("failed to save profile: %w", err)
+	}
+	return award, loadErr
+}
+
+// load implements Load; the caller must hold m.mu.
+func (m *XPManager) load() (Profile, error) {
+	profile, err := readProfile(m.profilePath)
+	if err == nil || errors.Is(err, os.ErrNotExist) {
+		return profile, nil
+	}
+
+	backup, backupErr := readProfile(m.profilePath + ".bak")
+	if backupErr == nil {
+		return backup, fmt.Errorf("profile corrupt, restored from backup: %w", err)
+	}
+	return Profile{}, fmt.Errorf("profile corrupt, starting over: %w", err)
+}
+
+// readProfile reads and parses one profile file.
+func readProfile(path string) (Profile, error) {
+	var profile Profile
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return profile, err
+	}
+	if err := json.Unmarshal(data, &profile); err != nil {
+		return Profile{}, err
+	}
+	return profile, nil
+}
@@ -0,0 +1,85 @@
+package internal
+
+import "testing"
+
+func TestLevelForXP(t *testing.T) {
+	tests := []struct {
+		xp    int
+		level int
+	}{
+		{0, 1},
+		{99, 1},
+		{100, 2},
+		{299, 2},
+		{300, 3},
+		{600, 4},
+		{2100, 7},
+	}
+
+	for _, tt := range tests {
+		if got := LevelForXP(tt.xp); got != tt.level {
+			t.Errorf("LevelForXP(%d) = %d, want %d", tt.xp, got, tt.level)
+		}
+	}
+
+	for level := 1; level <= 20; level++ {
+		if got := LevelForXP(XPForLevel(level)); got != level {
+			t.Errorf("Expected exactly XPForLevel(%d) XP to be level %d, got %d", level, level, got)
+		}
+	}
+}
+
+func TestTestXP(t *testing.T) {
+	// 250 keystrokes are 50 words; 40 WPM at 100% earns one XP per word
+	if got := TestXP(40, 100, 250); got != 50 {
+		t.Errorf("Expected 50 XP, got %d", got)
+	}
+	if faster := TestXP(80, 100, 250); faster != 100 {
+		t.Errorf("Expected double XP at double speed, got %d", faster)
+	}
+	if sloppy := TestXP(40, 50, 250); sloppy != 13 {
+		t.Errorf("Expected accuracy to scale XP quadratically, got %d", sloppy)
+	}
+	if got := TestXP(0, 0, 0); got != 0 {
+		t.Errorf("Expected no XP for an empty test, got %d", got)
+	}
+}
+
+func TestXPManagerLevelUp(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	manager, err := NewXPManager()
+	if err != nil {
+		t.Fatalf("failed to create XP manager: %v", err)
+	}
+
+	award, err := manager.Award(99)
+	if err != nil {
+		t.Fatalf("failed to award XP: %v", err)
+	}
+	if award.Level != 1 || award.LeveledUp {
+		t.Errorf("Expected to stay at level 1 just below the boundary, got %+v", award)
+	}
+
+	award, err = manager.Award(1)
+	if err != nil {
+		t.Fatalf("failed to award XP: %v", err)
+	}
+	if award.Level != 2 || !award.LeveledUp {
+		t.Errorf("Expected a level up to 2 on the boundary, got %+v", award)
+	}
+
+	award, _ = manager.Award(10)
+	if award.LeveledUp {
+		t.Errorf("Expected no level up within level 2, got %+v", award)
+	}
+
+	// The profile persists for the next session
+	profile, err := manager.Load()
+	if err != nil {
+		t.Fatalf("failed to load profile: %v", err)
+	}
+	if profile.XP != 110 || profile.Level() != 2 {
+		t.Errorf("Expected 110 XP at level 2, got %+v", profile)
+	}
+}